@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"wealthlist/config"
+	"wealthlist/internal/cache"
+	"wealthlist/internal/logger"
+)
+
+// openCache returns the response cache configured by cfg, or nil when
+// caching is off. An unreachable Redis is not fatal: reads go to the
+// database until it comes back.
+func openCache(ctx context.Context, cfg config.CacheConfig, log *slog.Logger) *cache.Cache {
+	switch cfg.Backend {
+	case "memory":
+		return cache.New(cache.NewMemory(cfg.Size), cfg.TTL, log)
+	case "redis":
+		store := cache.NewRedis(cache.RedisOptions{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+			PoolSize: cfg.Redis.PoolSize,
+			Timeout:  cfg.Redis.Timeout,
+		})
+		if err := store.Ping(ctx); err != nil {
+			log.Warn("Redis is unreachable; serving without cache until it is back", slog.String("addr", cfg.Redis.Addr), logger.Err(err))
+		}
+		return cache.New(store, cfg.TTL, log)
+	}
+	return nil
+}
+
+// invalidateSharedCache drops the responses cached by running servers after
+// a command changed data behind their back. Only the redis backend is
+// shared; memory caches expire within CACHE_TTL.
+func invalidateSharedCache(ctx context.Context, rt *runtime) {
+	if rt.cfg.Cache.Backend != "redis" {
+		return
+	}
+	if c := openCache(ctx, rt.cfg.Cache, rt.log); c != nil {
+		c.Invalidate(ctx)
+		c.Close()
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"wealthlist/config"
+
+	"github.com/urfave/cli/v2"
+)
+
+func configCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "inspect the effective configuration",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "print",
+				Usage: "print the merged configuration with secrets redacted",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "output format: env or yaml",
+						Value: config.FormatYAML,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					cfg, err := config.Load(configSources(c))
+					if err != nil {
+						return err
+					}
+					return cfg.Write(c.App.Writer, c.String("format"))
+				},
+			},
+			{
+				Name:  "validate",
+				Usage: "load the configuration and report every problem",
+				Action: func(c *cli.Context) error {
+					if _, err := config.Load(configSources(c)); err != nil {
+						return err
+					}
+					fmt.Fprintln(c.App.Writer, "configuration is valid")
+					return nil
+				},
+			},
+		},
+	}
+}
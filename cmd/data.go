@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"wealthlist/internal/exchange"
+	"wealthlist/internal/models"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/service"
+
+	"github.com/urfave/cli/v2"
+)
+
+const exportPageSize = 500
+
+func seedCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "seed",
+		Usage: "generate sample millionaires with photos and net worth histories",
+		Description: "The same --seed always generates the same people. Millionaires that already exist are\n" +
+			"skipped, so repeating a run changes nothing and a larger --count adds the difference.",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "count", Aliases: []string{"n"}, Usage: "number of millionaires", Value: 100},
+			&cli.Uint64Flag{Name: "seed", Usage: "random seed", Value: 1},
+			&cli.BoolFlag{Name: "reset", Usage: "delete all millionaires and their photos first, and empty featured homepage sections"},
+			&cli.BoolFlag{Name: "photos", Usage: "generate placeholder photos", Value: true},
+		},
+		Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+			if c.Int("count") < 0 {
+				return cli.Exit("--count must not be negative", exitUsage)
+			}
+			if c.Bool("reset") && rt.cfg.Env == "prod" {
+				return cli.Exit("refusing to reset a prod database", exitUsage)
+			}
+
+			uow := repo.NewUnitOfWork(rt.db, rt.log)
+			photoService := service.NewPhotoService(repo.NewPhotoRepo(repo.Direct(rt.db), rt.log), uow, rt.log)
+			seedService := service.NewSeedService(uow, photoService, rt.log)
+
+			report, err := seedService.Seed(c.Context, service.SeedOptions{
+				Seed:   c.Uint64("seed"),
+				Count:  c.Int("count"),
+				Reset:  c.Bool("reset"),
+				Photos: c.Bool("photos"),
+			})
+			if report.Deleted > 0 {
+				fmt.Fprintf(c.App.Writer, "deleted %d millionaires\n", report.Deleted)
+			}
+			fmt.Fprintf(c.App.Writer, "created %d millionaires, %d already existed\n", report.Created, report.Skipped)
+			if report.Deleted > 0 || report.Created > 0 {
+				invalidateSharedCache(c.Context, rt)
+			}
+			if err != nil {
+				return cli.Exit(err.Error(), exitFailure)
+			}
+			return nil
+		}),
+	}
+}
+
+func importCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "create millionaires from a CSV or JSON file",
+		ArgsUsage: "FILE (- for stdin)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "format", Usage: "csv or json; guessed from the file extension"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "validate the file without writing"},
+		},
+		Action: func(c *cli.Context) error {
+			path, err := argument(c, "FILE")
+			if err != nil {
+				return err
+			}
+
+			format := c.String("format")
+			if format == "" {
+				format = exchange.FormatOf(path)
+			}
+
+			var in io.Reader = os.Stdin
+			if path != "-" {
+				f, err := os.Open(path)
+				if err != nil {
+					return cli.Exit(err.Error(), exitFailure)
+				}
+				defer f.Close()
+				in = f
+			}
+
+			millionaires, err := exchange.Read(in, format)
+			if err != nil {
+				return cli.Exit(fmt.Sprintf("%s: %v", path, err), exitFailure)
+			}
+
+			if c.Bool("dry-run") {
+				fmt.Fprintf(c.App.Writer, "%d millionaires are valid\n", len(millionaires))
+				return nil
+			}
+
+			return withRuntime(func(c *cli.Context, rt *runtime) error {
+				created, err := createAll(c, rt, millionaires)
+				fmt.Fprintf(c.App.Writer, "imported %d of %d millionaires\n", created, len(millionaires))
+				return err
+			})(c)
+		},
+	}
+}
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "write all millionaires as CSV or JSON",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "output `FILE`; stdout when empty"},
+			&cli.StringFlag{Name: "format", Usage: "csv or json; guessed from --output"},
+		},
+		Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+			output := c.String("output")
+			format := c.String("format")
+			if format == "" {
+				format = exchange.FormatOf(output)
+			}
+
+			out := c.App.Writer
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return cli.Exit(err.Error(), exitFailure)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			w, err := exchange.NewWriter(out, format)
+			if err != nil {
+				return cli.Exit(err.Error(), exitUsage)
+			}
+
+			millionaireRepo := repo.NewMillionaireRepo(repo.Direct(rt.db), rt.log)
+			for page := 1; ; page++ {
+				millionaires, err := millionaireRepo.Page(c.Context, page, exportPageSize)
+				if err != nil {
+					return errFailed
+				}
+				if err := w.Write(millionaires); err != nil {
+					return cli.Exit(err.Error(), exitFailure)
+				}
+				if len(millionaires) < exportPageSize {
+					break
+				}
+			}
+
+			if err := w.Close(); err != nil {
+				return cli.Exit(err.Error(), exitFailure)
+			}
+			if output != "" {
+				fmt.Fprintf(c.App.ErrWriter, "exported %d millionaires to %s\n", w.Count(), output)
+			}
+			return nil
+		}),
+	}
+}
+
+// createAll inserts millionaires in one transaction, so a failing record
+// leaves the database unchanged.
+func createAll(c *cli.Context, rt *runtime, millionaires []models.Millionaire) (int, error) {
+	millionaireRepo := repo.NewMillionaireRepo(repo.Direct(rt.db), rt.log)
+	vocabularyRepo := repo.NewVocabularyRepo(repo.Direct(rt.db), rt.log)
+	millionaireService := service.NewMillionaireService(millionaireRepo, repo.NewUnitOfWork(rt.db, rt.log), vocabularyRepo, rt.log)
+
+	if err := millionaireService.CreateMillionaires(c.Context, millionaires); err != nil {
+		return 0, cli.Exit(err.Error(), exitFailure)
+	}
+	invalidateSharedCache(c.Context, rt)
+	return len(millionaires), nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+	"wealthlist/config"
+	"wealthlist/internal/service"
+
+	"github.com/urfave/cli/v2"
+)
+
+func doctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "check configuration, database, storage and SMTP",
+		Action: func(c *cli.Context) error {
+			cfg, err := loadConfig(c)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(c.App.Writer, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "CHECK\tSTATUS\tDURATION\tERROR")
+			fmt.Fprintln(w, "config\tok\t\t")
+
+			db, err := config.OpenDB(cfg)
+			if err != nil {
+				fmt.Fprintf(w, "database\tfail\t\t%v\n", err)
+				w.Flush()
+				return cli.Exit("", exitUnavailable)
+			}
+			defer db.Close()
+
+			// The readiness checks, with SMTP always included when configured.
+			checkCfg := *cfg
+			checkCfg.Health.CheckSMTP = cfg.SMTP.Host != ""
+			health := service.NewHealthService(db, &checkCfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			result := health.Readiness(c.Context)
+			for _, r := range result.Checks {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Status, r.Duration, r.Error)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if result.Status != service.StatusOK {
+				return cli.Exit("", exitUnavailable)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"wealthlist/internal/logger"
+	"wealthlist/migrations"
+
+	"github.com/urfave/cli/v2"
+)
+
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "manage the database schema",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "apply all pending migrations",
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					if err := migrations.RunMigrationUp(rt.db); err != nil {
+						rt.log.Error("Migration up failed", logger.Err(err))
+						return errFailed
+					}
+					return printVersion(c, rt)
+				}),
+			},
+			{
+				Name:  "down",
+				Usage: "roll back the latest migrations",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "steps", Usage: "number of migrations to roll back", Value: 1},
+				},
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					for i := 0; i < c.Int("steps"); i++ {
+						if err := migrations.RunMigrationDown(rt.db); err != nil {
+							rt.log.Error("Migration down failed", logger.Err(err))
+							return errFailed
+						}
+					}
+					return printVersion(c, rt)
+				}),
+			},
+			{
+				Name:  "status",
+				Usage: "list migrations and whether they are applied",
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					statuses, err := migrations.Status(rt.db)
+					if err != nil {
+						rt.log.Error("Could not read migration status", logger.Err(err))
+						return errFailed
+					}
+
+					w := tabwriter.NewWriter(c.App.Writer, 0, 4, 2, ' ', 0)
+					fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+					pending := 0
+					for _, s := range statuses {
+						state, appliedAt := "pending", ""
+						switch {
+						case s.Missing:
+							state = "applied, file missing"
+						case s.Applied:
+							state = "applied"
+						default:
+							pending++
+						}
+						if s.AppliedAt != nil {
+							appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+						}
+						fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+					}
+					if err := w.Flush(); err != nil {
+						return err
+					}
+
+					// Non-zero when the schema is behind, for deploy scripts.
+					if pending > 0 {
+						return cli.Exit(fmt.Sprintf("%d pending migration(s)", pending), exitFailure)
+					}
+					return nil
+				}),
+			},
+			{
+				Name:      "create",
+				Usage:     "create empty up and down scripts",
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "dir", Usage: "migrations directory", Value: "migrations"},
+				},
+				Action: func(c *cli.Context) error {
+					name, err := argument(c, "NAME")
+					if err != nil {
+						return err
+					}
+
+					paths, err := migrations.Create(c.String("dir"), name)
+					if err != nil {
+						return cli.Exit(err.Error(), exitFailure)
+					}
+					for _, p := range paths {
+						fmt.Fprintln(c.App.Writer, p)
+					}
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func printVersion(c *cli.Context, rt *runtime) error {
+	version, err := migrations.CurrentVersion(rt.db)
+	if err != nil {
+		rt.log.Error("Could not read schema version", logger.Err(err))
+		return errFailed
+	}
+	fmt.Fprintf(c.App.Writer, "schema version %d\n", version)
+	return nil
+}
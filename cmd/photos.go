@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/service"
+
+	"github.com/urfave/cli/v2"
+)
+
+func photosCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "photos",
+		Usage: "maintain uploaded photos",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "reconcile",
+				Usage: "find photo files and database references that do not match",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "fix", Usage: "delete orphaned files and clear missing references"},
+				},
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					photoService := service.NewPhotoService(repo.NewPhotoRepo(repo.Direct(rt.db), rt.log), repo.NewUnitOfWork(rt.db, rt.log), rt.log)
+
+					report, err := photoService.Reconcile(c.Context, c.Bool("fix"))
+					if err != nil {
+						rt.log.Error("Photo reconciliation failed", logger.Err(err))
+						return errFailed
+					}
+
+					for _, p := range report.Orphaned {
+						fmt.Fprintf(c.App.Writer, "orphaned file: %s\n", p)
+					}
+					for _, m := range report.Missing {
+						fmt.Fprintf(c.App.Writer, "missing file: %s (millionaire %d)\n", m.Path, m.MillionaireID)
+					}
+
+					problems := len(report.Orphaned) + len(report.Missing)
+					switch {
+					case problems == 0:
+						fmt.Fprintln(c.App.Writer, "photos are consistent")
+					case c.Bool("fix"):
+						fmt.Fprintf(c.App.Writer, "fixed %d problem(s)\n", problems)
+						invalidateSharedCache(c.Context, rt)
+					default:
+						return cli.Exit(fmt.Sprintf("%d problem(s) found, run with --fix to repair", problems), exitFailure)
+					}
+					return nil
+				}),
+			},
+		},
+	}
+}
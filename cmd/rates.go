@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"wealthlist/internal/rates"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/service"
+
+	"github.com/urfave/cli/v2"
+)
+
+func ratesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rates",
+		Usage: "maintain exchange rates",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "import",
+				Usage:     "load exchange rates per euro from a CSV (date,currency,rate) or ECB XML file",
+				ArgsUsage: "FILE (- for stdin)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "format", Usage: "csv or xml; guessed from the file extension"},
+				},
+				Action: func(c *cli.Context) error {
+					path, err := argument(c, "FILE")
+					if err != nil {
+						return err
+					}
+
+					format := c.String("format")
+					if format == "" {
+						format = rates.FormatOf(path)
+					}
+
+					var in io.Reader = os.Stdin
+					if path != "-" {
+						f, err := os.Open(path)
+						if err != nil {
+							return cli.Exit(err.Error(), exitFailure)
+						}
+						defer f.Close()
+						in = f
+					}
+
+					loaded, err := rates.Read(in, format)
+					if err != nil {
+						return cli.Exit(fmt.Sprintf("%s: %v", path, err), exitFailure)
+					}
+
+					return withRuntime(func(c *cli.Context, rt *runtime) error {
+						currencyService := service.NewCurrencyService(repo.NewRateRepo(repo.Direct(rt.db), rt.log), rt.log)
+						if err := currencyService.Import(c.Context, loaded); err != nil {
+							return errFailed
+						}
+						fmt.Fprintf(c.App.Writer, "imported %d exchange rates\n", len(loaded))
+						invalidateSharedCache(c.Context, rt)
+						return nil
+					})(c)
+				},
+			},
+		},
+	}
+}
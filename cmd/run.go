@@ -1,86 +1,110 @@
 package cmd
 
 import (
-	"flag"
-	"log/slog"
+	"errors"
+	"fmt"
+	"os"
 	"wealthlist/config"
-	"wealthlist/internal/handler"
-	"wealthlist/internal/logger"
-	"wealthlist/internal/repo"
-	"wealthlist/internal/router"
-	"wealthlist/internal/service"
-	"wealthlist/migrations"
+	"wealthlist/internal/buildinfo"
+
+	"github.com/urfave/cli/v2"
 )
 
-func Run() {
-	migrationDirection := flag.String("migrate", "", "Run migration in direction: up or down")
-	flag.Parse()
+// Exit codes, stable for scripts.
+const (
+	exitFailure     = 1 // the command ran and failed
+	exitUsage       = 2 // invalid command line
+	exitConfig      = 3 // the configuration is invalid
+	exitUnavailable = 4 // the database or another dependency is unreachable
+)
 
-	cfg, err := config.InitConfig(".env")
-	if err != nil {
-		slog.Error("Could not load config", slog.String("error", err.Error()))
-		return
-	}
+// errFailed ends a command whose failure has already been logged.
+var errFailed = cli.Exit("", exitFailure)
 
-	log := logger.SetupLogger(cfg.Env)
-	log.Info("Config loaded", slog.Any("config", cfg))
+func Run() {
+	app := &cli.App{
+		Name:    "wealthlist",
+		Usage:   "millionaires directory API",
+		Version: buildinfo.Get().Version,
+		Flags:   globalFlags(),
+		Commands: []*cli.Command{
+			serveCommand(),
+			migrateCommand(),
+			seedCommand(),
+			importCommand(),
+			exportCommand(),
+			userCommand(),
+			photosCommand(),
+			vocabularyCommand(),
+			ratesCommand(),
+			doctorCommand(),
+			configCommand(),
+		},
+		OnUsageError: func(c *cli.Context, err error, _ bool) error {
+			return cli.Exit(err.Error(), exitUsage)
+		},
+	}
 
-	db, err := config.ConnectDB(cfg)
-	if err != nil {
-		log.Error("Could not connect to database", logger.Err(err))
-		return
+	if err := app.Run(os.Args); err != nil {
+		var exitErr cli.ExitCoder
+		if errors.As(err, &exitErr) {
+			// Exit codes are handled, and messages printed, by the cli package.
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitFailure)
 	}
-	defer db.Close()
+}
 
-	log.Info("Successfully connected to the database")
+// globalFlags are --config, --env-file and one flag per configuration
+// option, e.g. --server-port for SERVER_PORT. They go before the command.
+func globalFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "YAML or TOML config `FILE`",
+			EnvVars: []string{"CONFIG_FILE"},
+		},
+		&cli.StringFlag{
+			Name:  "env-file",
+			Usage: "dotenv `FILE`, ignored when missing",
+			Value: ".env",
+		},
+	}
 
-	if *migrationDirection != "" {
-		log.Info("Running migrations", slog.String("direction", *migrationDirection))
-		switch *migrationDirection {
-		case "up":
-			log.Info("Running migration UP...")
-			if err := migrations.RunMigrationUp(db); err != nil {
-				log.Error("Migration up failed", logger.Err(err))
-				return
-			}
-			log.Info("Migration up finished")
-		case "down":
-			log.Info("Running migration DOWN...")
-			if err := migrations.RunMigrationDown(db); err != nil {
-				log.Error("Migration down failed", logger.Err(err))
-				return
-			}
-			log.Info("Migration down finished")
-		default:
-			log.Error("Invalid migration direction", logger.Err(err))
-			return
+	for _, o := range config.Options() {
+		usage := o.Usage
+		if usage == "" {
+			usage = o.Path
+		}
+		f := &cli.StringFlag{
+			Name:        o.Flag(),
+			Usage:       fmt.Sprintf("%s ($%s)", usage, o.Key),
+			Category:    "Settings",
+			DefaultText: o.Default,
 		}
-		return
-	} else {
-		log.Info("No migration flag provided, running UP migrations by default...")
-		if err := migrations.RunMigrationUp(db); err != nil {
-			log.Error("Migration up failed", logger.Err(err))
-			return
+		if o.Secret {
+			f.Usage += "; prefer $" + o.Key + "_FILE"
 		}
+		flags = append(flags, f)
 	}
 
-	millionaireRepo := repo.NewMillionaireRepo(db, log)
-	photoRepo := repo.NewPhotoRepo(db, log)
-
-	millionaireService := service.NewMillionaireService(millionaireRepo, log)
-	homeService := service.NewHomeService(millionaireRepo, log)
-	photoService := service.NewPhotoService(photoRepo, log)
-	feedbackService := service.NewFeedbackService(cfg, log)
-
-	millionaireHandler := handler.NewMillionaireHandler(millionaireService, log)
-	homeHandler := handler.NewHomeHandler(homeService, log)
-	photoHandler := handler.NewPhotoHandler(photoService, log)
-	feedbackHandler := handler.NewFeedbackHandler(feedbackService, log)
+	return flags
+}
 
-	r := router.SetupRouter(millionaireHandler, photoHandler, homeHandler, feedbackHandler)
+// configSources collects the layers given on the command line.
+func configSources(c *cli.Context) config.Sources {
+	flags := map[string]string{}
+	for _, o := range config.Options() {
+		if c.IsSet(o.Flag()) {
+			flags[o.Key] = c.String(o.Flag())
+		}
+	}
 
-	log.Info("Starting server on :8080")
-	if err := r.Run(); err != nil {
-		log.Error("Failed to start server", logger.Err(err))
+	return config.Sources{
+		File:    c.String("config"),
+		EnvFile: c.String("env-file"),
+		Flags:   flags,
 	}
 }
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"wealthlist/config"
+	"wealthlist/internal/handler"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/money"
+	"wealthlist/internal/publicurl"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/router"
+	"wealthlist/internal/server"
+	"wealthlist/internal/service"
+	"wealthlist/internal/tracing"
+	"wealthlist/migrations"
+
+	"github.com/urfave/cli/v2"
+)
+
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "run the HTTP server",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "migrate",
+				Usage: "apply pending migrations before starting",
+				Value: true,
+			},
+		},
+		Action: serve,
+	}
+}
+
+func serve(c *cli.Context) error {
+	src := configSources(c)
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+
+	log, err := logger.SetupLogger(cfg.Env, cfg.Log)
+	if err != nil {
+		return cli.Exit("could not set up logger: "+err.Error(), exitConfig)
+	}
+	defer logger.Close()
+
+	log.Info("Config loaded", slog.Any("config", cfg))
+
+	go reloadLogLevelOnSIGHUP(log, src)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Could not set up tracing", logger.Err(err))
+		return errFailed
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Error("Could not flush traces", logger.Err(err))
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := config.ConnectDB(ctx, cfg, log)
+	if err != nil {
+		log.Error("Could not connect to database", logger.Err(err))
+		return cli.Exit("", exitUnavailable)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("Could not close database connection", logger.Err(err))
+			return
+		}
+		log.Info("Database connection closed")
+	}()
+
+	log.Info("Successfully connected to the database")
+
+	if err := metrics.RegisterDBStats(db, "primary"); err != nil {
+		log.Error("Could not register database metrics", logger.Err(err))
+		return errFailed
+	}
+
+	if c.Bool("migrate") {
+		log.Info("Applying pending migrations")
+		if err := migrations.RunMigrationUp(db); err != nil {
+			log.Error("Migration up failed", logger.Err(err))
+			return errFailed
+		}
+	}
+
+	replicas, err := config.OpenReplicas(cfg)
+	if err != nil {
+		log.Error("Could not configure read replicas", logger.Err(err))
+		return errFailed
+	}
+	cluster := repo.NewCluster(db, replicas, repo.ClusterOptions{
+		CheckTimeout: cfg.Health.CheckTimeout,
+		MaxLag:       cfg.Database.ReplicaMaxLag,
+	}, log)
+	defer func() {
+		if err := cluster.Close(); err != nil {
+			log.Error("Could not close replica connections", logger.Err(err))
+		}
+	}()
+	for i, replica := range replicas {
+		if err := metrics.RegisterDBStats(replica, fmt.Sprintf("replica-%d", i+1)); err != nil {
+			log.Error("Could not register database metrics", logger.Err(err))
+			return errFailed
+		}
+	}
+	if len(replicas) > 0 {
+		// Replicas that are down at startup stay out of rotation until a
+		// later check succeeds; reads fall back to the primary meanwhile.
+		cluster.CheckReplicas(ctx)
+		go cluster.Monitor(ctx, cfg.Database.ReplicaCheckInterval)
+		log.Info("Routing read-only queries to replicas", slog.Int("replicas", len(replicas)))
+	}
+
+	millionaireRepo := repo.NewMillionaireRepo(cluster, log)
+	photoRepo := repo.NewPhotoRepo(cluster, log)
+	userRepo := repo.NewUserRepo(cluster, log)
+	companyRepo := repo.NewCompanyRepo(cluster, log)
+	holdingRepo := repo.NewHoldingRepo(cluster, log)
+	vocabularyRepo := repo.NewVocabularyRepo(cluster, log)
+	rateRepo := repo.NewRateRepo(cluster, log)
+	homeSectionRepo := repo.NewHomeSectionRepo(cluster, log)
+	redirectRepo := repo.NewRedirectRepo(cluster, log)
+	uow := repo.NewUnitOfWork(db, log)
+
+	var millionaireService service.MillionaireServiceInterface = service.NewMillionaireService(millionaireRepo, uow, vocabularyRepo, log)
+	companyService := service.NewCompanyService(companyRepo, holdingRepo, millionaireRepo, uow, log)
+	vocabularyService := service.NewVocabularyService(vocabularyRepo, log)
+	currencyService := service.NewCurrencyService(rateRepo, log)
+	var homeService service.HomeServiceInterface = service.NewHomeService(millionaireRepo, homeSectionRepo, vocabularyRepo, log)
+	statsService := service.NewStatsService(millionaireRepo, vocabularyRepo, currencyService, log)
+	photoService := service.NewPhotoService(photoRepo, uow, log)
+	mergeService := service.NewMergeService(millionaireRepo, redirectRepo, photoService, uow, log)
+	feedbackService := service.NewFeedbackService(cfg, log)
+	healthService := service.NewHealthService(db, cfg, log)
+	userService := service.NewUserService(userRepo, log)
+
+	if responseCache := openCache(ctx, cfg.Cache, log); responseCache != nil {
+		defer responseCache.Close()
+		millionaireService = service.NewCachedMillionaireService(millionaireService, responseCache)
+		homeService = service.NewCachedHomeService(homeService, responseCache)
+		photoService.OnChange(responseCache.Invalidate)
+		mergeService.OnChange(responseCache.Invalidate)
+		log.Info("Caching responses", slog.String("backend", cfg.Cache.Backend), slog.Duration("ttl", cfg.Cache.TTL))
+	}
+
+	millionaireHandler := handler.NewMillionaireHandler(millionaireService, currencyService, mergeService, log)
+	companyHandler := handler.NewCompanyHandler(companyService, log)
+	vocabularyHandler := handler.NewVocabularyHandler(vocabularyService, log)
+	homeHandler := handler.NewHomeHandler(homeService, currencyService, log)
+	statsHandler := handler.NewStatsHandler(statsService, log)
+	mergeHandler := handler.NewMergeHandler(mergeService, log)
+	photoHandler := handler.NewPhotoHandler(photoService, log)
+	feedbackHandler := handler.NewFeedbackHandler(feedbackService, log)
+	healthHandler := handler.NewHealthHandler(healthService, log)
+	adminHandler := handler.NewAdminHandler(log)
+
+	urls, err := publicurl.New(cfg.Server.PublicURL, cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Error("Could not configure public URLs", logger.Err(err))
+		return errFailed
+	}
+	if cfg.Server.PhotoURLSecret != "" {
+		urls.SignPhotos(cfg.Server.PhotoURLSecret, cfg.Server.PhotoURLTTL)
+	}
+
+	if cfg.API.MoneyAsString {
+		money.SetJSONEncoding(money.JSONStrings)
+	}
+	r := router.SetupRouter(millionaireHandler, companyHandler, vocabularyHandler, photoHandler, homeHandler, statsHandler, mergeHandler, feedbackHandler, healthHandler, adminHandler, cfg.Admin.Token, userService, urls, log)
+
+	srv, err := server.New(cfg.Server, r, log)
+	if err != nil {
+		log.Error("Could not configure server", logger.Err(err))
+		return errFailed
+	}
+	srv.OnShutdown(healthService.MarkShuttingDown)
+
+	if err := srv.Run(ctx); err != nil {
+		log.Error("Server stopped with error", logger.Err(err))
+		return errFailed
+	}
+	return nil
+}
+
+// reloadLogLevelOnSIGHUP reloads the configuration from the same sources
+// and applies its log level whenever the process receives SIGHUP.
+func reloadLogLevelOnSIGHUP(log *slog.Logger, src config.Sources) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for range hup {
+		cfg, err := config.Load(src)
+		if err != nil {
+			log.Error("SIGHUP received but the configuration is invalid", logger.Err(err))
+			continue
+		}
+
+		value := cfg.Log.Level
+		if value == "" {
+			log.Warn("SIGHUP received but LOG_LEVEL is not set")
+			continue
+		}
+
+		level, err := logger.ParseLevel(value)
+		if err != nil {
+			log.Error("SIGHUP received with invalid LOG_LEVEL", logger.Err(err))
+			continue
+		}
+
+		previous := logger.Level()
+		logger.SetLevel(level)
+		log.Warn("Log level changed", slog.String("from", previous.String()), slog.String("to", level.String()))
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"database/sql"
+	"log/slog"
+	"wealthlist/config"
+	"wealthlist/internal/logger"
+
+	"github.com/urfave/cli/v2"
+)
+
+// runtime is what a command needs once configuration, logging and the
+// database are set up.
+type runtime struct {
+	cfg *config.Config
+	log *slog.Logger
+	db  *sql.DB
+}
+
+// loadConfig loads the layered configuration, failing with exitConfig.
+func loadConfig(c *cli.Context) (*config.Config, error) {
+	cfg, err := config.Load(configSources(c))
+	if err != nil {
+		return nil, cli.Exit(err.Error(), exitConfig)
+	}
+	return cfg, nil
+}
+
+// setup prepares a one-off command. Logs go to stderr so that stdout only
+// carries the command's own output, e.g. an export.
+func setup(c *cli.Context) (*runtime, func(), error) {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logCfg := cfg.Log
+	logCfg.Sinks = make([]string, len(cfg.Log.Sinks))
+	for i, sink := range cfg.Log.Sinks {
+		if sink == logger.SinkStdout {
+			sink = logger.SinkStderr
+		}
+		logCfg.Sinks[i] = sink
+	}
+
+	log, err := logger.SetupLogger(cfg.Env, logCfg)
+	if err != nil {
+		return nil, nil, cli.Exit("could not set up logger: "+err.Error(), exitConfig)
+	}
+
+	db, err := config.ConnectDB(c.Context, cfg, log)
+	if err != nil {
+		logger.Close()
+		return nil, nil, cli.Exit(err.Error(), exitUnavailable)
+	}
+
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			log.Error("Could not close database connection", logger.Err(err))
+		}
+		logger.Close()
+	}
+
+	return &runtime{cfg: cfg, log: log, db: db}, cleanup, nil
+}
+
+// withRuntime adapts an action that needs a runtime.
+func withRuntime(action func(c *cli.Context, rt *runtime) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		rt, cleanup, err := setup(c)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		return action(c, rt)
+	}
+}
+
+// argument returns the only positional argument or a usage error.
+func argument(c *cli.Context, name string) (string, error) {
+	if c.NArg() != 1 {
+		return "", cli.Exit(c.Command.HelpName+": expected exactly one "+name, exitUsage)
+	}
+	return c.Args().First(), nil
+}
+
+// requireArgument checks the positional argument before the database is
+// opened.
+func requireArgument(name string) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		_, err := argument(c, name)
+		return err
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/service"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+func userCommand() *cli.Command {
+	passwordFlags := []cli.Flag{
+		&cli.BoolFlag{Name: "password-stdin", Usage: "read the password from stdin instead of prompting"},
+		&cli.BoolFlag{Name: "generate", Usage: "generate a random password and print it"},
+	}
+
+	return &cli.Command{
+		Name:  "user",
+		Usage: "manage admin users",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create a user",
+				ArgsUsage: "USERNAME",
+				Before:    requireArgument("USERNAME"),
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "role", Usage: "user role", Value: service.RoleAdmin},
+				}, passwordFlags...),
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					username, err := argument(c, "USERNAME")
+					if err != nil {
+						return err
+					}
+					password, err := readPassword(c)
+					if err != nil {
+						return err
+					}
+
+					users := service.NewUserService(repo.NewUserRepo(repo.Direct(rt.db), rt.log), rt.log)
+					u, err := users.CreateUser(c.Context, username, password, c.String("role"))
+					if err != nil {
+						return userError(err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "created user %s (id %d, role %s)\n", u.Username, u.ID, u.Role)
+					return nil
+				}),
+			},
+			{
+				Name:      "reset-password",
+				Usage:     "set a new password for a user",
+				ArgsUsage: "USERNAME",
+				Before:    requireArgument("USERNAME"),
+				Flags:     passwordFlags,
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					username, err := argument(c, "USERNAME")
+					if err != nil {
+						return err
+					}
+					password, err := readPassword(c)
+					if err != nil {
+						return err
+					}
+
+					users := service.NewUserService(repo.NewUserRepo(repo.Direct(rt.db), rt.log), rt.log)
+					if err := users.ResetPassword(c.Context, username, password); err != nil {
+						return userError(err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "password of %s changed\n", username)
+					return nil
+				}),
+			},
+		},
+	}
+}
+
+func userError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrWeakPassword), errors.Is(err, repo.ErrUserExists), errors.Is(err, repo.ErrUserNotFound):
+		return cli.Exit(err.Error(), exitFailure)
+	default:
+		return errFailed
+	}
+}
+
+// readPassword generates a password, reads one line from stdin or prompts
+// on the terminal without echo.
+func readPassword(c *cli.Context) (string, error) {
+	if c.Bool("generate") {
+		b := make([]byte, 18)
+		if _, err := rand.Read(b); err != nil {
+			return "", cli.Exit(err.Error(), exitFailure)
+		}
+		password := base64.RawURLEncoding.EncodeToString(b)
+		fmt.Fprintf(c.App.Writer, "generated password: %s\n", password)
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if c.Bool("password-stdin") || !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", cli.Exit("could not read password from stdin", exitUsage)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(c.App.ErrWriter, "Password: ")
+	first, err := term.ReadPassword(fd)
+	fmt.Fprintln(c.App.ErrWriter)
+	if err != nil {
+		return "", cli.Exit(err.Error(), exitFailure)
+	}
+
+	fmt.Fprint(c.App.ErrWriter, "Repeat password: ")
+	second, err := term.ReadPassword(fd)
+	fmt.Fprintln(c.App.ErrWriter)
+	if err != nil {
+		return "", cli.Exit(err.Error(), exitFailure)
+	}
+
+	if string(first) != string(second) {
+		return "", cli.Exit("passwords do not match", exitUsage)
+	}
+	return string(first), nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/service"
+
+	"github.com/urfave/cli/v2"
+)
+
+func vocabularyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "vocabulary",
+		Usage: "inspect country and industry codes",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "unmatched",
+				Usage: "list free-text countries and industries that have no code",
+				Action: withRuntime(func(c *cli.Context, rt *runtime) error {
+					vocabularyService := service.NewVocabularyService(repo.NewVocabularyRepo(repo.Direct(rt.db), rt.log), rt.log)
+
+					values, err := vocabularyService.Unmatched(c.Context)
+					if err != nil {
+						rt.log.Error("Listing unmatched values failed", logger.Err(err))
+						return errFailed
+					}
+					if len(values) == 0 {
+						fmt.Fprintln(c.App.Writer, "all countries and industries have codes")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(c.App.Writer, 0, 4, 2, ' ', 0)
+					fmt.Fprintln(w, "KIND\tVALUE\tMILLIONAIRES")
+					for _, v := range values {
+						fmt.Fprintf(w, "%s\t%s\t%d\n", v.Kind, v.Value, v.Millionaires)
+					}
+					return w.Flush()
+				}),
+			},
+		},
+	}
+}
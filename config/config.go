@@ -1,11 +1,7 @@
 package config
 
 import (
-	"log"
-	"os"
-	"strconv"
-
-	"github.com/joho/godotenv"
+	"time"
 )
 
 type Config struct {
@@ -13,19 +9,81 @@ type Config struct {
 	Server   ServerConfig
 	Database DBConfig
 	SMTP     SMTPConfig
+	Health   HealthConfig
+	Tracing  TracingConfig
+	Log      LogConfig
+	Admin    AdminConfig
+	API      APIConfig
+	Cache    CacheConfig
 }
 
 type ServerConfig struct {
-	Host string
-	Port int
+	Host              string
+	Port              int
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	ShutdownDelay     time.Duration
+	MaxHeaderBytes    int
+	TLS               TLSConfig
+	// PublicURL is the address clients reach the API at, used in absolute
+	// URLs. Without it they are built from the request, honouring the
+	// forwarding headers of TrustedProxies (addresses or CIDR ranges).
+	PublicURL      string
+	TrustedProxies []string
+	// PhotoURLSecret, when set, signs photo URLs so that photos are only
+	// served for PhotoURLTTL to twice that after the URL was made.
+	PhotoURLSecret string
+	PhotoURLTTL    time.Duration
+}
+
+// TLSConfig enables HTTPS when CertFile and KeyFile are set. Setting
+// ClientCAFile additionally requires clients to present a certificate
+// signed by that CA (mutual TLS).
+type TLSConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
 }
 
+// DBConfig describes the PostgreSQL connection either as a DSN (URL or
+// key=value form) or as individual fields. ApplicationName and
+// StatementTimeout are added to a DSN that does not set them.
 type DBConfig struct {
+	DSN      string
 	Host     string
 	Port     int
 	User     string
 	Password string
 	DBName   string
+
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+
+	ApplicationName  string
+	StatementTimeout time.Duration
+	ConnectTimeout   time.Duration
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// ConnectRetryTimeout bounds how long startup keeps retrying the first
+	// connection; zero tries once.
+	ConnectRetryTimeout time.Duration
+
+	// ReplicaDSNs are read replicas that share every setting above except
+	// the connection target. Read-only queries are spread across them.
+	ReplicaDSNs          []string
+	ReplicaCheckInterval time.Duration
+	// ReplicaMaxLag takes a replica out of rotation when it falls further
+	// behind the primary; zero disables the lag check.
+	ReplicaMaxLag time.Duration
 }
 
 type SMTPConfig struct {
@@ -37,56 +95,80 @@ type SMTPConfig struct {
 	To       string
 }
 
-func InitConfig(envPath string) (*Config, error) {
-	if err := godotenv.Load(envPath); err != nil {
-		log.Printf("Warning: .env file not found, using default values")
-	}
-
-	serverPort, err := strconv.Atoi(getEnv("SERVER_PORT", "8080"))
-	if err != nil {
-		log.Fatalf("Invalid SERVER_PORT value: %v", err)
-	}
-
-	dbPort, err := strconv.Atoi(getEnv("DB_PORT", "5432"))
-	if err != nil {
-		log.Fatalf("Invalid DB_PORT value: %v", err)
-	}
-
-	mailPort, err := strconv.Atoi(getEnv("MAIL_PORT", "587"))
-	if err != nil {
-		log.Fatalf("Invalid MAIL_PORT value: %v", err)
-	}
-
-	cfg := &Config{
-		Env: getEnv("APP_ENV", "local"),
-
-		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Port: serverPort,
-		},
-		Database: DBConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     dbPort,
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "12345678"),
-			DBName:   getEnv("DB_NAME", "MILLIONAIRE"),
-		},
-		SMTP: SMTPConfig{
-			Host:     getEnv("MAIL_HOST", "smtp.gmail.com"),
-			Port:     mailPort,
-			Username: getEnv("MAIL_USER", ""),
-			Password: getEnv("MAIL_PASSWORD", ""),
-			From:     getEnv("MAIL_FROM", ""),
-			To:       getEnv("MAIL_TO", ""),
-		},
-	}
-
-	return cfg, nil
+type HealthConfig struct {
+	CheckTimeout time.Duration
+	CheckSMTP    bool
+}
+
+// TracingConfig selects the span exporter: "none", "stdout", "file" (JSON
+// lines written to FilePath) or "otlp" (OTLP over HTTP to OTLPEndpoint, or
+// the standard OTEL_EXPORTER_OTLP_* variables when empty).
+type TracingConfig struct {
+	Exporter     string
+	ServiceName  string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	FilePath     string
+	SampleRatio  float64
+}
+
+// LogConfig controls where logs go. Level overrides the APP_ENV default and
+// can be changed at runtime through the admin API or SIGHUP.
+type LogConfig struct {
+	Level    string
+	Sinks    []string
+	File     LogFileConfig
+	Sampling LogSamplingConfig
 }
 
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	return defaultValue
+// LogFileConfig configures the "file" sink. Files are rotated when they reach
+// MaxSizeMB and, if RotateInterval is set, on that interval.
+type LogFileConfig struct {
+	Path           string
+	MaxSizeMB      int
+	MaxBackups     int
+	MaxAgeDays     int
+	Compress       bool
+	RotateInterval time.Duration
+}
+
+// LogSamplingConfig keeps the first First records with the same message in
+// every Interval, then one in Thereafter. Only records below WARN with one
+// of Messages are sampled; sampling requires the list.
+type LogSamplingConfig struct {
+	Messages   []string
+	First      int
+	Thereafter int
+	Interval   time.Duration
+}
+
+// APIConfig shapes API responses. MoneyAsString encodes amounts such as
+// net worth as JSON strings, for clients that read numbers as float64.
+type APIConfig struct {
+	MoneyAsString bool
+}
+
+// AdminConfig protects the /admin API. Besides Token, admin users created
+// with `wealthlist user create` can sign in with HTTP basic auth.
+type AdminConfig struct {
+	Token string
+}
+
+// CacheConfig configures the response cache in front of the home page,
+// millionaire lists, searches and profiles. Backend is none, memory (an LRU
+// of Size entries per process) or redis, which instances share so that a
+// write on one is seen by all. Entries live for at most TTL.
+type CacheConfig struct {
+	Backend string
+	TTL     time.Duration
+	Size    int
+	Redis   RedisConfig
+}
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+	PoolSize int
+	Timeout  time.Duration
 }
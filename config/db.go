@@ -1,33 +1,189 @@
 package config
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
-func ConnectDB(cfg *Config) (*sql.DB, error) {
-	connStr := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.User, cfg.Database.Password, cfg.Database.DBName,
-	)
+const (
+	initialRetryDelay = 500 * time.Millisecond
+	maxRetryDelay     = 10 * time.Second
+)
 
-	db, err := sql.Open("postgres", connStr)
+// ConnString returns the lib/pq key=value connection string.
+func (c DBConfig) ConnString() (string, error) {
+	if c.DSN != "" {
+		dsn := c.DSN
+		if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+			var err error
+			if dsn, err = pq.ParseURL(dsn); err != nil {
+				return "", err
+			}
+		}
+		return dsn + c.sessionParams(dsn), nil
+	}
+
+	params := []string{
+		"host=" + quote(c.Host),
+		"port=" + strconv.Itoa(c.Port),
+		"user=" + quote(c.User),
+		"password=" + quote(c.Password),
+		"dbname=" + quote(c.DBName),
+		"sslmode=" + quote(c.SSLMode),
+	}
+	for _, p := range [][2]string{
+		{"sslrootcert", c.SSLRootCert},
+		{"sslcert", c.SSLCert},
+		{"sslkey", c.SSLKey},
+	} {
+		if p[1] != "" {
+			params = append(params, p[0]+"="+quote(p[1]))
+		}
+	}
+
+	return strings.Join(params, " ") + c.sessionParams(""), nil
+}
+
+// sessionParams adds the settings a DSN does not specify itself. lib/pq sends
+// unknown keys such as statement_timeout as run-time parameters.
+func (c DBConfig) sessionParams(dsn string) string {
+	var params []string
+	add := func(key, value string) {
+		if !regexp.MustCompile(`(^|\s)` + key + `\s*=`).MatchString(dsn) {
+			params = append(params, key+"="+quote(value))
+		}
+	}
+
+	if c.ApplicationName != "" {
+		add("application_name", c.ApplicationName)
+	}
+	if c.StatementTimeout > 0 {
+		add("statement_timeout", strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10))
+	}
+	if c.ConnectTimeout > 0 {
+		// lib/pq takes whole seconds.
+		seconds := int64((c.ConnectTimeout + time.Second - 1) / time.Second)
+		add("connect_timeout", strconv.FormatInt(seconds, 10))
+	}
+
+	if len(params) == 0 {
+		return ""
+	}
+	return " " + strings.Join(params, " ")
+}
+
+// quote escapes a value for the key=value format.
+func quote(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
+
+// replica returns the settings of the i-th read replica: the primary's
+// with the connection target replaced.
+func (c DBConfig) replica(i int) DBConfig {
+	c.DSN = c.ReplicaDSNs[i]
+	c.ReplicaDSNs = nil
+	return c
+}
+
+// OpenDB configures the pool without connecting; the first query or Ping
+// establishes the connection.
+func OpenDB(cfg *Config) (*sql.DB, error) {
+	return openPool(cfg.Database)
+}
+
+// OpenReplicas configures a pool per read replica, in DB_REPLICA_DSNS
+// order. Like OpenDB it does not connect.
+func OpenReplicas(cfg *Config) ([]*sql.DB, error) {
+	var replicas []*sql.DB
+	for i := range cfg.Database.ReplicaDSNs {
+		db, err := openPool(cfg.Database.replica(i))
+		if err != nil {
+			for _, r := range replicas {
+				r.Close()
+			}
+			return nil, fmt.Errorf("replica %d: %w", i+1, err)
+		}
+		replicas = append(replicas, db)
+	}
+	return replicas, nil
+}
+
+func openPool(c DBConfig) (*sql.DB, error) {
+	connStr, err := c.ConnString()
 	if err != nil {
-		log.Fatalf("Error opening database connection: %v", err)
+		return nil, fmt.Errorf("invalid database settings: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		db.Close()
-		log.Fatalf("Error connecting to database: %v", err)
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("error opening database connection: %w", err)
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
 
 	return db, nil
 }
+
+// ConnectDB opens the pool and waits for the database, retrying with
+// exponential backoff until ConnectRetryTimeout has passed or ctx is done.
+func ConnectDB(ctx context.Context, cfg *Config, log *slog.Logger) (*sql.DB, error) {
+	db, err := OpenDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(cfg.Database.ConnectRetryTimeout)
+	delay := initialRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx, db, cfg.Database.ConnectTimeout)
+		if err == nil {
+			return db, nil
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			db.Close()
+			return nil, fmt.Errorf("error connecting to database after %d attempt(s): %w", attempt, err)
+		}
+
+		// Jitter keeps instances that restart together from retrying in lockstep.
+		wait := delay/2 + rand.N(delay/2)
+		log.Warn("Database not reachable, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", wait),
+			slog.String("error", err.Error()),
+		)
+
+		select {
+		case <-ctx.Done():
+			db.Close()
+			return nil, fmt.Errorf("error connecting to database: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+func ping(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return db.PingContext(ctx)
+}
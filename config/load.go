@@ -0,0 +1,260 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// secretFileSuffix marks a setting whose value is read from a file, e.g.
+// DB_PASSWORD_FILE=/run/secrets/db_password or database.password_file.
+const secretFileSuffix = "_FILE"
+
+// Sources locates the optional layers of a Load. Layers are applied in order:
+// defaults, File, EnvFile, the process environment, Flags.
+type Sources struct {
+	// File is a YAML (.yaml, .yml) or TOML (.toml) file. CONFIG_FILE is used
+	// when empty.
+	File string
+	// EnvFile is a dotenv file; a missing file is ignored.
+	EnvFile string
+	// Flags holds values set on the command line, keyed by Option.Key.
+	Flags map[string]string
+}
+
+// ValidationError lists every problem found while loading the configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// layer records where a value came from for error messages.
+type layer struct {
+	value  string
+	source string
+}
+
+// Load builds the configuration from all layers and validates it. Errors
+// from every layer are collected into a single *ValidationError.
+func Load(src Sources) (*Config, error) {
+	var problems []string
+	values := make(map[string]layer, len(options))
+
+	for _, o := range options {
+		values[o.Key] = layer{value: o.Default, source: "default"}
+	}
+
+	file := src.File
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	if file != "" {
+		fileValues, err := readConfigFile(file)
+		if err != nil {
+			return nil, &ValidationError{Problems: []string{err.Error()}}
+		}
+		problems = append(problems, applyFile(values, file, fileValues)...)
+	}
+
+	env, err := environment(src.EnvFile)
+	if err != nil {
+		return nil, &ValidationError{Problems: []string{err.Error()}}
+	}
+	problems = append(problems, applyEnv(values, env)...)
+
+	for key, value := range src.Flags {
+		values[key] = layer{value: value, source: "flag"}
+	}
+
+	cfg := &Config{}
+	for _, o := range options {
+		l := values[o.Key]
+		if err := o.set(cfg, strings.TrimSpace(l.value)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (from %s): %v", o.Key, l.source, err))
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			problems = append(problems, verr.Problems...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// InitConfig loads the configuration from the environment and an optional
+// dotenv file.
+func InitConfig(envPath string) (*Config, error) {
+	return Load(Sources{EnvFile: envPath})
+}
+
+func readConfigFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	flat := map[string]interface{}{}
+	flatten("", raw, flat)
+	return flat, nil
+}
+
+// flatten turns nested tables into dotted paths such as server.tls.cert_file.
+func flatten(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for k, v := range in {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(path, nested, out)
+			continue
+		}
+		out[path] = v
+	}
+}
+
+func applyFile(values map[string]layer, file string, fileValues map[string]interface{}) []string {
+	byPath := make(map[string]Option, len(options))
+	for _, o := range options {
+		byPath[o.Path] = o
+	}
+
+	var problems []string
+	source := "file " + file
+
+	paths := make([]string, 0, len(fileValues))
+	for path := range fileValues {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		value := scalar(fileValues[path])
+
+		if o, ok := byPath[path]; ok {
+			if _, dup := fileValues[path+strings.ToLower(secretFileSuffix)]; dup {
+				problems = append(problems, fmt.Sprintf("%s (from %s): set either %s or %s_file", path, source, path, path))
+				continue
+			}
+			values[o.Key] = layer{value: value, source: source}
+			continue
+		}
+
+		if base, ok := strings.CutSuffix(path, strings.ToLower(secretFileSuffix)); ok {
+			if o, ok := byPath[base]; ok {
+				secret, err := readSecretFile(value)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s (from %s): %v", path, source, err))
+					continue
+				}
+				values[o.Key] = layer{value: secret, source: source}
+				continue
+			}
+		}
+
+		problems = append(problems, fmt.Sprintf("%s (from %s): unknown setting", path, source))
+	}
+
+	return problems
+}
+
+func scalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// environment merges the dotenv file with the process environment, which
+// takes precedence.
+func environment(envFile string) (map[string]string, error) {
+	env := map[string]string{}
+	if envFile != "" {
+		values, err := godotenv.Read(envFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("env file %s: %w", envFile, err)
+		}
+		for k, v := range values {
+			env[k] = v
+		}
+	}
+
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env, nil
+}
+
+func applyEnv(values map[string]layer, env map[string]string) []string {
+	var problems []string
+
+	for _, o := range options {
+		value, hasValue := env[o.Key]
+		path, hasFile := env[o.Key+secretFileSuffix]
+
+		switch {
+		case hasValue && hasFile:
+			problems = append(problems, fmt.Sprintf("%s (from env): set either %s or %s%s", o.Key, o.Key, o.Key, secretFileSuffix))
+		case hasFile:
+			secret, err := readSecretFile(path)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s%s (from env): %v", o.Key, secretFileSuffix, err))
+				continue
+			}
+			values[o.Key] = layer{value: secret, source: "env " + o.Key + secretFileSuffix}
+		case hasValue:
+			values[o.Key] = layer{value: value, source: "env"}
+		}
+	}
+
+	return problems
+}
+
+// readSecretFile reads a mounted secret, dropping the trailing newline most
+// tools write.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
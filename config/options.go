@@ -0,0 +1,215 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Option describes one setting. Every layer addresses it differently: Key in
+// the environment, Path in a config file and Flag on the command line.
+type Option struct {
+	Key     string
+	Path    string
+	Default string
+	Secret  bool
+	Usage   string
+
+	set func(c *Config, v string) error
+	get func(c *Config) string
+}
+
+// Flag is the command line flag name, e.g. server-port for server.port.
+func (o Option) Flag() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(o.Path)
+}
+
+func option(key, path, def, usage string, b binding) Option {
+	return Option{Key: key, Path: path, Default: def, Usage: usage, set: b.set, get: b.get}
+}
+
+func secret(key, path, usage string, b binding) Option {
+	o := option(key, path, "", usage, b)
+	o.Secret = true
+	return o
+}
+
+var options = []Option{
+	option("APP_ENV", "env", "local", "environment: local, dev or prod", str(func(c *Config) *string { return &c.Env })),
+
+	option("SERVER_HOST", "server.host", "0.0.0.0", "listen address", str(func(c *Config) *string { return &c.Server.Host })),
+	option("SERVER_PORT", "server.port", "8080", "listen port", integer(func(c *Config) *int { return &c.Server.Port })),
+	option("SERVER_READ_TIMEOUT", "server.read_timeout", "15s", "", duration(func(c *Config) *time.Duration { return &c.Server.ReadTimeout })),
+	option("SERVER_READ_HEADER_TIMEOUT", "server.read_header_timeout", "5s", "", duration(func(c *Config) *time.Duration { return &c.Server.ReadHeaderTimeout })),
+	option("SERVER_WRITE_TIMEOUT", "server.write_timeout", "30s", "", duration(func(c *Config) *time.Duration { return &c.Server.WriteTimeout })),
+	option("SERVER_IDLE_TIMEOUT", "server.idle_timeout", "120s", "", duration(func(c *Config) *time.Duration { return &c.Server.IdleTimeout })),
+	option("SERVER_SHUTDOWN_TIMEOUT", "server.shutdown_timeout", "20s", "time allowed for in-flight requests to finish", duration(func(c *Config) *time.Duration { return &c.Server.ShutdownTimeout })),
+	option("SERVER_SHUTDOWN_DELAY", "server.shutdown_delay", "0s", "wait after failing readiness before shutting down", duration(func(c *Config) *time.Duration { return &c.Server.ShutdownDelay })),
+	option("SERVER_MAX_HEADER_BYTES", "server.max_header_bytes", "1048576", "", integer(func(c *Config) *int { return &c.Server.MaxHeaderBytes })),
+	option("SERVER_TLS_CERT_FILE", "server.tls.cert_file", "", "TLS certificate", str(func(c *Config) *string { return &c.Server.TLS.CertFile })),
+	option("SERVER_TLS_KEY_FILE", "server.tls.key_file", "", "TLS private key", str(func(c *Config) *string { return &c.Server.TLS.KeyFile })),
+	option("SERVER_TLS_CLIENT_CA_FILE", "server.tls.client_ca_file", "", "CA for client certificates (mutual TLS)", str(func(c *Config) *string { return &c.Server.TLS.ClientCAFile })),
+	option("SERVER_PUBLIC_URL", "server.public_url", "", "base of absolute URLs in responses, e.g. https://api.example.com; empty takes it from the request", str(func(c *Config) *string { return &c.Server.PublicURL })),
+	option("SERVER_TRUSTED_PROXIES", "server.trusted_proxies", "", "comma-separated proxy addresses or CIDR ranges whose Forwarded and X-Forwarded-* headers are believed", list(func(c *Config) *[]string { return &c.Server.TrustedProxies })),
+	secret("SERVER_PHOTO_URL_SECRET", "server.photo_url_secret", "HMAC key signing photo URLs, at least 32 bytes; empty serves photos unsigned", str(func(c *Config) *string { return &c.Server.PhotoURLSecret })),
+	option("SERVER_PHOTO_URL_TTL", "server.photo_url_ttl", "1h", "how long signed photo URLs last at least", duration(func(c *Config) *time.Duration { return &c.Server.PhotoURLTTL })),
+
+	secret("DB_DSN", "database.dsn", "connection URL or key=value string; overrides host, port, user, password, name and ssl settings", str(func(c *Config) *string { return &c.Database.DSN })),
+	option("DB_HOST", "database.host", "localhost", "", str(func(c *Config) *string { return &c.Database.Host })),
+	option("DB_PORT", "database.port", "5432", "", integer(func(c *Config) *int { return &c.Database.Port })),
+	option("DB_USER", "database.user", "postgres", "", str(func(c *Config) *string { return &c.Database.User })),
+	secret("DB_PASSWORD", "database.password", "", str(func(c *Config) *string { return &c.Database.Password })),
+	option("DB_NAME", "database.name", "MILLIONAIRE", "", str(func(c *Config) *string { return &c.Database.DBName })),
+	option("DB_SSLMODE", "database.sslmode", "disable", "disable, require, verify-ca or verify-full", str(func(c *Config) *string { return &c.Database.SSLMode })),
+	option("DB_SSLROOTCERT", "database.sslrootcert", "", "CA certificate to verify the server", str(func(c *Config) *string { return &c.Database.SSLRootCert })),
+	option("DB_SSLCERT", "database.sslcert", "", "client certificate", str(func(c *Config) *string { return &c.Database.SSLCert })),
+	option("DB_SSLKEY", "database.sslkey", "", "client certificate key", str(func(c *Config) *string { return &c.Database.SSLKey })),
+	option("DB_APPLICATION_NAME", "database.application_name", "wealthlist", "shown in pg_stat_activity", str(func(c *Config) *string { return &c.Database.ApplicationName })),
+	option("DB_STATEMENT_TIMEOUT", "database.statement_timeout", "0s", "server-side limit per statement; 0 disables it", duration(func(c *Config) *time.Duration { return &c.Database.StatementTimeout })),
+	option("DB_CONNECT_TIMEOUT", "database.connect_timeout", "5s", "timeout of each connection attempt", duration(func(c *Config) *time.Duration { return &c.Database.ConnectTimeout })),
+	option("DB_MAX_OPEN_CONNS", "database.max_open_conns", "25", "0 is unlimited", integer(func(c *Config) *int { return &c.Database.MaxOpenConns })),
+	option("DB_MAX_IDLE_CONNS", "database.max_idle_conns", "5", "", integer(func(c *Config) *int { return &c.Database.MaxIdleConns })),
+	option("DB_CONN_MAX_LIFETIME", "database.conn_max_lifetime", "5m", "0 keeps connections forever", duration(func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime })),
+	option("DB_CONN_MAX_IDLE_TIME", "database.conn_max_idle_time", "0s", "0 keeps idle connections forever", duration(func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime })),
+	option("DB_CONNECT_RETRY_TIMEOUT", "database.connect_retry_timeout", "30s", "keep retrying the first connection this long; 0 tries once", duration(func(c *Config) *time.Duration { return &c.Database.ConnectRetryTimeout })),
+	secret("DB_REPLICA_DSNS", "database.replica_dsns", "comma-separated read replica URLs; empty sends every query to the primary", list(func(c *Config) *[]string { return &c.Database.ReplicaDSNs })),
+	option("DB_REPLICA_CHECK_INTERVAL", "database.replica_check_interval", "10s", "how often replica health and lag are checked", duration(func(c *Config) *time.Duration { return &c.Database.ReplicaCheckInterval })),
+	option("DB_REPLICA_MAX_LAG", "database.replica_max_lag", "0s", "replicas further behind leave the rotation; 0 disables the check", duration(func(c *Config) *time.Duration { return &c.Database.ReplicaMaxLag })),
+
+	option("MAIL_HOST", "smtp.host", "smtp.gmail.com", "", str(func(c *Config) *string { return &c.SMTP.Host })),
+	option("MAIL_PORT", "smtp.port", "587", "", integer(func(c *Config) *int { return &c.SMTP.Port })),
+	option("MAIL_USER", "smtp.username", "", "", str(func(c *Config) *string { return &c.SMTP.Username })),
+	secret("MAIL_PASSWORD", "smtp.password", "", str(func(c *Config) *string { return &c.SMTP.Password })),
+	option("MAIL_FROM", "smtp.from", "", "sender of feedback emails", str(func(c *Config) *string { return &c.SMTP.From })),
+	option("MAIL_TO", "smtp.to", "", "recipient of feedback emails", str(func(c *Config) *string { return &c.SMTP.To })),
+
+	option("HEALTH_CHECK_TIMEOUT", "health.check_timeout", "2s", "timeout of each readiness check", duration(func(c *Config) *time.Duration { return &c.Health.CheckTimeout })),
+	option("HEALTH_CHECK_SMTP", "health.check_smtp", "false", "include the SMTP server in readiness", boolean(func(c *Config) *bool { return &c.Health.CheckSMTP })),
+
+	option("TRACING_EXPORTER", "tracing.exporter", "none", "none, stdout, file or otlp", str(func(c *Config) *string { return &c.Tracing.Exporter })),
+	option("TRACING_SERVICE_NAME", "tracing.service_name", "wealthlist", "", str(func(c *Config) *string { return &c.Tracing.ServiceName })),
+	option("TRACING_OTLP_ENDPOINT", "tracing.otlp_endpoint", "", "OTLP/HTTP endpoint URL", str(func(c *Config) *string { return &c.Tracing.OTLPEndpoint })),
+	option("TRACING_OTLP_INSECURE", "tracing.otlp_insecure", "false", "", boolean(func(c *Config) *bool { return &c.Tracing.OTLPInsecure })),
+	option("TRACING_FILE", "tracing.file", "traces.jsonl", "output of the file exporter", str(func(c *Config) *string { return &c.Tracing.FilePath })),
+	option("TRACING_SAMPLE_RATIO", "tracing.sample_ratio", "1", "fraction of root spans sampled", float(func(c *Config) *float64 { return &c.Tracing.SampleRatio })),
+
+	option("LOG_LEVEL", "log.level", "", "minimum level; defaults by environment", str(func(c *Config) *string { return &c.Log.Level })),
+	option("LOG_SINKS", "log.sinks", "stdout", "comma-separated: stdout, stderr, file", list(func(c *Config) *[]string { return &c.Log.Sinks })),
+	option("LOG_FILE_PATH", "log.file.path", "logs/wealthlist.log", "", str(func(c *Config) *string { return &c.Log.File.Path })),
+	option("LOG_FILE_MAX_SIZE_MB", "log.file.max_size_mb", "100", "", integer(func(c *Config) *int { return &c.Log.File.MaxSizeMB })),
+	option("LOG_FILE_MAX_BACKUPS", "log.file.max_backups", "7", "", integer(func(c *Config) *int { return &c.Log.File.MaxBackups })),
+	option("LOG_FILE_MAX_AGE_DAYS", "log.file.max_age_days", "30", "", integer(func(c *Config) *int { return &c.Log.File.MaxAgeDays })),
+	option("LOG_FILE_COMPRESS", "log.file.compress", "true", "", boolean(func(c *Config) *bool { return &c.Log.File.Compress })),
+	option("LOG_FILE_ROTATE_INTERVAL", "log.file.rotate_interval", "0s", "", duration(func(c *Config) *time.Duration { return &c.Log.File.RotateInterval })),
+	option("LOG_SAMPLE_MESSAGES", "log.sampling.messages", "", "", list(func(c *Config) *[]string { return &c.Log.Sampling.Messages })),
+	option("LOG_SAMPLE_FIRST", "log.sampling.first", "0", "0 disables sampling", integer(func(c *Config) *int { return &c.Log.Sampling.First })),
+	option("LOG_SAMPLE_THEREAFTER", "log.sampling.thereafter", "100", "", integer(func(c *Config) *int { return &c.Log.Sampling.Thereafter })),
+	option("LOG_SAMPLE_INTERVAL", "log.sampling.interval", "1s", "", duration(func(c *Config) *time.Duration { return &c.Log.Sampling.Interval })),
+
+	option("API_MONEY_AS_STRING", "api.money_as_string", "false", "encode amounts as JSON strings instead of numbers", boolean(func(c *Config) *bool { return &c.API.MoneyAsString })),
+
+	secret("ADMIN_TOKEN", "admin.token", "bearer token for /admin; empty allows only admin users", str(func(c *Config) *string { return &c.Admin.Token })),
+
+	option("CACHE_BACKEND", "cache.backend", "memory", "none, memory or redis", str(func(c *Config) *string { return &c.Cache.Backend })),
+	option("CACHE_TTL", "cache.ttl", "30s", "how long a cached response is served", duration(func(c *Config) *time.Duration { return &c.Cache.TTL })),
+	option("CACHE_SIZE", "cache.size", "1000", "entries kept by the memory backend", integer(func(c *Config) *int { return &c.Cache.Size })),
+	option("REDIS_ADDR", "cache.redis.addr", "localhost:6379", "host:port of the redis backend", str(func(c *Config) *string { return &c.Cache.Redis.Addr })),
+	secret("REDIS_PASSWORD", "cache.redis.password", "", str(func(c *Config) *string { return &c.Cache.Redis.Password })),
+	option("REDIS_DB", "cache.redis.db", "0", "", integer(func(c *Config) *int { return &c.Cache.Redis.DB })),
+	option("REDIS_POOL_SIZE", "cache.redis.pool_size", "10", "idle connections kept open", integer(func(c *Config) *int { return &c.Cache.Redis.PoolSize })),
+	option("REDIS_TIMEOUT", "cache.redis.timeout", "200ms", "dial, read and write timeout; on expiry requests go to the database", duration(func(c *Config) *time.Duration { return &c.Cache.Redis.Timeout })),
+}
+
+// Options lists every setting in the order used by config print.
+func Options() []Option {
+	return options
+}
+
+type binding struct {
+	set func(c *Config, v string) error
+	get func(c *Config) string
+}
+
+func bind[T any](field func(*Config) *T, parse func(string) (T, error), format func(T) string) binding {
+	return binding{
+		set: func(c *Config, v string) error {
+			parsed, err := parse(v)
+			if err != nil {
+				return err
+			}
+			*field(c) = parsed
+			return nil
+		},
+		get: func(c *Config) string { return format(*field(c)) },
+	}
+}
+
+func str(field func(*Config) *string) binding {
+	return bind(field,
+		func(v string) (string, error) { return v, nil },
+		func(v string) string { return v })
+}
+
+func integer(field func(*Config) *int) binding {
+	return bind(field,
+		func(v string) (int, error) {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("%q is not an integer", v)
+			}
+			return i, nil
+		},
+		strconv.Itoa)
+}
+
+func float(field func(*Config) *float64) binding {
+	return bind(field,
+		func(v string) (float64, error) {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%q is not a number", v)
+			}
+			return f, nil
+		},
+		func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) })
+}
+
+func boolean(field func(*Config) *bool) binding {
+	return bind(field,
+		func(v string) (bool, error) {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return false, fmt.Errorf("%q is not a boolean", v)
+			}
+			return b, nil
+		},
+		strconv.FormatBool)
+}
+
+func duration(field func(*Config) *time.Duration) binding {
+	return bind(field,
+		func(v string) (time.Duration, error) {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return 0, fmt.Errorf("%q is not a duration", v)
+			}
+			return d, nil
+		},
+		time.Duration.String)
+}
+
+// list splits a comma-separated value, dropping empty items.
+func list(field func(*Config) *[]string) binding {
+	return bind(field,
+		func(v string) ([]string, error) {
+			var items []string
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items, nil
+		},
+		func(v []string) string { return strings.Join(v, ",") })
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+const (
+	FormatEnv  = "env"
+	FormatYAML = "yaml"
+)
+
+// value returns the setting as text, masking secrets.
+func (o Option) value(c *Config) string {
+	v := o.get(c)
+	if o.Secret && v != "" {
+		return redacted
+	}
+	return v
+}
+
+// String renders the configuration in dotenv format with secrets redacted,
+// so it is safe to log or print.
+func (c *Config) String() string {
+	var b strings.Builder
+	for _, o := range options {
+		fmt.Fprintf(&b, "%s=%s\n", o.Key, o.value(c))
+	}
+	return b.String()
+}
+
+// LogValue implements slog.LogValuer with secrets redacted.
+func (c *Config) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(options))
+	for i, o := range options {
+		attrs[i] = slog.String(o.Path, o.value(c))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Write prints the redacted configuration as FormatEnv or FormatYAML. The
+// YAML output has the layout of a config file.
+func (c *Config) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatEnv:
+		_, err := io.WriteString(w, c.String())
+		return err
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(c.yamlNode()); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unknown format %q, use %s or %s", format, FormatEnv, FormatYAML)
+	}
+}
+
+// yamlNode builds a mapping in option order; a plain map would be sorted.
+func (c *Config) yamlNode() *yaml.Node {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, o := range options {
+		parent := root
+		keys := strings.Split(o.Path, ".")
+		for _, key := range keys[:len(keys)-1] {
+			parent = yamlChild(parent, key)
+		}
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: keys[len(keys)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: o.value(c)},
+		)
+	}
+	return root
+}
+
+func yamlChild(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+const minAdminTokenLength = 16
+
+// Validate checks the configuration as a whole and reports every problem at
+// once as a *ValidationError.
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(oneOf(c.Env, "local", "dev", "prod"), "APP_ENV: must be local, dev or prod, got %q", c.Env)
+
+	check(validPort(c.Server.Port), "SERVER_PORT: %d is not a valid port", c.Server.Port)
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", c.Server.ReadTimeout},
+		{"SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout},
+		{"SERVER_SHUTDOWN_DELAY", c.Server.ShutdownDelay},
+	} {
+		check(d.value >= 0, "%s: must not be negative", d.key)
+	}
+	check(c.Server.ShutdownTimeout > 0, "SERVER_SHUTDOWN_TIMEOUT: must be positive")
+	check(c.Server.MaxHeaderBytes > 0, "SERVER_MAX_HEADER_BYTES: must be positive")
+
+	if c.Server.PublicURL != "" {
+		u, err := url.Parse(c.Server.PublicURL)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.RawQuery == "" && u.Fragment == "",
+			"SERVER_PUBLIC_URL: %q is not an absolute http or https URL without query", c.Server.PublicURL)
+	}
+	for _, p := range c.Server.TrustedProxies {
+		_, errPrefix := netip.ParsePrefix(p)
+		_, errAddr := netip.ParseAddr(p)
+		check(errPrefix == nil || errAddr == nil, "SERVER_TRUSTED_PROXIES: %q is not an address or CIDR range", p)
+	}
+	if c.Server.PhotoURLSecret != "" {
+		check(len(c.Server.PhotoURLSecret) >= 32, "SERVER_PHOTO_URL_SECRET: must be at least 32 bytes")
+		check(c.Server.PhotoURLTTL > 0, "SERVER_PHOTO_URL_TTL: must be positive")
+	}
+
+	tls := c.Server.TLS
+	check((tls.CertFile == "") == (tls.KeyFile == ""), "SERVER_TLS_CERT_FILE, SERVER_TLS_KEY_FILE: must be set together")
+	check(tls.ClientCAFile == "" || tls.CertFile != "", "SERVER_TLS_CLIENT_CA_FILE: requires SERVER_TLS_CERT_FILE and SERVER_TLS_KEY_FILE")
+
+	db := c.Database
+	if db.DSN == "" {
+		check(db.Host != "", "DB_HOST: required")
+		check(validPort(db.Port), "DB_PORT: %d is not a valid port", db.Port)
+		check(db.User != "", "DB_USER: required")
+		check(db.DBName != "", "DB_NAME: required")
+		check(db.Password != "" || c.Env == "local", "DB_PASSWORD: required outside the local environment")
+		check(oneOf(db.SSLMode, "disable", "require", "verify-ca", "verify-full"),
+			"DB_SSLMODE: must be disable, require, verify-ca or verify-full, got %q", db.SSLMode)
+		check((db.SSLCert == "") == (db.SSLKey == ""), "DB_SSLCERT, DB_SSLKEY: must be set together")
+	} else {
+		_, err := db.ConnString()
+		check(err == nil, "DB_DSN: %v", err)
+	}
+	check(db.StatementTimeout >= 0, "DB_STATEMENT_TIMEOUT: must not be negative")
+	check(db.ConnectTimeout >= 0, "DB_CONNECT_TIMEOUT: must not be negative")
+	check(db.MaxOpenConns >= 0, "DB_MAX_OPEN_CONNS: must not be negative")
+	check(db.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS: must not be negative")
+	check(db.MaxOpenConns == 0 || db.MaxIdleConns <= db.MaxOpenConns, "DB_MAX_IDLE_CONNS: must not exceed DB_MAX_OPEN_CONNS")
+	check(db.ConnMaxLifetime >= 0 && db.ConnMaxIdleTime >= 0, "DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME: must not be negative")
+	check(db.ConnectRetryTimeout >= 0, "DB_CONNECT_RETRY_TIMEOUT: must not be negative")
+	for i := range db.ReplicaDSNs {
+		_, err := db.replica(i).ConnString()
+		check(err == nil, "DB_REPLICA_DSNS: replica %d: %v", i+1, err)
+	}
+	check(len(db.ReplicaDSNs) == 0 || db.ReplicaCheckInterval > 0, "DB_REPLICA_CHECK_INTERVAL: must be positive when replicas are set")
+	check(db.ReplicaMaxLag >= 0, "DB_REPLICA_MAX_LAG: must not be negative")
+
+	check(validPort(c.SMTP.Port), "MAIL_PORT: %d is not a valid port", c.SMTP.Port)
+	check(c.SMTP.Username == "" || c.SMTP.Password != "", "MAIL_PASSWORD: required when MAIL_USER is set")
+
+	check(c.Health.CheckTimeout > 0, "HEALTH_CHECK_TIMEOUT: must be positive")
+	check(!c.Health.CheckSMTP || c.SMTP.Host != "", "HEALTH_CHECK_SMTP: requires MAIL_HOST")
+
+	check(oneOf(c.Tracing.Exporter, "none", "stdout", "file", "otlp"), "TRACING_EXPORTER: must be none, stdout, file or otlp, got %q", c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "file" || c.Tracing.FilePath != "", "TRACING_FILE: required by the file exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO: must be between 0 and 1")
+
+	if c.Log.Level != "" {
+		var l slog.Level
+		check(l.UnmarshalText([]byte(c.Log.Level)) == nil, "LOG_LEVEL: %q is not a log level", c.Log.Level)
+	}
+	for _, sink := range c.Log.Sinks {
+		check(oneOf(sink, "stdout", "stderr", "file"), "LOG_SINKS: unknown sink %q", sink)
+		if sink == "file" {
+			check(c.Log.File.Path != "", "LOG_FILE_PATH: required by the file sink")
+		}
+	}
+	check(c.Log.File.MaxSizeMB >= 0 && c.Log.File.MaxBackups >= 0 && c.Log.File.MaxAgeDays >= 0,
+		"LOG_FILE_MAX_SIZE_MB, LOG_FILE_MAX_BACKUPS, LOG_FILE_MAX_AGE_DAYS: must not be negative")
+	check(c.Log.File.RotateInterval >= 0, "LOG_FILE_ROTATE_INTERVAL: must not be negative")
+	if c.Log.Sampling.First > 0 {
+		check(c.Log.Sampling.Thereafter >= 0, "LOG_SAMPLE_THEREAFTER: must not be negative")
+		check(c.Log.Sampling.Interval > 0, "LOG_SAMPLE_INTERVAL: must be positive when sampling is enabled")
+		check(len(c.Log.Sampling.Messages) > 0, "LOG_SAMPLE_MESSAGES: required when sampling is enabled")
+	}
+	check(c.Log.Sampling.First >= 0, "LOG_SAMPLE_FIRST: must not be negative")
+
+	check(c.Admin.Token == "" || len(c.Admin.Token) >= minAdminTokenLength,
+		"ADMIN_TOKEN: must be at least %d characters", minAdminTokenLength)
+
+	check(oneOf(c.Cache.Backend, "none", "memory", "redis"), "CACHE_BACKEND: must be none, memory or redis, got %q", c.Cache.Backend)
+	if c.Cache.Backend != "none" {
+		check(c.Cache.TTL > 0, "CACHE_TTL: must be positive when caching is enabled")
+	}
+	check(c.Cache.Backend != "memory" || c.Cache.Size > 0, "CACHE_SIZE: must be positive for the memory backend")
+	if c.Cache.Backend == "redis" {
+		check(c.Cache.Redis.Addr != "", "REDIS_ADDR: required by the redis backend")
+		check(c.Cache.Redis.DB >= 0, "REDIS_DB: must not be negative")
+		check(c.Cache.Redis.PoolSize > 0, "REDIS_POOL_SIZE: must be positive")
+		check(c.Cache.Redis.Timeout > 0, "REDIS_TIMEOUT: must be positive")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func oneOf(v string, allowed ...string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
@@ -15,39 +15,45 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/millionaires": {
+        "/admin/home/sections": {
             "get": {
-                "description": "Fetches a paginated list of millionaires from the database.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "millionaires"
-                ],
-                "summary": "Get all millionaires",
-                "parameters": [
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "pageNum",
-                        "in": "query"
+                        "AdminToken": []
                     },
                     {
-                        "type": "integer",
-                        "description": "Page size (default: 10)",
-                        "name": "pageSize",
-                        "in": "query"
+                        "AdminBasic": []
                     }
                 ],
+                "description": "Lists the section definitions of the homepage by position, hidden ones included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get homepage sections",
                 "responses": {
                     "200": {
-                        "description": "List of millionaires retrieved successfully",
+                        "description": "Sections",
                         "schema": {
-                            "$ref": "#/definitions/models.PaginationMillionaireDto"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HomeSection"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Error retrieving data",
+                        "description": "Error listing sections",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -58,7 +64,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Adds a new millionaire to the database.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
+                ],
+                "description": "Adds a section: top (richest), newcomers (added last), gainers or losers (net worth change over windowDays), each of up to size millionaires and optionally only those in countries or industries (codes or names); or featured, listing millionaireIds in their order. Without a position the section goes last.",
                 "consumes": [
                     "application/json"
                 ],
@@ -66,23 +80,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "millionaires"
+                    "admin"
                 ],
-                "summary": "Create a new millionaire",
+                "summary": "Create a homepage section",
                 "parameters": [
                     {
-                        "description": "Millionaire data",
-                        "name": "millionaire",
+                        "description": "Section",
+                        "name": "section",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Millionaire"
+                            "$ref": "#/definitions/models.HomeSection"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Millionaire created",
+                        "description": "Section created",
+                        "schema": {
+                            "$ref": "#/definitions/models.HomeSection"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid section",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -90,8 +110,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Incorrect JSON format",
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -100,7 +120,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Error creating millionaire",
+                        "description": "Error creating section",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -111,20 +131,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/millionaires/{id}": {
+        "/admin/home/sections/{id}": {
             "get": {
-                "description": "Fetches a millionaire's details using their unique ID.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "millionaires"
+                    "admin"
                 ],
-                "summary": "Get millionaire by ID",
+                "summary": "Get a homepage section",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Millionaire ID",
+                        "description": "Section ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -132,9 +159,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Millionaire retrieved successfully",
+                        "description": "Section",
                         "schema": {
-                            "$ref": "#/definitions/models.Millionaire"
+                            "$ref": "#/definitions/models.HomeSection"
                         }
                     },
                     "400": {
@@ -146,8 +173,26 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "Millionaire not found",
+                        "description": "Section not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error fetching section",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -158,7 +203,15 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates millionaire details based on the provided ID.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
+                ],
+                "description": "Replaces the section's definition, as described at creation. Reorder featured profiles by sending millionaireIds in the new order, and sections by changing their position.",
                 "consumes": [
                     "application/json"
                 ],
@@ -166,30 +219,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "millionaires"
+                    "admin"
                 ],
-                "summary": "Update a millionaire",
+                "summary": "Update a homepage section",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Millionaire ID",
+                        "description": "Section ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated millionaire data",
-                        "name": "millionaire",
+                        "description": "Section",
+                        "name": "section",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Millionaire"
+                            "$ref": "#/definitions/models.HomeSection"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Millionaire updated",
+                        "description": "Section updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.HomeSection"
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format or invalid section",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -197,8 +256,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Incorrect ID or JSON format",
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Section not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -207,7 +275,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Error updating millionaire",
+                        "description": "Error updating section",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -216,32 +284,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/photo/add/{millionaireId}": {
-            "post": {
-                "description": "Allows uploading a photo file for an existing millionaire.",
-                "consumes": [
-                    "multipart/form-data"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "millionaires"
+                    "admin"
                 ],
-                "summary": "Upload a photo for a millionaire",
+                "summary": "Delete a homepage section",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Photo file to upload",
-                        "name": "photo",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "Millionaire ID",
+                        "description": "Section ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -249,7 +312,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Photo uploaded successfully",
+                        "description": "Section deleted",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -258,7 +321,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Error receiving file",
+                        "description": "Incorrect ID format",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -266,40 +329,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Error uploading or updating photo",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/photo/delete/{millionaireId}": {
-            "delete": {
-                "description": "Removes the associated photo of a millionaire and clears its record in the database.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "millionaires"
-                ],
-                "summary": "Delete a millionaire's photo",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Millionaire ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Photo deleted successfully",
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -308,7 +339,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "No photo found for this millionaire",
+                        "description": "Section not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -317,7 +348,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Error deleting or clearing photo path",
+                        "description": "Error deleting section",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -328,40 +359,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/photo/{imageName}": {
+        "/admin/log-level": {
             "get": {
-                "description": "Serves an image file from the uploads/photos directory based on the provided image name.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
+                ],
+                "description": "Returns the minimum level currently written to the log sinks.",
                 "produces": [
-                    "image/jpeg"
+                    "application/json"
                 ],
                 "tags": [
-                    "millionaires"
-                ],
-                "summary": "Get a millionaire's photo",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Image filename",
-                        "name": "imageName",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Get log level",
                 "responses": {
                     "200": {
-                        "description": "Returns the requested image file"
-                    },
-                    "400": {
-                        "description": "Image name is required",
+                        "description": "Current log level",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handler.LogLevelDto"
                         }
                     },
-                    "404": {
-                        "description": "Image not found",
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -370,11 +394,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/feedback": {
-            "post": {
-                "description": "Accepts JSON feedback and sends it via email.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
+                ],
+                "description": "Changes the minimum level for all log sinks at runtime (debug, info, warn, error).",
                 "consumes": [
                     "application/json"
                 ],
@@ -382,39 +412,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feedback"
+                    "admin"
                 ],
-                "summary": "Send feedback",
+                "summary": "Set log level",
                 "parameters": [
                     {
-                        "description": "Feedback data",
-                        "name": "feedback",
+                        "description": "New log level",
+                        "name": "level",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.FeedbackDto"
+                            "$ref": "#/definitions/handler.LogLevelDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Feedback successfully sent",
+                        "description": "Log level changed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handler.LogLevelDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid data format or validation error",
+                        "description": "Invalid log level",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Error while sending feedback",
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -425,23 +454,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/home": {
+        "/api/companies": {
             "get": {
-                "description": "Fetches homepage-related data and returns it as JSON.",
+                "description": "Fetches companies ordered by name, optionally only those whose name contains the given text.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "home"
+                    "companies"
+                ],
+                "summary": "Get companies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the company name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get homepage data",
                 "responses": {
                     "200": {
-                        "description": "Homepage data successfully retrieved",
-                        "schema": {}
+                        "description": "List of companies",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginationCompanyDto"
+                        }
                     },
                     "500": {
-                        "description": "Failed to get homepage data",
+                        "description": "Error listing companies",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -450,11 +501,1829 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/millionaires/search": {
-            "get": {
-                "description": "Searches for millionaires using optional filters such as name and country.",
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Create a company",
+                "parameters": [
+                    {
+                        "description": "Company data",
+                        "name": "company",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Company created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect JSON format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Legal ID already used",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error creating company",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/companies/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Get company by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company",
+                        "schema": {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Update a company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated company data",
+                        "name": "company",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID or JSON format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Legal ID already used",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error updating company",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Delete a company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Company still has holdings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error deleting company",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/companies/{id}/people": {
+            "get": {
+                "description": "Lists everyone holding or having held a part in the company, current holdings first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Get the people behind a company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Holdings with their holders",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CompanyPerson"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error listing people",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/countries": {
+            "get": {
+                "description": "Lists all countries ordered by name. Names are in the requested language where a translation exists and in English otherwise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocabularies"
+                ],
+                "summary": "Get countries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language of the names: en, ru or kk (default: en)",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Countries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Country"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error listing countries",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/countries/{code}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocabularies"
+                ],
+                "summary": "Get country by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Country",
+                        "schema": {
+                            "$ref": "#/definitions/models.Country"
+                        }
+                    },
+                    "404": {
+                        "description": "Country not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error fetching country",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/duplicates": {
+            "get": {
+                "description": "Scores pairs of millionaires on the similarity of their names, normalized and transliterated to Latin letters (either name order), and on whether their birth dates and companies agree. Only names starting alike are compared. Pairs are ordered by score, highest first; merge them with POST /api/millionaires/merge.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Find duplicate millionaires",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Lowest score to report, above 0 and at most 1 (default: 0.85)",
+                        "name": "minScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of pairs, at most 500 (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Possible duplicates",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DuplicatePair"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid minScore or limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error finding duplicates",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/industries": {
+            "get": {
+                "description": "Returns the industry taxonomy as a tree of top-level industries with their children.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocabularies"
+                ],
+                "summary": "Get industries",
+                "responses": {
+                    "200": {
+                        "description": "Industry tree",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Industry"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error listing industries",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/industries/{code}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocabularies"
+                ],
+                "summary": "Get industry by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Industry code, e.g. technology.software",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Industry with its children",
+                        "schema": {
+                            "$ref": "#/definitions/models.Industry"
+                        }
+                    },
+                    "404": {
+                        "description": "Industry not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error fetching industry",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/millionaires": {
+            "get": {
+                "description": "Fetches a paginated list of millionaires from the database, without their biography and education.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Get all millionaires",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,lastName,firstName,netWorth (default: all)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert net worth to at the rate of its valuation day, e.g. USD, KZT or EUR",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of millionaires retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginationMillionaireDto"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS (with X-Consistency: strong)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown field or currency",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new millionaire to the database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Create a new millionaire",
+                "parameters": [
+                    {
+                        "description": "Millionaire data",
+                        "name": "millionaire",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Millionaire"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Millionaire created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect JSON format, unknown country or industry code, or too many decimal places",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error creating millionaire",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/millionaires/merge": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    },
+                    {
+                        "AdminBasic": []
+                    }
+                ],
+                "description": "Merges the source millionaire into the target, which keeps its ID. fields picks \"target\" or \"source\" per field by JSON name; other fields keep the target's value unless it is empty. netWorth brings netWorthCurrency and netWorthAsOf along, country and industry their codes. The source's net worth history (winning on shared days when its netWorth is picked), holdings and featured homepage spots move to the target. The source is deleted, GET on its ID answers 301 with the target, and the merge is written to the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Merge two millionaires",
+                "parameters": [
+                    {
+                        "description": "Millionaires to merge and field winners",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Merged millionaire",
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, same millionaire or unknown field",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error merging millionaires",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/millionaires/{id}": {
+            "get": {
+                "description": "Fetches a millionaire's details using their unique ID. The ID of a millionaire merged into another answers 301 with the other's URL.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Get millionaire by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,lastName,firstName,netWorth (default: all)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert net worth to at the rate of its valuation day, e.g. USD, KZT or EUR",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Millionaire retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Millionaire"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS (with X-Consistency: strong)"
+                            }
+                        }
+                    },
+                    "301": {
+                        "description": "Millionaire merged into the one at Location",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the millionaire merged into"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format, unknown field or unknown currency",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error converting net worth",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates millionaire details based on the provided ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Update a millionaire",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated millionaire data",
+                        "name": "millionaire",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Millionaire"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Millionaire updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID or JSON format, unknown country or industry code, or too many decimal places",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error updating millionaire",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/millionaires/{id}/holdings": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Get a millionaire's holdings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Holdings, most valuable first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Holding"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error listing holdings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Add a holding",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Holding data",
+                        "name": "holding",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Holding"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Holding added",
+                        "schema": {
+                            "$ref": "#/definitions/models.Holding"
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID or JSON format, or invalid value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire or company not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error adding holding",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/millionaires/{id}/holdings/{holdingId}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Update a holding",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holding ID",
+                        "name": "holdingId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated holding data",
+                        "name": "holding",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Holding"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Holding updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID or JSON format, or invalid value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire, company or holding not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error updating holding",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Delete a holding",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holding ID",
+                        "name": "holdingId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Holding deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Holding not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error deleting holding",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/millionaires/{id}/net-worth-breakdown": {
+            "get": {
+                "description": "Attributes the millionaire's net worth to current holdings by their value; \"other\" is the remainder.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Get a net worth breakdown",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Net worth by holding",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetWorthBreakdown"
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect ID format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error computing breakdown",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/photo/add/{millionaireId}": {
+            "post": {
+                "description": "Allows uploading a photo file for an existing millionaire.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Upload a photo for a millionaire",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Photo file to upload",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Photo uploaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Error receiving file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Millionaire not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error uploading or updating photo",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/photo/delete/{millionaireId}": {
+            "delete": {
+                "description": "Removes the associated photo of a millionaire and clears its record in the database.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Delete a millionaire's photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Millionaire ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Photo deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No photo found for this millionaire",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error deleting or clearing photo path",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/photo/{imageName}": {
+            "get": {
+                "description": "Serves an image file from the uploads/photos directory based on the provided image name.\nResponses carry a content-hash ETag and Last-Modified, honour conditional and range requests,\nand serve an AVIF or WebP variant instead of the original when the Accept header allows it.\nsize=thumb serves the thumbnail, or the photo itself when it has none. With SERVER_PHOTO_URL_SECRET set,\nonly the signed URLs the API returns are served, until they expire.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp",
+                    "image/avif"
+                ],
+                "tags": [
+                    "millionaires"
+                ],
+                "summary": "Get a millionaire's photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image filename",
+                        "name": "imageName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "thumb"
+                        ],
+                        "type": "string",
+                        "description": "thumb for the thumbnail",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry of a signed URL, in Unix seconds",
+                        "name": "expires",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of a signed URL",
+                        "name": "signature",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Accepted image types, e.g. image/avif,image/webp,*/*",
+                        "name": "Accept",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Byte range, e.g. bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the requested image file",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Caching policy"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong validator derived from the file content"
+                            },
+                            "Vary": {
+                                "type": "string",
+                                "description": "Accept"
+                            }
+                        }
+                    },
+                    "206": {
+                        "description": "Returns the requested byte range",
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Caching policy"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong validator derived from the file content"
+                            },
+                            "Vary": {
+                                "type": "string",
+                                "description": "Accept"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Image not modified"
+                    },
+                    "400": {
+                        "description": "Image name is required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "URL not signed, wrongly signed or expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats": {
+            "get": {
+                "description": "Count, total, mean, median, percentiles, Gini coefficient and top 1%/10% shares of the net worths of the millionaires matching the search filter, converted to one currency at the rate of each valuation's day. Millionaires whose net worth cannot be converted are left out and counted as unconverted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get net worth statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the last name",
+                        "name": "lastName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the first name",
+                        "name": "firstName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the middle name",
+                        "name": "middleName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code, name, or part of the free-text country, as in the search",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Industry code, name, or part of the free-text industry, as in the search",
+                        "name": "industry",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the statistics (default: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatsSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown currency",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error computing statistics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/by/{dimension}": {
+            "get": {
+                "description": "The statistics of /api/stats for each country or industry code (the free text when there is none), age bracket (\u003c30, 30-39 ... 70-79, 80+) or birth decade (1950s). Countries and industries are ordered by total, largest first; millionaires without the value are grouped as \"unknown\", last. With format=csv the groups come as a CSV table.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get net worth statistics by group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "country, industry, age or decade",
+                        "name": "dimension",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the last name",
+                        "name": "lastName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the first name",
+                        "name": "firstName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the middle name",
+                        "name": "middleName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code, name, or part of the free-text country, as in the search",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Industry code, name, or part of the free-text industry, as in the search",
+                        "name": "industry",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the statistics (default: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or csv (default: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics by group",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatsGroups"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown dimension, currency or format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error computing statistics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/histogram": {
+            "get": {
+                "description": "Counts and totals the net worths of the millionaires matching the search filter between consecutive bucket boundaries. The first bucket has no lower bound and the last no upper one. With format=csv the buckets come as a CSV table.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get net worth histogram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated, strictly increasing boundaries (default: 1000000,10000000,100000000,1000000000,10000000000,100000000000)",
+                        "name": "buckets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the last name",
+                        "name": "lastName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the first name",
+                        "name": "firstName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the middle name",
+                        "name": "middleName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code, name, or part of the free-text country, as in the search",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Industry code, name, or part of the free-text industry, as in the search",
+                        "name": "industry",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the statistics (default: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or csv (default: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Histogram",
+                        "schema": {
+                            "$ref": "#/definitions/models.Histogram"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid buckets, unknown currency or format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error computing statistics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/feedback": {
+            "post": {
+                "description": "Accepts JSON feedback and sends it via email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedback"
+                ],
+                "summary": "Send feedback",
+                "parameters": [
+                    {
+                        "description": "Feedback data",
+                        "name": "feedback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedbackDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feedback successfully sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data format or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Error while sending feedback",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Returns 200 as long as the process is able to serve HTTP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Process is alive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/home": {
+            "get": {
+                "description": "Returns the homepage sections that are not hidden, in order, each with its millionaires. Gainers and losers also carry the net worth change in USD.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "home"
+                ],
+                "summary": "Get homepage data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert net worth to; rankings and changes themselves are in USD",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Homepage data successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/models.HomePageDto"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS (with X-Consistency: strong)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown currency",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get homepage data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/millionaires/search": {
+            "get": {
+                "description": "Searches for millionaires using optional filters such as name and country. Results leave out the biography and education.",
                 "produces": [
                     "application/json"
                 ],
@@ -483,10 +2352,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Country of the millionaire",
+                        "description": "Country code (ISO 3166-1 alpha-2 or alpha-3), country name in any language, or part of the free-text country",
                         "name": "country",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Industry code or name, matching the industries below it too, or part of the free-text industry",
+                        "name": "industry",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -500,6 +2375,18 @@ const docTemplate = `{
                         "description": "Number of records per page",
                         "name": "pageSize",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,lastName,firstName,netWorth (default: all)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert net worth to at the rate of its valuation day, e.g. USD, KZT or EUR",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -510,6 +2397,21 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/models.Millionaire"
                             }
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS (with X-Consistency: strong)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown field or currency",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -573,58 +2475,551 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.FeedbackDto": {
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks the database, schema version, photo storage and optionally SMTP.\nFails while the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "All checks passed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReadinessDto"
+                        }
+                    },
+                    "503": {
+                        "description": "At least one check failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReadinessDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Returns the version, git commit, build time and database schema version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Build and schema version",
+                "responses": {
+                    "200": {
+                        "description": "Version information",
+                        "schema": {
+                            "$ref": "#/definitions/models.VersionDto"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.LogLevelDto": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "DEBUG"
+                }
+            }
+        },
+        "models.Company": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "founded": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "legalId": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "ticker": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompanyPerson": {
+            "type": "object",
+            "required": [
+                "companyId",
+                "role"
+            ],
+            "properties": {
+                "companyId": {
+                    "type": "integer"
+                },
+                "companyName": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "endedOn": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "millionaireId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "founder",
+                        "ceo",
+                        "shareholder"
+                    ]
+                },
+                "stakePercent": {
+                    "type": "number",
+                    "maximum": 100
+                },
+                "startedOn": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Country": {
+            "type": "object",
+            "properties": {
+                "alpha3": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.DuplicatePair": {
+            "type": "object",
+            "properties": {
+                "birthDate": {
+                    "type": "number",
+                    "example": 1
+                },
+                "company": {
+                    "type": "number",
+                    "example": 0.8
+                },
+                "millionaires": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Millionaire"
+                    }
+                },
+                "name": {
+                    "type": "number",
+                    "example": 0.97
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.94
+                }
+            }
+        },
+        "models.FeedbackDto": {
+            "type": "object",
+            "required": [
+                "email",
+                "message",
+                "name"
+            ],
+            "properties": {
+                "cityOrRegion": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gratitudeExpression": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Histogram": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HistogramBucket"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "unconverted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HistogramBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Holding": {
+            "type": "object",
+            "required": [
+                "companyId",
+                "role"
+            ],
+            "properties": {
+                "companyId": {
+                    "type": "integer"
+                },
+                "companyName": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "endedOn": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "millionaireId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "founder",
+                        "ceo",
+                        "shareholder"
+                    ]
+                },
+                "stakePercent": {
+                    "type": "number",
+                    "maximum": 100
+                },
+                "startedOn": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.HomeEntry": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "$ref": "#/definitions/models.NetWorthChange"
+                },
+                "millionaire": {
+                    "$ref": "#/definitions/models.Millionaire"
+                }
+            }
+        },
+        "models.HomePageDto": {
+            "type": "object",
+            "properties": {
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HomeSectionDto"
+                    }
+                }
+            }
+        },
+        "models.HomeSection": {
             "type": "object",
             "required": [
-                "email",
-                "message",
-                "name"
+                "kind",
+                "title"
             ],
             "properties": {
-                "cityOrRegion": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "KZ"
+                    ]
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "email": {
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "industries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "technology"
+                    ]
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "top",
+                        "newcomers",
+                        "gainers",
+                        "losers",
+                        "featured"
+                    ],
+                    "example": "top"
+                },
+                "millionaireIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 10
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Richest in Kazakhstan"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
-                "gratitudeExpression": {
+                "windowDays": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 365
+                }
+            }
+        },
+        "models.HomeSectionDto": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HomeEntry"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
                     "type": "string"
                 },
-                "message": {
+                "title": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Industry": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Industry"
+                    }
                 },
-                "name": {
+                "code": {
                     "type": "string"
                 },
-                "organization": {
+                "name": {
                     "type": "string"
                 },
-                "position": {
+                "parentCode": {
                     "type": "string"
                 }
             }
         },
+        "models.MergeRequest": {
+            "type": "object",
+            "required": [
+                "sourceId",
+                "targetId"
+            ],
+            "properties": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sourceId": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 48
+                },
+                "targetId": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 12
+                }
+            }
+        },
+        "models.MergeResult": {
+            "type": "object",
+            "properties": {
+                "fromSource": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "millionaire": {
+                    "$ref": "#/definitions/models.Millionaire"
+                }
+            }
+        },
         "models.Millionaire": {
             "type": "object",
+            "required": [
+                "citizenships",
+                "socialHandles"
+            ],
             "properties": {
+                "biography": {
+                    "description": "Biography is Markdown; BiographyHTML is its sanitized rendering and is\nonly filled in when a single millionaire is fetched.",
+                    "type": "string"
+                },
+                "biographyHtml": {
+                    "type": "string",
+                    "readOnly": true
+                },
                 "birthDate": {
                     "type": "string"
                 },
                 "birthPlace": {
                     "type": "string"
                 },
+                "childrenCount": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 0
+                },
+                "citizenships": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "company": {
                     "type": "string"
                 },
                 "country": {
                     "type": "string"
                 },
+                "countryCode": {
+                    "description": "CountryCode (ISO 3166-1 alpha-2) and IndustryCode are looked up from\nCountry and Industry when they are not given.",
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "education": {
+                    "type": "string"
+                },
                 "firstName": {
                     "type": "string"
                 },
@@ -634,20 +3029,165 @@ const docTemplate = `{
                 "industry": {
                     "type": "string"
                 },
+                "industryCode": {
+                    "type": "string"
+                },
                 "lastName": {
                     "type": "string"
                 },
+                "maritalStatus": {
+                    "type": "string",
+                    "enum": [
+                        "single",
+                        "married",
+                        "divorced",
+                        "widowed",
+                        "partnered"
+                    ]
+                },
                 "middleName": {
                     "type": "string"
                 },
                 "netWorth": {
+                    "description": "NetWorth is valued in NetWorthCurrency (ISO 4217) on NetWorthAsOf;\nthey default to USD and today. It may have as many decimal places as\nthe currency has minor units.",
                     "type": "number"
                 },
+                "netWorthAsOf": {
+                    "type": "string"
+                },
+                "netWorthCurrency": {
+                    "type": "string"
+                },
                 "pathToPhoto": {
+                    "description": "PathToPhoto is stored as a path under uploads/photos; responses carry\nthe absolute URL the photo is served at, which is accepted back, and\nThumbnailURL that of its thumbnail.",
+                    "type": "string",
+                    "example": "https://api.example.com/api/photo/12_0123456789abcdef.jpg"
+                },
+                "residenceCity": {
                     "type": "string"
                 },
+                "socialHandles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "thumbnailUrl": {
+                    "type": "string",
+                    "readOnly": true,
+                    "example": "https://api.example.com/api/photo/12_0123456789abcdef.jpg?size=thumb"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NetWorthBreakdown": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "holdings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Holding"
+                    }
+                },
+                "millionaireId": {
+                    "type": "integer"
+                },
+                "netWorth": {
+                    "type": "number"
+                },
+                "other": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.NetWorthChange": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "number"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.NetWorthStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "gini": {
+                    "description": "Gini is the Gini coefficient, 0 when everyone is equally rich.",
+                    "type": "number"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "percentiles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "top10Share": {
+                    "type": "number"
+                },
+                "top1Share": {
+                    "description": "Top1Share and Top10Share are the parts of Total held by the richest\n1% and 10%, at least one person each.",
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PaginationCompanyDto": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Company"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
@@ -670,6 +3210,123 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.ReadinessDto": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HealthCheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StatsGroups": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "dimension": {
+                    "type": "string"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetWorthStats"
+                    }
+                },
+                "unconverted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StatsSummary": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "gini": {
+                    "description": "Gini is the Gini coefficient, 0 when everyone is equally rich.",
+                    "type": "number"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "percentiles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "top10Share": {
+                    "type": "number"
+                },
+                "top1Share": {
+                    "description": "Top1Share and Top10Share are the parts of Total held by the richest\n1% and 10%, at least one person each.",
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "unconverted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.VersionDto": {
+            "type": "object",
+            "properties": {
+                "buildTime": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "expectedSchemaVersion": {
+                    "type": "integer"
+                },
+                "goVersion": {
+                    "type": "string"
+                },
+                "schemaVersion": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminBasic": {
+            "type": "basic"
+        },
+        "AdminToken": {
+            "description": "Admin API token, sent as \"Bearer \u003cADMIN_TOKEN\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
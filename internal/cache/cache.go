@@ -0,0 +1,191 @@
+// Package cache keeps encoded service responses in a Store so that hot
+// reads skip the database. Entries are never updated in place: every key
+// carries a generation number, and Invalidate moves every instance sharing
+// the store to a new generation at once. Concurrent misses for one key are
+// loaded once.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Store keeps values for a limited time. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get reports whether key was found and has not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Incr adds one to the counter at key, which starts at zero and never
+	// expires, and returns the new value.
+	Incr(ctx context.Context, key string) (int64, error)
+	Close() error
+}
+
+// Header tells clients whether the response came from the cache.
+const Header = "X-Cache"
+
+// Result is the value of Header.
+type Result string
+
+const (
+	Hit    Result = "HIT"
+	Miss   Result = "MISS"
+	Bypass Result = "BYPASS"
+)
+
+const (
+	prefix        = "wealthlist:cache:"
+	generationKey = prefix + "generation"
+)
+
+type Cache struct {
+	store Store
+	ttl   time.Duration
+	log   *slog.Logger
+	group singleflight.Group
+}
+
+func New(store Store, ttl time.Duration, log *slog.Logger) *Cache {
+	return &Cache{store: store, ttl: ttl, log: log}
+}
+
+// Do returns the value cached under name and key, or loads, caches and
+// returns it. Values are stored as JSON, so every caller gets its own copy.
+// If the store fails the value is loaded as if it were not cached. Errors
+// are not cached.
+func Do[T any](ctx context.Context, c *Cache, name, key string, load func(context.Context) (T, error)) (T, error) {
+	var v T
+
+	full, err := c.key(ctx, name, key)
+	if err != nil {
+		c.fail(ctx, name, "Could not read cache generation", err)
+		report(ctx, Miss)
+		return load(ctx)
+	}
+
+	b, ok, err := c.store.Get(ctx, full)
+	switch {
+	case err != nil:
+		c.fail(ctx, name, "Could not read from cache", err)
+	case ok:
+		if err := json.Unmarshal(b, &v); err == nil {
+			metrics.ObserveCache(name, "hit")
+			report(ctx, Hit)
+			return v, nil
+		}
+		c.fail(ctx, name, "Could not decode cached value", err)
+	}
+
+	metrics.ObserveCache(name, "miss")
+	report(ctx, Miss)
+
+	shared, err, _ := c.group.Do(full, func() (interface{}, error) {
+		// Other callers wait for this load, so it must not end with the
+		// request that started it.
+		ctx := context.WithoutCancel(ctx)
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.store.Set(ctx, full, b, c.ttl); err != nil {
+			c.fail(ctx, name, "Could not write to cache", err)
+		}
+		return b, nil
+	})
+	if err != nil {
+		return v, err
+	}
+	err = json.Unmarshal(shared.([]byte), &v)
+	return v, err
+}
+
+// Skip loads the value without the cache, e.g. when the client asked for
+// fresh data.
+func Skip[T any](ctx context.Context, name string, load func(context.Context) (T, error)) (T, error) {
+	metrics.ObserveCache(name, "bypass")
+	report(ctx, Bypass)
+	return load(ctx)
+}
+
+// Close closes the store.
+func (c *Cache) Close() error {
+	return c.store.Close()
+}
+
+// Invalidate drops every cached value, in every instance sharing the store.
+func (c *Cache) Invalidate(ctx context.Context) {
+	generation, err := c.store.Incr(ctx, generationKey)
+	if err != nil {
+		// Stale values stay until their TTL runs out.
+		c.log.ErrorContext(ctx, "Could not invalidate cache", logger.Err(err))
+		return
+	}
+	metrics.CacheInvalidations.Inc()
+	c.log.DebugContext(ctx, "Cache invalidated", slog.Int64("generation", generation))
+}
+
+func (c *Cache) key(ctx context.Context, name, key string) (string, error) {
+	generation := "0"
+	b, ok, err := c.store.Get(ctx, generationKey)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		generation = string(b)
+	}
+	return prefix + generation + ":" + name + ":" + key, nil
+}
+
+func (c *Cache) fail(ctx context.Context, name, msg string, err error) {
+	metrics.ObserveCache(name, "error")
+	c.log.WarnContext(ctx, msg, slog.String("cache", name), logger.Err(err))
+}
+
+// Key joins the parts of a cache key.
+func Key(parts ...interface{}) string {
+	b, _ := json.Marshal(parts)
+	return string(b)
+}
+
+type reporterKey struct{}
+
+// WithReporter returns a context in which Do and Skip call set with the
+// result of the request: a miss outweighs hits, and a bypass both.
+func WithReporter(ctx context.Context, set func(Result)) context.Context {
+	var current Result
+	return context.WithValue(ctx, reporterKey{}, func(r Result) {
+		if rank(r) > rank(current) {
+			current = r
+			set(r)
+		}
+	})
+}
+
+func report(ctx context.Context, r Result) {
+	if f, ok := ctx.Value(reporterKey{}).(func(Result)); ok {
+		f(r)
+	}
+}
+
+func rank(r Result) int {
+	switch r {
+	case Hit:
+		return 1
+	case Miss:
+		return 2
+	case Bypass:
+		return 3
+	}
+	return 0
+}
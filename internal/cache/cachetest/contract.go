@@ -0,0 +1,207 @@
+package cachetest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"wealthlist/internal/cache"
+)
+
+// Factory returns an empty store that is closed when the test ends.
+type Factory func(t testing.TB) cache.Store
+
+// Memory is a Factory for in-process stores of 100 entries.
+func Memory(t testing.TB) cache.Store {
+	return cache.NewMemory(100)
+}
+
+// Redis is a Factory for stores backed by a fresh RedisServer.
+func Redis(t testing.TB) cache.Store {
+	srv := NewRedisServer(t, "secret")
+	store := cache.NewRedis(cache.RedisOptions{Addr: srv.Addr(), Password: "secret", DB: 1, PoolSize: 2, Timeout: time.Second})
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// StoreContract checks a store and the cache over it.
+func StoreContract(t *testing.T, newStore Factory) {
+	for _, c := range []struct {
+		name string
+		run  func(t *testing.T, store cache.Store)
+	}{
+		{"GetSet", testGetSet},
+		{"Expiry", testExpiry},
+		{"Incr", testIncr},
+		{"Do", testDo},
+		{"Invalidate", testInvalidate},
+		{"Singleflight", testSingleflight},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newStore(t))
+		})
+	}
+}
+
+func logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func testGetSet(t *testing.T, store cache.Store) {
+	ctx := context.Background()
+
+	if _, ok, err := store.Get(ctx, "missing"); err != nil || ok {
+		t.Fatalf("get missing: ok %v, err %v", ok, err)
+	}
+	for _, value := range []string{"first", "second"} {
+		if err := store.Set(ctx, "key", []byte(value), time.Minute); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		got, ok, err := store.Get(ctx, "key")
+		if err != nil || !ok || string(got) != value {
+			t.Fatalf("get: %q, ok %v, err %v; want %q", got, ok, err, value)
+		}
+	}
+}
+
+func testExpiry(t *testing.T, store cache.Store) {
+	ctx := context.Background()
+
+	if err := store.Set(ctx, "short", []byte("v"), 20*time.Millisecond); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, ok, err := store.Get(ctx, "short"); err != nil || ok {
+		t.Errorf("get after ttl: ok %v, err %v", ok, err)
+	}
+}
+
+func testIncr(t *testing.T, store cache.Store) {
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		n, err := store.Incr(ctx, "counter")
+		if err != nil || n != want {
+			t.Fatalf("incr: %d, err %v; want %d", n, err, want)
+		}
+	}
+	got, ok, err := store.Get(ctx, "counter")
+	if err != nil || !ok || string(got) != "3" {
+		t.Errorf("get counter: %q, ok %v, err %v", got, ok, err)
+	}
+}
+
+type value struct {
+	N     int      `json:"n"`
+	Items []string `json:"items"`
+}
+
+func testDo(t *testing.T, store cache.Store) {
+	ctx := context.Background()
+	c := cache.New(store, time.Minute, logger())
+
+	loads := 0
+	load := func(context.Context) (value, error) {
+		loads++
+		return value{N: loads, Items: []string{"a"}}, nil
+	}
+
+	var results []cache.Result
+	ctx = cache.WithReporter(ctx, func(r cache.Result) { results = append(results, r) })
+
+	first, err := cache.Do(ctx, c, "test", "k", load)
+	if err != nil || first.N != 1 {
+		t.Fatalf("first: %+v, err %v", first, err)
+	}
+	first.Items[0] = "changed"
+
+	second, err := cache.Do(ctx, c, "test", "k", load)
+	if err != nil || second.N != 1 || loads != 1 {
+		t.Fatalf("second: %+v after %d loads, err %v; want the cached value", second, loads, err)
+	}
+	if second.Items[0] != "a" {
+		t.Errorf("cached value shares memory with a returned one: %v", second.Items)
+	}
+	if len(results) != 1 || results[0] != cache.Miss {
+		t.Errorf("reported %v, want a miss outweighing the hit", results)
+	}
+
+	other, err := cache.Do(ctx, c, "test", "other", load)
+	if err != nil || other.N != 2 {
+		t.Errorf("other key: %+v, err %v", other, err)
+	}
+
+	failed := errors.New("load failed")
+	if _, err := cache.Do(ctx, c, "test", "failing", func(context.Context) (value, error) { return value{}, failed }); !errors.Is(err, failed) {
+		t.Fatalf("failing load: %v", err)
+	}
+	got, err := cache.Do(ctx, c, "test", "failing", load)
+	if err != nil || got.N != 3 {
+		t.Errorf("after a failed load: %+v, err %v; errors must not be cached", got, err)
+	}
+}
+
+func testInvalidate(t *testing.T, store cache.Store) {
+	ctx := context.Background()
+	c := cache.New(store, time.Minute, logger())
+	// A second cache over the same store, as in another instance.
+	peer := cache.New(store, time.Minute, logger())
+
+	loads := 0
+	load := func(context.Context) (int, error) {
+		loads++
+		return loads, nil
+	}
+
+	if _, err := cache.Do(ctx, c, "test", "k", load); err != nil {
+		t.Fatal(err)
+	}
+	peer.Invalidate(ctx)
+	got, err := cache.Do(ctx, c, "test", "k", load)
+	if err != nil || got != 2 {
+		t.Errorf("after invalidation: %d, err %v; want a reload", got, err)
+	}
+}
+
+func testSingleflight(t *testing.T, store cache.Store) {
+	ctx := context.Background()
+	c := cache.New(store, time.Minute, logger())
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const callers = 10
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	results := make([]int, callers)
+	for i := range results {
+		go func() {
+			defer done.Done()
+			started.Done()
+			results[i], _ = cache.Do(ctx, c, "test", "k", load)
+		}()
+	}
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("%d concurrent misses loaded %d times, want once", callers, n)
+	}
+	for i, r := range results {
+		if r != 42 {
+			t.Errorf("caller %d got %d", i, r)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package cachetest stands in for Redis in tests and checks that cache
+// stores, and the cache over them, behave alike:
+//
+//	func TestMemory(t *testing.T) { cachetest.StoreContract(t, cachetest.Memory) }
+//	func TestRedis(t *testing.T)  { cachetest.StoreContract(t, cachetest.Redis) }
+package cachetest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"wealthlist/internal/cache"
+)
+
+// RedisServer is an in-process server for the commands cache.Redis sends:
+// PING, AUTH, SELECT, GET, SET with PX, INCR and FLUSHALL. Databases are not
+// kept apart.
+type RedisServer struct {
+	ln       net.Listener
+	password string
+
+	mu     sync.Mutex
+	values map[string]redisValue
+	conns  map[net.Conn]struct{}
+}
+
+type redisValue struct {
+	data    string
+	expires time.Time // zero for no expiry
+}
+
+// NewRedisServer listens on a free local port until the test ends. A
+// non-empty password must be given with AUTH before other commands.
+func NewRedisServer(t testing.TB, password string) *RedisServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &RedisServer{
+		ln:       ln,
+		password: password,
+		values:   make(map[string]redisValue),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *RedisServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Close stops the server and drops its connections, as a crashed Redis
+// would.
+func (s *RedisServer) Close() {
+	s.ln.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+	}
+}
+
+func (s *RedisServer) serve() {
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *RedisServer) handle(c net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.Close()
+	}()
+
+	r := bufio.NewReader(c)
+	authenticated := s.password == ""
+	for {
+		request, err := cache.ReadReply(r)
+		if err != nil {
+			return
+		}
+		values, ok := request.([]interface{})
+		if !ok || len(values) == 0 {
+			fmt.Fprint(c, "-ERR expected an array of bulk strings\r\n")
+			continue
+		}
+		args := make([]string, len(values))
+		for i, v := range values {
+			b, _ := v.([]byte)
+			args[i] = string(b)
+		}
+
+		name := strings.ToUpper(args[0])
+		switch {
+		case name == "AUTH":
+			authenticated = len(args) == 2 && args[1] == s.password
+			if !authenticated {
+				fmt.Fprint(c, "-WRONGPASS invalid password\r\n")
+				continue
+			}
+			fmt.Fprint(c, "+OK\r\n")
+		case !authenticated:
+			fmt.Fprint(c, "-NOAUTH Authentication required.\r\n")
+		default:
+			fmt.Fprint(c, s.exec(name, args[1:]))
+		}
+	}
+}
+
+func (s *RedisServer) exec(name string, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case name == "PING":
+		return "+PONG\r\n"
+	case name == "SELECT" && len(args) == 1:
+		return "+OK\r\n"
+	case name == "FLUSHALL":
+		s.values = make(map[string]redisValue)
+		return "+OK\r\n"
+	case name == "GET" && len(args) == 1:
+		v, ok := s.get(args[0])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(v.data)
+	case name == "SET" && (len(args) == 2 || len(args) == 4 && strings.EqualFold(args[2], "PX")):
+		v := redisValue{data: args[1]}
+		if len(args) == 4 {
+			ms, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil || ms <= 0 {
+				return "-ERR invalid expire time in 'set' command\r\n"
+			}
+			v.expires = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		s.values[args[0]] = v
+		return "+OK\r\n"
+	case name == "INCR" && len(args) == 1:
+		v, _ := s.get(args[0])
+		n := int64(0)
+		if v.data != "" {
+			var err error
+			if n, err = strconv.ParseInt(v.data, 10, 64); err != nil {
+				return "-ERR value is not an integer or out of range\r\n"
+			}
+		}
+		n++
+		s.values[args[0]] = redisValue{data: strconv.FormatInt(n, 10), expires: v.expires}
+		return ":" + strconv.FormatInt(n, 10) + "\r\n"
+	}
+	return fmt.Sprintf("-ERR unknown command or wrong number of arguments for '%s'\r\n", strings.ToLower(name))
+}
+
+func (s *RedisServer) get(key string) (redisValue, bool) {
+	v, ok := s.values[key]
+	if ok && !v.expires.IsZero() && !time.Now().Before(v.expires) {
+		delete(s.values, key)
+		return redisValue{}, false
+	}
+	return v, ok
+}
+
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
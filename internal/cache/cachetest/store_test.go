@@ -0,0 +1,9 @@
+package cachetest_test
+
+import (
+	"testing"
+	"wealthlist/internal/cache/cachetest"
+)
+
+func TestMemory(t *testing.T) { cachetest.StoreContract(t, cachetest.Memory) }
+func TestRedis(t *testing.T)  { cachetest.StoreContract(t, cachetest.Redis) }
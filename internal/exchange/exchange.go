@@ -0,0 +1,277 @@
+// Package exchange reads and writes millionaires as CSV or JSON for the
+// import and export commands.
+package exchange
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// columns are the CSV header. A citizenships cell separates countries with
+// semicolons; a social_handles cell holds a JSON object.
+var columns = []string{
+	"last_name", "first_name", "middle_name", "birth_date", "birth_place",
+	"company", "net_worth", "net_worth_currency", "net_worth_as_of", "industry", "country", "country_code", "industry_code",
+	"biography", "education", "citizenships", "marital_status", "children_count", "residence_city", "website", "social_handles",
+}
+
+// FormatOf picks the format from a file extension, defaulting to JSON.
+func FormatOf(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return FormatCSV
+	}
+	return FormatJSON
+}
+
+// Read decodes millionaires and checks that each has a first and last name.
+// Errors name the offending record, counting from 1.
+func Read(r io.Reader, format string) ([]models.Millionaire, error) {
+	var (
+		millionaires []models.Millionaire
+		err          error
+	)
+
+	switch format {
+	case FormatJSON:
+		err = json.NewDecoder(r).Decode(&millionaires)
+	case FormatCSV:
+		millionaires, err = readCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q, use %s or %s", format, FormatCSV, FormatJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, m := range millionaires {
+		if strings.TrimSpace(m.LastName) == "" || strings.TrimSpace(m.FirstName) == "" {
+			return nil, fmt.Errorf("record %d: first and last name are required", i+1)
+		}
+	}
+	return millionaires, nil
+}
+
+func readCSV(r io.Reader) ([]models.Millionaire, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.TrimSpace(h)] = i
+	}
+	for _, required := range []string{"last_name", "first_name"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("header: missing column %s", required)
+		}
+	}
+
+	var millionaires []models.Millionaire
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) *string {
+			i, ok := index[name]
+			if !ok || strings.TrimSpace(record[i]) == "" {
+				return nil
+			}
+			v := strings.TrimSpace(record[i])
+			return &v
+		}
+
+		m := models.Millionaire{
+			MiddleName:    field("middle_name"),
+			BirthDate:     field("birth_date"),
+			BirthPlace:    field("birth_place"),
+			Company:       field("company"),
+			NetWorthAsOf:  field("net_worth_as_of"),
+			Industry:      field("industry"),
+			Country:       field("country"),
+			CountryCode:   field("country_code"),
+			IndustryCode:  field("industry_code"),
+			Biography:     field("biography"),
+			Education:     field("education"),
+			MaritalStatus: field("marital_status"),
+			ResidenceCity: field("residence_city"),
+			Website:       field("website"),
+		}
+		if v := field("last_name"); v != nil {
+			m.LastName = *v
+		}
+		if v := field("first_name"); v != nil {
+			m.FirstName = *v
+		}
+		if v := field("net_worth_currency"); v != nil {
+			m.NetWorthCurrency = strings.ToUpper(*v)
+		}
+		if v := field("net_worth"); v != nil {
+			netWorth, err := money.Parse(*v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid net_worth %q", line, *v)
+			}
+			m.NetWorth = &netWorth
+		}
+		if v := field("citizenships"); v != nil {
+			for _, c := range strings.Split(*v, ";") {
+				if c = strings.TrimSpace(c); c != "" {
+					m.Citizenships = append(m.Citizenships, c)
+				}
+			}
+		}
+		if v := field("children_count"); v != nil {
+			n, err := strconv.Atoi(*v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid children_count %q", line, *v)
+			}
+			m.ChildrenCount = &n
+		}
+		if v := field("social_handles"); v != nil {
+			if err := json.Unmarshal([]byte(*v), &m.SocialHandles); err != nil {
+				return nil, fmt.Errorf("line %d: invalid social_handles %q", line, *v)
+			}
+		}
+
+		millionaires = append(millionaires, m)
+	}
+
+	return millionaires, nil
+}
+
+// Writer streams millionaires in batches; call Close to finish the output.
+type Writer struct {
+	format string
+	w      io.Writer
+	csv    *csv.Writer
+	count  int
+}
+
+func NewWriter(w io.Writer, format string) (*Writer, error) {
+	ew := &Writer{format: format, w: w}
+
+	switch format {
+	case FormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return nil, err
+		}
+	case FormatCSV:
+		ew.csv = csv.NewWriter(w)
+		if err := ew.csv.Write(columns); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q, use %s or %s", format, FormatCSV, FormatJSON)
+	}
+
+	return ew, nil
+}
+
+func (ew *Writer) Write(millionaires []models.Millionaire) error {
+	for _, m := range millionaires {
+		var err error
+		if ew.csv != nil {
+			var record []string
+			if record, err = csvRecord(m); err == nil {
+				err = ew.csv.Write(record)
+			}
+		} else {
+			err = ew.writeJSON(m)
+		}
+		if err != nil {
+			return err
+		}
+		ew.count++
+	}
+	return nil
+}
+
+// Count is the number of millionaires written so far.
+func (ew *Writer) Count() int {
+	return ew.count
+}
+
+func (ew *Writer) writeJSON(m models.Millionaire) error {
+	// IDs, timestamps and photos belong to the source database.
+	m.ID = 0
+	m.PathToPhoto = nil
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n  "
+	if ew.count == 0 {
+		sep = "\n  "
+	}
+	_, err = io.WriteString(ew.w, sep+string(b))
+	return err
+}
+
+func (ew *Writer) Close() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		return ew.csv.Error()
+	}
+
+	end := "\n]\n"
+	if ew.count == 0 {
+		end = "]\n"
+	}
+	_, err := io.WriteString(ew.w, end)
+	return err
+}
+
+func csvRecord(m models.Millionaire) ([]string, error) {
+	str := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	netWorth := ""
+	if m.NetWorth != nil {
+		netWorth = m.NetWorth.String()
+	}
+
+	childrenCount := ""
+	if m.ChildrenCount != nil {
+		childrenCount = strconv.Itoa(*m.ChildrenCount)
+	}
+
+	socialHandles := ""
+	if len(m.SocialHandles) > 0 {
+		b, err := json.Marshal(m.SocialHandles)
+		if err != nil {
+			return nil, err
+		}
+		socialHandles = string(b)
+	}
+
+	return []string{
+		m.LastName, m.FirstName, str(m.MiddleName), str(m.BirthDate), str(m.BirthPlace),
+		str(m.Company), netWorth, m.NetWorthCurrency, str(m.NetWorthAsOf), str(m.Industry), str(m.Country), str(m.CountryCode), str(m.IndustryCode),
+		str(m.Biography), str(m.Education), strings.Join(m.Citizenships, ";"), str(m.MaritalStatus), childrenCount, str(m.ResidenceCity), str(m.Website), socialHandles,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package exchange
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// profile is a millionaire with every exported field set.
+func profile() models.Millionaire {
+	netWorth := money.MustParse("3000000.50")
+	return models.Millionaire{
+		LastName:         "Hopper",
+		FirstName:        "Grace",
+		MiddleName:       ptr("Brewster"),
+		BirthDate:        ptr("1906-12-09"),
+		BirthPlace:       ptr("New York"),
+		Company:          ptr("Remington Rand"),
+		NetWorth:         &netWorth,
+		NetWorthCurrency: "USD",
+		NetWorthAsOf:     ptr("2024-01-02"),
+		Industry:         ptr("Software"),
+		Country:          ptr("United States"),
+		CountryCode:      ptr("US"),
+		IndustryCode:     ptr("software"),
+		Biography:        ptr("Rear admiral, \"Amazing Grace\".\n\n*Compiler* pioneer; COBOL."),
+		Education:        ptr("Yale University"),
+		Citizenships:     []string{"United States", "Canada"},
+		MaritalStatus:    ptr("divorced"),
+		ChildrenCount:    ptr(0),
+		ResidenceCity:    ptr("Arlington"),
+		Website:          ptr("https://example.com/hopper"),
+		SocialHandles:    map[string]string{"x": "@grace", "github": "ghopper"},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		var out bytes.Buffer
+		w, err := NewWriter(&out, format)
+		if err != nil {
+			t.Fatalf("%s: new writer: %v", format, err)
+		}
+		m := profile()
+		if err := w.Write([]models.Millionaire{m, {LastName: "Bare", FirstName: "Minimum"}}); err != nil {
+			t.Fatalf("%s: write: %v", format, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: close: %v", format, err)
+		}
+
+		got, err := Read(&out, format)
+		if err != nil {
+			t.Fatalf("%s: read back: %v", format, err)
+		}
+		want := []models.Millionaire{m, {LastName: "Bare", FirstName: "Minimum"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: read back\n%+v\nwant\n%+v", format, got, want)
+		}
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for _, c := range []struct {
+		row, want string
+	}{
+		{"Hopper,Grace,many,", `line 2: invalid children_count "many"`},
+		{"Hopper,Grace,,x=@grace", `line 2: invalid social_handles "x=@grace"`},
+	} {
+		in := "last_name,first_name,children_count,social_handles\n" + c.row + "\n"
+		if _, err := Read(strings.NewReader(in), FormatCSV); err == nil || err.Error() != c.want {
+			t.Errorf("read %q: %v, want %s", c.row, err, c.want)
+		}
+	}
+}
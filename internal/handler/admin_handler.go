@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"wealthlist/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	log *slog.Logger
+}
+
+func NewAdminHandler(log *slog.Logger) *AdminHandler {
+	return &AdminHandler{log: log}
+}
+
+type LogLevelDto struct {
+	Level string `json:"level" example:"DEBUG"`
+}
+
+// GetLogLevel returns the current minimum log level.
+// @Summary Get log level
+// @Description Returns the minimum level currently written to the log sinks.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Success 200 {object} LogLevelDto "Current log level"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Router /admin/log-level [get]
+func (h *AdminHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, LogLevelDto{Level: logger.Level().String()})
+}
+
+// SetLogLevel changes the minimum log level without a restart.
+// @Summary Set log level
+// @Description Changes the minimum level for all log sinks at runtime (debug, info, warn, error).
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Param level body LogLevelDto true "New log level"
+// @Success 200 {object} LogLevelDto "Log level changed"
+// @Failure 400 {object} map[string]string "Invalid log level"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Router /admin/log-level [put]
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	var req LogLevelDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	level, err := logger.ParseLevel(req.Level)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	previous := logger.Level()
+	logger.SetLevel(level)
+	h.log.WarnContext(c.Request.Context(), "Log level changed",
+		slog.String("from", previous.String()),
+		slog.String("to", level.String()),
+	)
+
+	c.JSON(http.StatusOK, LogLevelDto{Level: level.String()})
+}
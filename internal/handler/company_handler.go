@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyHandler struct {
+	service *service.CompanyService
+	log     *slog.Logger
+}
+
+func NewCompanyHandler(service *service.CompanyService, log *slog.Logger) *CompanyHandler {
+	return &CompanyHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// intParam parses the named path parameter and answers 400 if it is not a
+// number.
+func (h *CompanyHandler) intParam(c *gin.Context, name string) (int, bool) {
+	v, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect ID", slog.String("param", name), logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect ID"})
+		return 0, false
+	}
+	return v, true
+}
+
+// fail answers with the status matching err.
+func (h *CompanyHandler) fail(c *gin.Context, err error, what string) {
+	switch {
+	case errors.Is(err, service.ErrCompanyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Company not found"})
+	case errors.Is(err, service.ErrMillionaireNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Millionaire not found"})
+	case errors.Is(err, service.ErrHoldingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Holding not found"})
+	case errors.Is(err, service.ErrCompanyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "A company with this legal ID already exists"})
+	case errors.Is(err, service.ErrCompanyHasHoldings):
+		c.JSON(http.StatusConflict, gin.H{"error": "Company still has holdings"})
+	case errors.Is(err, service.ErrInvalidAmount):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorContext(c.Request.Context(), what, logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": what})
+	}
+}
+
+// List retrieves a paginated list of companies.
+// @Summary Get companies
+// @Description Fetches companies ordered by name, optionally only those whose name contains the given text.
+// @Tags companies
+// @Produce json
+// @Param name query string false "Part of the company name"
+// @Param pageNum query int false "Page number (default: 1)"
+// @Param pageSize query int false "Page size (default: 10)"
+// @Success 200 {object} models.PaginationCompanyDto "List of companies"
+// @Failure 500 {object} map[string]string "Error listing companies"
+// @Router /api/companies [get]
+func (h *CompanyHandler) List(c *gin.Context) {
+	pageNum, _ := strconv.Atoi(c.DefaultQuery("pageNum", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+
+	result, err := h.service.ListCompanies(c.Request.Context(), c.Query("name"), pageNum, pageSize)
+	if err != nil {
+		h.fail(c, err, "Error listing companies")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetByID retrieves a company.
+// @Summary Get company by ID
+// @Tags companies
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} models.Company "Company"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 404 {object} map[string]string "Company not found"
+// @Router /api/companies/{id} [get]
+func (h *CompanyHandler) GetByID(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	company, err := h.service.GetCompany(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, err, "Error fetching company")
+		return
+	}
+	c.JSON(http.StatusOK, company)
+}
+
+// Create adds a company.
+// @Summary Create a company
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param company body models.Company true "Company data"
+// @Success 201 {object} models.Company "Company created"
+// @Failure 400 {object} map[string]string "Incorrect JSON format"
+// @Failure 409 {object} map[string]string "Legal ID already used"
+// @Failure 500 {object} map[string]string "Error creating company"
+// @Router /api/companies [post]
+func (h *CompanyHandler) Create(c *gin.Context) {
+	var company models.Company
+	if err := c.ShouldBindJSON(&company); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	if err := h.service.CreateCompany(c.Request.Context(), &company); err != nil {
+		h.fail(c, err, "Error creating company")
+		return
+	}
+	c.JSON(http.StatusCreated, company)
+}
+
+// Update modifies a company.
+// @Summary Update a company
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param company body models.Company true "Updated company data"
+// @Success 200 {object} map[string]string "Company updated"
+// @Failure 400 {object} map[string]string "Incorrect ID or JSON format"
+// @Failure 404 {object} map[string]string "Company not found"
+// @Failure 409 {object} map[string]string "Legal ID already used"
+// @Failure 500 {object} map[string]string "Error updating company"
+// @Router /api/companies/{id} [put]
+func (h *CompanyHandler) Update(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var company models.Company
+	if err := c.ShouldBindJSON(&company); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	company.ID = id
+	if err := h.service.UpdateCompany(c.Request.Context(), &company); err != nil {
+		h.fail(c, err, "Error updating company")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Company updated"})
+}
+
+// Delete removes a company that nobody holds a part in.
+// @Summary Delete a company
+// @Tags companies
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} map[string]string "Company deleted"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 404 {object} map[string]string "Company not found"
+// @Failure 409 {object} map[string]string "Company still has holdings"
+// @Failure 500 {object} map[string]string "Error deleting company"
+// @Router /api/companies/{id} [delete]
+func (h *CompanyHandler) Delete(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCompany(c.Request.Context(), id); err != nil {
+		h.fail(c, err, "Error deleting company")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Company deleted"})
+}
+
+// People lists the holders of a company.
+// @Summary Get the people behind a company
+// @Description Lists everyone holding or having held a part in the company, current holdings first.
+// @Tags companies
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {array} models.CompanyPerson "Holdings with their holders"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 404 {object} map[string]string "Company not found"
+// @Failure 500 {object} map[string]string "Error listing people"
+// @Router /api/companies/{id}/people [get]
+func (h *CompanyHandler) People(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	people, err := h.service.CompanyPeople(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, err, "Error listing people")
+		return
+	}
+	if people == nil {
+		people = []models.CompanyPerson{}
+	}
+	c.JSON(http.StatusOK, people)
+}
+
+// ListHoldings lists a millionaire's holdings.
+// @Summary Get a millionaire's holdings
+// @Tags companies
+// @Produce json
+// @Param id path int true "Millionaire ID"
+// @Success 200 {array} models.Holding "Holdings, most valuable first"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 404 {object} map[string]string "Millionaire not found"
+// @Failure 500 {object} map[string]string "Error listing holdings"
+// @Router /api/millionaires/{id}/holdings [get]
+func (h *CompanyHandler) ListHoldings(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	holdings, err := h.service.ListHoldings(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, err, "Error listing holdings")
+		return
+	}
+	if holdings == nil {
+		holdings = []models.Holding{}
+	}
+	c.JSON(http.StatusOK, holdings)
+}
+
+// AddHolding records a millionaire's part in a company.
+// @Summary Add a holding
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Millionaire ID"
+// @Param holding body models.Holding true "Holding data"
+// @Success 201 {object} models.Holding "Holding added"
+// @Failure 400 {object} map[string]string "Incorrect ID or JSON format, or invalid value"
+// @Failure 404 {object} map[string]string "Millionaire or company not found"
+// @Failure 500 {object} map[string]string "Error adding holding"
+// @Router /api/millionaires/{id}/holdings [post]
+func (h *CompanyHandler) AddHolding(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var holding models.Holding
+	if err := c.ShouldBindJSON(&holding); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	holding.MillionaireID = id
+	holding.CompanyName = ""
+	if err := h.service.AddHolding(c.Request.Context(), &holding); err != nil {
+		h.fail(c, err, "Error adding holding")
+		return
+	}
+	c.JSON(http.StatusCreated, holding)
+}
+
+// UpdateHolding changes a millionaire's holding.
+// @Summary Update a holding
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Millionaire ID"
+// @Param holdingId path int true "Holding ID"
+// @Param holding body models.Holding true "Updated holding data"
+// @Success 200 {object} map[string]string "Holding updated"
+// @Failure 400 {object} map[string]string "Incorrect ID or JSON format, or invalid value"
+// @Failure 404 {object} map[string]string "Millionaire, company or holding not found"
+// @Failure 500 {object} map[string]string "Error updating holding"
+// @Router /api/millionaires/{id}/holdings/{holdingId} [put]
+func (h *CompanyHandler) UpdateHolding(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+	holdingID, ok := h.intParam(c, "holdingId")
+	if !ok {
+		return
+	}
+
+	var holding models.Holding
+	if err := c.ShouldBindJSON(&holding); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	holding.ID, holding.MillionaireID = holdingID, id
+	if err := h.service.UpdateHolding(c.Request.Context(), &holding); err != nil {
+		h.fail(c, err, "Error updating holding")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Holding updated"})
+}
+
+// DeleteHolding removes a millionaire's holding.
+// @Summary Delete a holding
+// @Tags companies
+// @Produce json
+// @Param id path int true "Millionaire ID"
+// @Param holdingId path int true "Holding ID"
+// @Success 200 {object} map[string]string "Holding deleted"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 404 {object} map[string]string "Holding not found"
+// @Failure 500 {object} map[string]string "Error deleting holding"
+// @Router /api/millionaires/{id}/holdings/{holdingId} [delete]
+func (h *CompanyHandler) DeleteHolding(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+	holdingID, ok := h.intParam(c, "holdingId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteHolding(c.Request.Context(), id, holdingID); err != nil {
+		h.fail(c, err, "Error deleting holding")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Holding deleted"})
+}
+
+// Breakdown splits a millionaire's net worth by current holding.
+// @Summary Get a net worth breakdown
+// @Description Attributes the millionaire's net worth to current holdings by their value; "other" is the remainder.
+// @Tags companies
+// @Produce json
+// @Param id path int true "Millionaire ID"
+// @Success 200 {object} models.NetWorthBreakdown "Net worth by holding"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 404 {object} map[string]string "Millionaire not found"
+// @Failure 500 {object} map[string]string "Error computing breakdown"
+// @Router /api/millionaires/{id}/net-worth-breakdown [get]
+func (h *CompanyHandler) Breakdown(c *gin.Context) {
+	id, ok := h.intParam(c, "id")
+	if !ok {
+		return
+	}
+
+	breakdown, err := h.service.NetWorthBreakdown(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, err, "Error computing breakdown")
+		return
+	}
+	if breakdown.Holdings == nil {
+		breakdown.Holdings = []models.Holding{}
+	}
+	c.JSON(http.StatusOK, breakdown)
+}
@@ -37,11 +37,11 @@ func NewFeedbackHandler(service *service.FeedbackService, log *slog.Logger) *Fee
 // @Failure 500 {object} map[string]string "Error while sending feedback"
 // @Router /feedback [post]
 func (h *FeedbackHandler) SendFeedback(c *gin.Context) {
-	h.log.Info("Received feedback submission request")
+	h.log.InfoContext(c.Request.Context(), "Received feedback submission request")
 
 	var feedback models.FeedbackDto
 	if err := c.ShouldBindJSON(&feedback); err != nil {
-		h.log.Error("Failed to parse JSON", logger.Err(err))
+		h.log.ErrorContext(c.Request.Context(), "Failed to parse JSON", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Invalid data format",
 		})
@@ -49,7 +49,7 @@ func (h *FeedbackHandler) SendFeedback(c *gin.Context) {
 	}
 
 	if err := h.validate.Struct(feedback); err != nil {
-		h.log.Error("Validation error", logger.Err(err))
+		h.log.ErrorContext(c.Request.Context(), "Validation error", logger.Err(err))
 
 		var validationErrors []string
 		for _, err := range err.(validator.ValidationErrors) {
@@ -63,16 +63,16 @@ func (h *FeedbackHandler) SendFeedback(c *gin.Context) {
 		return
 	}
 
-	err := h.service.SendFeedbackEmail(feedback)
+	err := h.service.SendFeedbackEmail(c.Request.Context(), feedback)
 	if err != nil {
-		h.log.Error("Failed to send feedback via email", logger.Err(err))
+		h.log.ErrorContext(c.Request.Context(), "Failed to send feedback via email", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Error while sending feedback",
 		})
 		return
 	}
 
-	h.log.Info("Feedback successfully sent via email",
+	h.log.InfoContext(c.Request.Context(), "Feedback successfully sent via email",
 		slog.String("name", feedback.Name),
 		slog.String("email", feedback.Email))
 
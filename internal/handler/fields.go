@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"wealthlist/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// millionaireFields are the JSON names a fields parameter may select.
+var millionaireFields = jsonFieldNames(reflect.TypeOf(models.Millionaire{}))
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// parseFields reads the comma-separated fields query parameter. It returns
+// nil when the parameter is absent, meaning every field. The id is always
+// included.
+func parseFields(c *gin.Context) ([]string, error) {
+	raw, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, nil
+	}
+
+	fields := []string{"id"}
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" || f == "id" {
+			continue
+		}
+		if !millionaireFields[f] {
+			return nil, fmt.Errorf("unknown field %q", f)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// wants reports whether fields, as returned by parseFields, selects field.
+func wants(fields []string, field string) bool {
+	return fields == nil || slices.Contains(fields, field)
+}
+
+// selectFields returns m with only the given fields, or m itself when fields
+// is nil. Fields that m omits stay omitted.
+func selectFields(m models.Millionaire, fields []string) (interface{}, error) {
+	if fields == nil {
+		return m, nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if v, ok := all[f]; ok {
+			selected[f] = v
+		}
+	}
+	return selected, nil
+}
+
+// selectPageFields applies selectFields to every millionaire on the page.
+func selectPageFields(page models.PaginationMillionaireDto, fields []string) (interface{}, error) {
+	if fields == nil {
+		return page, nil
+	}
+
+	millionaires := make([]interface{}, 0, len(page.Millionaires))
+	for _, m := range page.Millionaires {
+		v, err := selectFields(m, fields)
+		if err != nil {
+			return nil, err
+		}
+		millionaires = append(millionaires, v)
+	}
+	return gin.H{
+		"millionaires": millionaires,
+		"total":        page.Total,
+		"page":         page.Page,
+		"pageSize":     page.PageSize,
+	}, nil
+}
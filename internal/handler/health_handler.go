@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	service *service.HealthService
+	log     *slog.Logger
+}
+
+func NewHealthHandler(service *service.HealthService, log *slog.Logger) *HealthHandler {
+	return &HealthHandler{service: service, log: log}
+}
+
+// Liveness reports that the process is up.
+// @Summary Liveness probe
+// @Description Returns 200 as long as the process is able to serve HTTP.
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string "Process is alive"
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": service.StatusOK})
+}
+
+// Readiness reports whether the service can take traffic.
+// @Summary Readiness probe
+// @Description Checks the database, schema version, photo storage and optionally SMTP.
+// @Description Fails while the server is shutting down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.ReadinessDto "All checks passed"
+// @Failure 503 {object} models.ReadinessDto "At least one check failed"
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	result := h.service.Readiness(c.Request.Context())
+	if result.Status != service.StatusOK {
+		c.JSON(http.StatusServiceUnavailable, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Version returns build and schema information.
+// @Summary Build and schema version
+// @Description Returns the version, git commit, build time and database schema version.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.VersionDto "Version information"
+// @Router /version [get]
+func (h *HealthHandler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Version(c.Request.Context()))
+}
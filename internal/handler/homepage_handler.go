@@ -1,47 +1,235 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
 	"wealthlist/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type HomeHandler struct {
-	service *service.HomeService
-	log     *slog.Logger
+	service  service.HomeServiceInterface
+	currency *service.CurrencyService
+	log      *slog.Logger
 }
 
-func NewHomeHandler(service *service.HomeService, log *slog.Logger) *HomeHandler {
-	return &HomeHandler{service: service, log: log}
+func NewHomeHandler(service service.HomeServiceInterface, currency *service.CurrencyService, log *slog.Logger) *HomeHandler {
+	return &HomeHandler{service: service, currency: currency, log: log}
 }
 
 // GetHomePage retrieves homepage data.
 // @Summary Get homepage data
-// @Description Fetches homepage-related data and returns it as JSON.
+// @Description Returns the homepage sections that are not hidden, in order, each with its millionaires. Gainers and losers also carry the net worth change in USD.
 // @Tags home
 // @Produce json
-// @Success 200 {object} interface{} "Homepage data successfully retrieved"
+// @Param currency query string false "ISO 4217 currency to convert net worth to; rankings and changes themselves are in USD"
+// @Success 200 {object} models.HomePageDto "Homepage data successfully retrieved"
+// @Failure 400 {object} map[string]string "Unknown currency"
 // @Failure 500 {object} map[string]string "Failed to get homepage data"
+// @Header 200 {string} X-Cache "HIT, MISS or BYPASS (with X-Consistency: strong)"
 // @Router /home [get]
 func (h *HomeHandler) GetHomePage(c *gin.Context) {
-	h.log.Info("Received request for homepage data")
+	h.log.InfoContext(c.Request.Context(), "Received request for homepage data")
 
-	baseURL := fmt.Sprintf("%s://%s", c.Request.URL.Scheme, c.Request.Host)
-
-	h.log.Info("Constructed base URL", slog.String("baseURL", baseURL))
-
-	data, err := h.service.GetHomePageData(baseURL)
+	data, err := h.service.GetHomePageData(c.Request.Context())
 	if err != nil {
-		h.log.Error("Failed to get homepage data", logger.Err(err))
+		h.log.ErrorContext(c.Request.Context(), "Failed to get homepage data", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get homepage data"})
 		return
 	}
 
-	h.log.Info("Successfully retrieved homepage data")
+	for _, section := range data.Sections {
+		millionaires := make([]models.Millionaire, len(section.Entries))
+		for i, e := range section.Entries {
+			millionaires[i] = e.Millionaire
+		}
+		if err := h.currency.Convert(c.Request.Context(), c.Query("currency"), millionaires); err != nil {
+			if errors.Is(err, service.ErrUnknownCurrency) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			h.log.ErrorContext(c.Request.Context(), "Error converting net worth", logger.Err(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error converting net worth"})
+			return
+		}
+		photoURLs(c.Request.Context(), millionaires)
+		for i := range section.Entries {
+			section.Entries[i].Millionaire = millionaires[i]
+		}
+	}
+
+	h.log.InfoContext(c.Request.Context(), "Successfully retrieved homepage data")
 
 	c.JSON(http.StatusOK, data)
 }
+
+// sectionID parses the id path parameter and answers 400 if it is not a
+// number.
+func (h *HomeHandler) sectionID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect ID", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// fail answers with the status matching err.
+func (h *HomeHandler) fail(c *gin.Context, err error, what string) {
+	switch {
+	case errors.Is(err, service.ErrSectionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case errors.Is(err, service.ErrInvalidSection),
+		errors.Is(err, service.ErrUnknownCountry),
+		errors.Is(err, service.ErrUnknownIndustry):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorContext(c.Request.Context(), what, logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": what})
+	}
+}
+
+// ListSections returns every homepage section definition.
+// @Summary Get homepage sections
+// @Description Lists the section definitions of the homepage by position, hidden ones included.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Success 200 {array} models.HomeSection "Sections"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Failure 500 {object} map[string]string "Error listing sections"
+// @Router /admin/home/sections [get]
+func (h *HomeHandler) ListSections(c *gin.Context) {
+	sections, err := h.service.Sections(c.Request.Context())
+	if err != nil {
+		h.fail(c, err, "Error listing sections")
+		return
+	}
+	c.JSON(http.StatusOK, sections)
+}
+
+// GetSection returns one homepage section definition.
+// @Summary Get a homepage section
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Param id path int true "Section ID"
+// @Success 200 {object} models.HomeSection "Section"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Failure 404 {object} map[string]string "Section not found"
+// @Failure 500 {object} map[string]string "Error fetching section"
+// @Router /admin/home/sections/{id} [get]
+func (h *HomeHandler) GetSection(c *gin.Context) {
+	id, ok := h.sectionID(c)
+	if !ok {
+		return
+	}
+
+	section, err := h.service.Section(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, err, "Error fetching section")
+		return
+	}
+	c.JSON(http.StatusOK, section)
+}
+
+// CreateSection adds a homepage section.
+// @Summary Create a homepage section
+// @Description Adds a section: top (richest), newcomers (added last), gainers or losers (net worth change over windowDays), each of up to size millionaires and optionally only those in countries or industries (codes or names); or featured, listing millionaireIds in their order. Without a position the section goes last.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Param section body models.HomeSection true "Section"
+// @Success 201 {object} models.HomeSection "Section created"
+// @Failure 400 {object} map[string]string "Invalid section"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Failure 500 {object} map[string]string "Error creating section"
+// @Router /admin/home/sections [post]
+func (h *HomeHandler) CreateSection(c *gin.Context) {
+	var section models.HomeSection
+	if err := c.ShouldBindJSON(&section); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	if err := h.service.CreateSection(c.Request.Context(), &section); err != nil {
+		h.fail(c, err, "Error creating section")
+		return
+	}
+	c.JSON(http.StatusCreated, section)
+}
+
+// UpdateSection replaces a homepage section definition.
+// @Summary Update a homepage section
+// @Description Replaces the section's definition, as described at creation. Reorder featured profiles by sending millionaireIds in the new order, and sections by changing their position.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Param id path int true "Section ID"
+// @Param section body models.HomeSection true "Section"
+// @Success 200 {object} models.HomeSection "Section updated"
+// @Failure 400 {object} map[string]string "Incorrect ID format or invalid section"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Failure 404 {object} map[string]string "Section not found"
+// @Failure 500 {object} map[string]string "Error updating section"
+// @Router /admin/home/sections/{id} [put]
+func (h *HomeHandler) UpdateSection(c *gin.Context) {
+	id, ok := h.sectionID(c)
+	if !ok {
+		return
+	}
+
+	var section models.HomeSection
+	if err := c.ShouldBindJSON(&section); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	section.ID = id
+	if err := h.service.UpdateSection(c.Request.Context(), &section); err != nil {
+		h.fail(c, err, "Error updating section")
+		return
+	}
+	c.JSON(http.StatusOK, section)
+}
+
+// DeleteSection removes a homepage section.
+// @Summary Delete a homepage section
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Param id path int true "Section ID"
+// @Success 200 {object} map[string]string "Section deleted"
+// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Failure 404 {object} map[string]string "Section not found"
+// @Failure 500 {object} map[string]string "Error deleting section"
+// @Router /admin/home/sections/{id} [delete]
+func (h *HomeHandler) DeleteSection(c *gin.Context) {
+	id, ok := h.sectionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSection(c.Request.Context(), id); err != nil {
+		h.fail(c, err, "Error deleting section")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Section deleted"})
+}
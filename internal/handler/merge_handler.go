@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MergeHandler struct {
+	service *service.MergeService
+	log     *slog.Logger
+}
+
+func NewMergeHandler(service *service.MergeService, log *slog.Logger) *MergeHandler {
+	return &MergeHandler{service: service, log: log}
+}
+
+// fail answers with the status matching err.
+func (h *MergeHandler) fail(c *gin.Context, err error, what string) {
+	switch {
+	case errors.Is(err, service.ErrMillionaireNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Millionaire not found"})
+	case errors.Is(err, service.ErrSelfMerge), errors.Is(err, service.ErrUnmergeableField), errors.Is(err, service.ErrInvalidMinScore):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorContext(c.Request.Context(), what, logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": what})
+	}
+}
+
+// Duplicates lists pairs of millionaires that may be the same person.
+// @Summary Find duplicate millionaires
+// @Description Scores pairs of millionaires on the similarity of their names, normalized and transliterated to Latin letters (either name order), and on whether their birth dates and companies agree. Only names starting alike are compared. Pairs are ordered by score, highest first; merge them with POST /api/millionaires/merge.
+// @Tags millionaires
+// @Produce json
+// @Param minScore query number false "Lowest score to report, above 0 and at most 1 (default: 0.85)"
+// @Param limit query int false "Maximum number of pairs, at most 500 (default: 50)"
+// @Success 200 {array} models.DuplicatePair "Possible duplicates"
+// @Failure 400 {object} map[string]string "Invalid minScore or limit"
+// @Failure 500 {object} map[string]string "Error finding duplicates"
+// @Router /api/duplicates [get]
+func (h *MergeHandler) Duplicates(c *gin.Context) {
+	minScore, err := strconv.ParseFloat(c.DefaultQuery("minScore", strconv.FormatFloat(service.DefaultMinDuplicateScore, 'f', -1, 64)), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidMinScore.Error()})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+		return
+	}
+
+	pairs, err := h.service.Duplicates(c.Request.Context(), minScore, limit)
+	if err != nil {
+		h.fail(c, err, "Error finding duplicates")
+		return
+	}
+	for _, p := range pairs {
+		photoURLs(c.Request.Context(), p.Millionaires)
+	}
+	c.JSON(http.StatusOK, pairs)
+}
+
+// Merge folds one millionaire into another.
+// @Summary Merge two millionaires
+// @Description Merges the source millionaire into the target, which keeps its ID. fields picks "target" or "source" per field by JSON name; other fields keep the target's value unless it is empty. netWorth brings netWorthCurrency and netWorthAsOf along, country and industry their codes. The source's net worth history (winning on shared days when its netWorth is picked), holdings and featured homepage spots move to the target. The source is deleted, GET on its ID answers 301 with the target, and the merge is written to the audit log.
+// @Tags millionaires
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Security AdminBasic
+// @Param merge body models.MergeRequest true "Millionaires to merge and field winners"
+// @Success 200 {object} models.MergeResult "Merged millionaire"
+// @Failure 400 {object} map[string]string "Invalid JSON, same millionaire or unknown field"
+// @Failure 401 {object} map[string]string "Missing or invalid admin credentials"
+// @Failure 404 {object} map[string]string "Millionaire not found"
+// @Failure 500 {object} map[string]string "Error merging millionaires"
+// @Router /api/millionaires/merge [post]
+func (h *MergeHandler) Merge(c *gin.Context) {
+	var req models.MergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
+		return
+	}
+
+	result, err := h.service.Merge(c.Request.Context(), req)
+	if err != nil {
+		h.fail(c, err, "Error merging millionaires")
+		return
+	}
+	merged := []models.Millionaire{result.Millionaire}
+	photoURLs(c.Request.Context(), merged)
+	result.Millionaire = merged[0]
+	c.JSON(http.StatusOK, result)
+}
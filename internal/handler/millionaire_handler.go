@@ -1,78 +1,154 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"wealthlist/internal/logger"
 	"wealthlist/internal/models"
+	"wealthlist/internal/publicurl"
 	"wealthlist/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type MillionaireHandler struct {
-	service service.MillionaireServiceInterface
-	log     *slog.Logger
+	service  service.MillionaireServiceInterface
+	currency *service.CurrencyService
+	merges   *service.MergeService
+	log      *slog.Logger
 }
 
-func NewMillionaireHandler(service service.MillionaireServiceInterface, log *slog.Logger) *MillionaireHandler {
+func NewMillionaireHandler(service service.MillionaireServiceInterface, currency *service.CurrencyService, merges *service.MergeService, log *slog.Logger) *MillionaireHandler {
 	return &MillionaireHandler{
-		service: service,
-		log:     log,
+		service:  service,
+		currency: currency,
+		merges:   merges,
+		log:      log,
 	}
 }
 
 // GetAll retrieves a paginated list of millionaires.
 // @Summary Get all millionaires
-// @Description Fetches a paginated list of millionaires from the database.
+// @Description Fetches a paginated list of millionaires from the database, without their biography and education.
 // @Tags millionaires
 // @Produce json
 // @Param pageNum query int false "Page number (default: 1)"
 // @Param pageSize query int false "Page size (default: 10)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,lastName,firstName,netWorth (default: all)"
+// @Param currency query string false "ISO 4217 currency to convert net worth to at the rate of its valuation day, e.g. USD, KZT or EUR"
 // @Success 200 {object} models.PaginationMillionaireDto "List of millionaires retrieved successfully"
+// @Failure 400 {object} map[string]string "Unknown field or currency"
 // @Failure 500 {object} map[string]string "Error retrieving data"
+// @Header 200 {string} X-Cache "HIT, MISS or BYPASS (with X-Consistency: strong)"
 // @Router /api/millionaires [get]
 func (mh *MillionaireHandler) GetAll(c *gin.Context) {
+	fields, err := parseFields(c)
+	if err != nil {
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect fields", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	pageNum, _ := strconv.Atoi(c.DefaultQuery("pageNum", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
 
-	result, err := mh.service.GetAllMillionaires(pageNum, pageSize)
+	result, err := mh.service.GetAllMillionaires(c.Request.Context(), pageNum, pageSize)
 	if err != nil {
-		mh.log.Error("Error recieving data", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Error recieving data", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recieving data"})
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	if err := mh.currency.Convert(c.Request.Context(), c.Query("currency"), result.Millionaires); err != nil {
+		if errors.Is(err, service.ErrUnknownCurrency) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		mh.log.ErrorContext(c.Request.Context(), "Error converting net worth", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error converting net worth"})
+		return
+	}
+	photoURLs(c.Request.Context(), result.Millionaires)
+
+	body, err := selectPageFields(result, fields)
+	if err != nil {
+		mh.log.ErrorContext(c.Request.Context(), "Error selecting fields", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error selecting fields"})
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
 }
 
 // GetByID retrieves a millionaire by ID.
 // @Summary Get millionaire by ID
-// @Description Fetches a millionaire's details using their unique ID.
+// @Description Fetches a millionaire's details using their unique ID. The ID of a millionaire merged into another answers 301 with the other's URL.
 // @Tags millionaires
 // @Produce json
 // @Param id path int true "Millionaire ID"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,lastName,firstName,netWorth (default: all)"
+// @Param currency query string false "ISO 4217 currency to convert net worth to at the rate of its valuation day, e.g. USD, KZT or EUR"
 // @Success 200 {object} models.Millionaire "Millionaire retrieved successfully"
-// @Failure 400 {object} map[string]string "Incorrect ID format"
+// @Success 301 "Millionaire merged into the one at Location"
+// @Failure 400 {object} map[string]string "Incorrect ID format, unknown field or unknown currency"
 // @Failure 404 {object} map[string]string "Millionaire not found"
+// @Failure 500 {object} map[string]string "Error converting net worth"
+// @Header 200 {string} X-Cache "HIT, MISS or BYPASS (with X-Consistency: strong)"
+// @Header 301 {string} Location "URL of the millionaire merged into"
 // @Router /api/millionaires/{id} [get]
 func (mh *MillionaireHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		mh.log.Error("Incorrect ID", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect ID", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect ID"})
 		return
 	}
 
-	millionaire, err := mh.service.GetMillionaireByID(id)
+	fields, err := parseFields(c)
 	if err != nil {
-		mh.log.Error("Millionaire not found", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect fields", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	millionaire, err := mh.service.GetMillionaireByID(c.Request.Context(), id, wants(fields, "biographyHtml"))
+	if err != nil {
+		if to, rerr := mh.merges.Resolve(c.Request.Context(), id); rerr == nil {
+			location := "/api/millionaires/" + strconv.Itoa(to)
+			if c.Request.URL.RawQuery != "" {
+				location += "?" + c.Request.URL.RawQuery
+			}
+			c.Redirect(http.StatusMovedPermanently, location)
+			return
+		}
+		mh.log.ErrorContext(c.Request.Context(), "Millionaire not found", logger.Err(err))
 		c.JSON(http.StatusNotFound, gin.H{"error": "Millionaire not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, millionaire)
+	converted := []models.Millionaire{*millionaire}
+	if err := mh.currency.Convert(c.Request.Context(), c.Query("currency"), converted); err != nil {
+		if errors.Is(err, service.ErrUnknownCurrency) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		mh.log.ErrorContext(c.Request.Context(), "Error converting net worth", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error converting net worth"})
+		return
+	}
+	photoURLs(c.Request.Context(), converted)
+
+	body, err := selectFields(converted[0], fields)
+	if err != nil {
+		mh.log.ErrorContext(c.Request.Context(), "Error selecting fields", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error selecting fields"})
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
 }
 
 // Create adds a new millionaire.
@@ -83,20 +159,25 @@ func (mh *MillionaireHandler) GetByID(c *gin.Context) {
 // @Produce json
 // @Param millionaire body models.Millionaire true "Millionaire data"
 // @Success 201 {object} map[string]string "Millionaire created"
-// @Failure 400 {object} map[string]string "Incorrect JSON format"
+// @Failure 400 {object} map[string]string "Incorrect JSON format, unknown country or industry code, or too many decimal places"
 // @Failure 500 {object} map[string]string "Error creating millionaire"
 // @Router /api/millionaires [post]
 func (mh *MillionaireHandler) Create(c *gin.Context) {
 	var millionaire models.Millionaire
 	if err := c.ShouldBindJSON(&millionaire); err != nil {
-		mh.log.Error("Incorrect JSON", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
 		return
 	}
+	storedPhotoPath(&millionaire)
 
-	err := mh.service.CreateMillionaire(&millionaire)
+	err := mh.service.CreateMillionaire(c.Request.Context(), &millionaire)
+	if errors.Is(err, service.ErrUnknownCountry) || errors.Is(err, service.ErrUnknownIndustry) || errors.Is(err, service.ErrInvalidAmount) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
-		mh.log.Error("Error creating millionaire", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Error creating millionaire", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating millionaire"})
 		return
 	}
@@ -113,28 +194,33 @@ func (mh *MillionaireHandler) Create(c *gin.Context) {
 // @Param id path int true "Millionaire ID"
 // @Param millionaire body models.Millionaire true "Updated millionaire data"
 // @Success 200 {object} map[string]string "Millionaire updated"
-// @Failure 400 {object} map[string]string "Incorrect ID or JSON format"
+// @Failure 400 {object} map[string]string "Incorrect ID or JSON format, unknown country or industry code, or too many decimal places"
 // @Failure 500 {object} map[string]string "Error updating millionaire"
 // @Router /api/millionaires/{id} [put]
 func (mh *MillionaireHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		mh.log.Error("Incorrect ID", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect ID", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect ID"})
 		return
 	}
 
 	var millionaire models.Millionaire
 	if err := c.ShouldBindJSON(&millionaire); err != nil {
-		mh.log.Error("Incorrect JSON", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect JSON", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect JSON"})
 		return
 	}
+	storedPhotoPath(&millionaire)
 
 	millionaire.ID = id
-	err = mh.service.UpdateMillionaire(&millionaire)
+	err = mh.service.UpdateMillionaire(c.Request.Context(), &millionaire)
+	if errors.Is(err, service.ErrUnknownCountry) || errors.Is(err, service.ErrUnknownIndustry) || errors.Is(err, service.ErrInvalidAmount) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
-		mh.log.Error("Error updating millionaire", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Error updating millionaire", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating millionaire"})
 		return
 	}
@@ -155,14 +241,14 @@ func (mh *MillionaireHandler) Update(c *gin.Context) {
 func (mh *MillionaireHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		mh.log.Error("Incorrect ID", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect ID", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect ID"})
 		return
 	}
 
-	err = mh.service.DeleteMillionaire(id)
+	err = mh.service.DeleteMillionaire(c.Request.Context(), id)
 	if err != nil {
-		mh.log.Error("Error deleting millionaire", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Error deleting millionaire", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting millionaire"})
 		return
 	}
@@ -172,32 +258,93 @@ func (mh *MillionaireHandler) Delete(c *gin.Context) {
 
 // Search finds millionaires based on given query parameters.
 // @Summary Search for millionaires
-// @Description Searches for millionaires using optional filters such as name and country.
+// @Description Searches for millionaires using optional filters such as name and country. Results leave out the biography and education.
 // @Tags millionaires
 // @Produce json
 // @Param lastName query string false "Last name of the millionaire"
 // @Param firstName query string false "First name of the millionaire"
 // @Param middleName query string false "Middle name of the millionaire"
-// @Param country query string false "Country of the millionaire"
+// @Param country query string false "Country code (ISO 3166-1 alpha-2 or alpha-3), country name in any language, or part of the free-text country"
+// @Param industry query string false "Industry code or name, matching the industries below it too, or part of the free-text industry"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Number of records per page" default(10)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,lastName,firstName,netWorth (default: all)"
+// @Param currency query string false "ISO 4217 currency to convert net worth to at the rate of its valuation day, e.g. USD, KZT or EUR"
 // @Success 200 {array} models.Millionaire "List of matching millionaires"
+// @Failure 400 {object} map[string]string "Unknown field or currency"
 // @Failure 500 {object} map[string]string "Error searching millionaire"
+// @Header 200 {string} X-Cache "HIT, MISS or BYPASS (with X-Consistency: strong)"
 // @Router /millionaires/search [get]
 func (mh *MillionaireHandler) Search(c *gin.Context) {
-	lastName := c.Query("lastName")
-	firstName := c.Query("firstName")
-	middleName := c.Query("middleName")
-	country := c.Query("country")
+	fields, err := parseFields(c)
+	if err != nil {
+		mh.log.ErrorContext(c.Request.Context(), "Incorrect fields", logger.Err(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	q := searchQuery(c)
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
 
-	result, err := mh.service.SearchMillionaire(lastName, firstName, middleName, country, page, pageSize)
+	result, err := mh.service.SearchMillionaire(c.Request.Context(), q.LastName, q.FirstName, q.MiddleName, q.Country, q.Industry, page, pageSize)
 	if err != nil {
-		mh.log.Error("Error searching millionaire", logger.Err(err))
+		mh.log.ErrorContext(c.Request.Context(), "Error searching millionaire", logger.Err(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error searching millionaire"})
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	if err := mh.currency.Convert(c.Request.Context(), c.Query("currency"), result.Millionaires); err != nil {
+		if errors.Is(err, service.ErrUnknownCurrency) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		mh.log.ErrorContext(c.Request.Context(), "Error converting net worth", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error converting net worth"})
+		return
+	}
+	photoURLs(c.Request.Context(), result.Millionaires)
+
+	body, err := selectPageFields(result, fields)
+	if err != nil {
+		mh.log.ErrorContext(c.Request.Context(), "Error selecting fields", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error selecting fields"})
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+// photoURLs replaces the stored photo paths of ms with the URLs they are
+// served at and adds the URLs of their thumbnails.
+func photoURLs(ctx context.Context, ms []models.Millionaire) {
+	for i := range ms {
+		if p := ms[i].PathToPhoto; p != nil && *p != "" {
+			url := publicurl.Photo(ctx, *p)
+			ms[i].PathToPhoto, ms[i].ThumbnailURL = &url, publicurl.Thumbnail(ctx, *p)
+		}
+	}
+}
+
+// storedPhotoPath turns a photo URL that a client sent back into the path
+// it was made from.
+func storedPhotoPath(m *models.Millionaire) {
+	if m.PathToPhoto == nil {
+		return
+	}
+	if name, ok := publicurl.PhotoName(*m.PathToPhoto); ok {
+		path := service.PhotoDir + "/" + name
+		m.PathToPhoto = &path
+	}
+}
+
+// searchQuery reads the filter parameters of the search.
+func searchQuery(c *gin.Context) service.SearchQuery {
+	return service.SearchQuery{
+		LastName:   c.Query("lastName"),
+		FirstName:  c.Query("firstName"),
+		MiddleName: c.Query("middleName"),
+		Country:    c.Query("country"),
+		Industry:   c.Query("industry"),
+	}
 }
@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"mime"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"wealthlist/internal/logger"
+	"wealthlist/internal/publicurl"
 	"wealthlist/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -28,7 +33,7 @@ func NewPhotoHandler(photoService *service.PhotoService, log *slog.Logger) *Phot
 func (h *PhotoHandler) getMillionaireID(c *gin.Context) (int, bool) {
 	millionaireID, err := strconv.Atoi(c.Param("millionaireId"))
 	if err != nil {
-		h.log.Error("Incorrect ID", logger.Err(err))
+		h.log.ErrorContext(c.Request.Context(), "Incorrect ID", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect millionaire ID"})
 		return 0, false
 	}
@@ -45,6 +50,7 @@ func (h *PhotoHandler) getMillionaireID(c *gin.Context) (int, bool) {
 // @Param id path int true "Millionaire ID"
 // @Success 200 {object} map[string]string "Photo uploaded successfully"
 // @Failure 400 {object} map[string]string "Error receiving file"
+// @Failure 404 {object} map[string]string "Millionaire not found"
 // @Failure 500 {object} map[string]string "Error uploading or updating photo"
 // @Router /api/photo/add/{millionaireId} [post]
 func (h *PhotoHandler) AddPhotoForMillionaire(c *gin.Context) {
@@ -55,28 +61,27 @@ func (h *PhotoHandler) AddPhotoForMillionaire(c *gin.Context) {
 
 	file, err := c.FormFile("photo")
 	if err != nil {
-		h.log.Error("Error receiving file", logger.Err(err))
+		h.log.ErrorContext(c.Request.Context(), "Error receiving file", logger.Err(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Error receiving file"})
 		return
 	}
 
-	filePath, err := h.photoService.UploadPhoto(millionaireID, file)
-	if err != nil {
-		h.log.Error("Error uploading file", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	filePath, err := h.photoService.UploadPhoto(c.Request.Context(), millionaireID, file)
+	if errors.Is(err, service.ErrMillionaireNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Millionaire not found"})
 		return
 	}
-
-	err = h.photoService.UpdatePhoto(millionaireID, filePath)
 	if err != nil {
-		h.log.Error("Error updating millionaire photo path", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update millionaire photo"})
+		h.log.ErrorContext(c.Request.Context(), "Error uploading photo", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload photo"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":   "Photo uploaded successfully",
-		"photoPath": filePath,
+		"message":      "Photo uploaded successfully",
+		"photoPath":    filePath,
+		"photoUrl":     publicurl.Photo(c.Request.Context(), filePath),
+		"thumbnailUrl": publicurl.Thumbnail(c.Request.Context(), filePath),
 	})
 }
 
@@ -96,28 +101,13 @@ func (h *PhotoHandler) DeleteMillionairePhoto(c *gin.Context) {
 		return
 	}
 
-	photoPath, err := h.photoService.GetPhotoPath(millionaireID)
-	if err != nil {
-		h.log.Error("Error retrieving photo path", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve photo path"})
-		return
-	}
-
-	if photoPath == "" {
+	err := h.photoService.DeletePhoto(c.Request.Context(), millionaireID)
+	if errors.Is(err, service.ErrPhotoNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "No photo found for this millionaire"})
 		return
 	}
-
-	if err := os.Remove(photoPath); err != nil && !os.IsNotExist(err) {
-		h.log.Error("Error deleting photo file", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete photo file"})
-		return
-	}
-
-	err = h.photoService.ClearPhotoPath(millionaireID)
 	if err != nil {
-		h.log.Error("Error clearing photo path", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear photo path"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete photo"})
 		return
 	}
 
@@ -127,11 +117,30 @@ func (h *PhotoHandler) DeleteMillionairePhoto(c *gin.Context) {
 // GetPhoto retrieves a millionaire's photo.
 // @Summary Get a millionaire's photo
 // @Description Serves an image file from the uploads/photos directory based on the provided image name.
+// @Description Responses carry a content-hash ETag and Last-Modified, honour conditional and range requests,
+// @Description and serve an AVIF or WebP variant instead of the original when the Accept header allows it.
+// @Description size=thumb serves the thumbnail, or the photo itself when it has none. With SERVER_PHOTO_URL_SECRET set,
+// @Description only the signed URLs the API returns are served, until they expire.
 // @Tags millionaires
 // @Produce image/jpeg
+// @Produce image/png
+// @Produce image/webp
+// @Produce image/avif
 // @Param imageName path string true "Image filename"
+// @Param size query string false "thumb for the thumbnail" Enums(thumb)
+// @Param expires query int false "Expiry of a signed URL, in Unix seconds"
+// @Param signature query string false "Signature of a signed URL"
+// @Param Accept header string false "Accepted image types, e.g. image/avif,image/webp,*/*"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
 // @Success 200 "Returns the requested image file"
+// @Success 206 "Returns the requested byte range"
+// @Success 304 "Image not modified"
+// @Header 200,206 {string} ETag "Strong validator derived from the file content"
+// @Header 200,206 {string} Cache-Control "Caching policy"
+// @Header 200,206 {string} Vary "Accept"
 // @Failure 400 {object} map[string]string "Image name is required"
+// @Failure 403 {object} map[string]string "URL not signed, wrongly signed or expired"
 // @Failure 404 {object} map[string]string "Image not found"
 // @Router /api/photo/{imageName} [get]
 func (h *PhotoHandler) GetPhoto(c *gin.Context) {
@@ -141,12 +150,69 @@ func (h *PhotoHandler) GetPhoto(c *gin.Context) {
 		return
 	}
 
-	imagePath := filepath.Join("uploads/photos", imageName)
+	expires, err := publicurl.SignerFrom(c.Request.Context()).Verify(imageName, c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	thumbnail := c.Query("size") == "thumb"
+	photo, err := h.photoService.ResolvePhoto(c.Request.Context(), imageName, thumbnail, acceptedPhotoVariants(c.GetHeader("Accept")))
+	if err != nil {
+		if errors.Is(err, service.ErrPhotoNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+			return
+		}
+		h.log.ErrorContext(c.Request.Context(), "Error resolving photo", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read image"})
+		return
+	}
 
-	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
+	f, err := os.Open(photo.Path)
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Error opening photo", logger.Err(err))
 		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
 		return
 	}
+	defer f.Close()
+
+	c.Header("ETag", photo.ETag)
+	c.Header("Vary", "Accept")
+	c.Header("Content-Type", photo.ContentType)
+	switch {
+	case !expires.IsZero():
+		// A signed URL must not outlive its expiry in shared caches.
+		c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(time.Until(expires).Seconds())))
+	case photo.Immutable:
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	default:
+		c.Header("Cache-Control", "public, no-cache")
+	}
+
+	http.ServeContent(c.Writer, c.Request, photo.Name, photo.ModTime, f)
+}
 
-	c.File(imagePath)
+// acceptedPhotoVariants returns the variant extensions the client accepts,
+// in server preference order. Wildcards do not count: a browser sending
+// "*/*" alone has not said it can decode AVIF or WebP.
+func acceptedPhotoVariants(accept string) []string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok && strings.Trim(q, "0.") == "" {
+			continue
+		}
+		accepted[mediaType] = true
+	}
+
+	var exts []string
+	for _, ext := range service.PhotoVariantExts {
+		if accepted["image/"+strings.TrimPrefix(ext, ".")] {
+			exts = append(exts, ext)
+		}
+	}
+	return exts
 }
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/money"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	service *service.StatsService
+	log     *slog.Logger
+}
+
+func NewStatsHandler(service *service.StatsService, log *slog.Logger) *StatsHandler {
+	return &StatsHandler{service: service, log: log}
+}
+
+// Summary returns net worth statistics of the matching millionaires.
+// @Summary Get net worth statistics
+// @Description Count, total, mean, median, percentiles, Gini coefficient and top 1%/10% shares of the net worths of the millionaires matching the search filter, converted to one currency at the rate of each valuation's day. Millionaires whose net worth cannot be converted are left out and counted as unconverted.
+// @Tags stats
+// @Produce json
+// @Param lastName query string false "Part of the last name"
+// @Param firstName query string false "Part of the first name"
+// @Param middleName query string false "Part of the middle name"
+// @Param country query string false "Country code, name, or part of the free-text country, as in the search"
+// @Param industry query string false "Industry code, name, or part of the free-text industry, as in the search"
+// @Param currency query string false "ISO 4217 currency of the statistics (default: USD)"
+// @Success 200 {object} models.StatsSummary "Statistics"
+// @Failure 400 {object} map[string]string "Unknown currency"
+// @Failure 500 {object} map[string]string "Error computing statistics"
+// @Router /api/stats [get]
+func (h *StatsHandler) Summary(c *gin.Context) {
+	stats, err := h.service.Summary(c.Request.Context(), searchQuery(c), c.Query("currency"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// Groups returns net worth statistics per country, industry, age bracket or
+// birth decade.
+// @Summary Get net worth statistics by group
+// @Description The statistics of /api/stats for each country or industry code (the free text when there is none), age bracket (<30, 30-39 ... 70-79, 80+) or birth decade (1950s). Countries and industries are ordered by total, largest first; millionaires without the value are grouped as "unknown", last. With format=csv the groups come as a CSV table.
+// @Tags stats
+// @Produce json,text/csv
+// @Param dimension path string true "country, industry, age or decade"
+// @Param lastName query string false "Part of the last name"
+// @Param firstName query string false "Part of the first name"
+// @Param middleName query string false "Part of the middle name"
+// @Param country query string false "Country code, name, or part of the free-text country, as in the search"
+// @Param industry query string false "Industry code, name, or part of the free-text industry, as in the search"
+// @Param currency query string false "ISO 4217 currency of the statistics (default: USD)"
+// @Param format query string false "json or csv (default: json)"
+// @Success 200 {object} models.StatsGroups "Statistics by group"
+// @Failure 400 {object} map[string]string "Unknown dimension, currency or format"
+// @Failure 500 {object} map[string]string "Error computing statistics"
+// @Router /api/stats/by/{dimension} [get]
+func (h *StatsHandler) Groups(c *gin.Context) {
+	format, ok := h.format(c)
+	if !ok {
+		return
+	}
+
+	groups, err := h.service.Groups(c.Request.Context(), searchQuery(c), c.Query("currency"), c.Param("dimension"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	if format == "json" {
+		c.JSON(http.StatusOK, groups)
+		return
+	}
+
+	percentiles := []string{"p10", "p25", "p75", "p90", "p99"}
+	header := []string{groups.Dimension, "currency", "count", "total", "mean", "median", "min", "max"}
+	header = append(header, percentiles...)
+	header = append(header, "gini", "top1Share", "top10Share")
+	records := [][]string{header}
+	for _, g := range groups.Groups {
+		record := []string{g.Key, groups.Currency, strconv.Itoa(g.Count), g.Total.String(),
+			amountCell(g.Mean), amountCell(g.Median), amountCell(g.Min), amountCell(g.Max)}
+		for _, p := range percentiles {
+			if v, ok := g.Percentiles[p]; ok {
+				record = append(record, v.String())
+			} else {
+				record = append(record, "")
+			}
+		}
+		record = append(record, ratioCell(g.Gini), ratioCell(g.Top1Share), ratioCell(g.Top10Share))
+		records = append(records, record)
+	}
+	h.writeCSV(c, records)
+}
+
+// Histogram counts the matching millionaires by net worth.
+// @Summary Get net worth histogram
+// @Description Counts and totals the net worths of the millionaires matching the search filter between consecutive bucket boundaries. The first bucket has no lower bound and the last no upper one. With format=csv the buckets come as a CSV table.
+// @Tags stats
+// @Produce json,text/csv
+// @Param buckets query string false "Comma-separated, strictly increasing boundaries (default: 1000000,10000000,100000000,1000000000,10000000000,100000000000)"
+// @Param lastName query string false "Part of the last name"
+// @Param firstName query string false "Part of the first name"
+// @Param middleName query string false "Part of the middle name"
+// @Param country query string false "Country code, name, or part of the free-text country, as in the search"
+// @Param industry query string false "Industry code, name, or part of the free-text industry, as in the search"
+// @Param currency query string false "ISO 4217 currency of the statistics (default: USD)"
+// @Param format query string false "json or csv (default: json)"
+// @Success 200 {object} models.Histogram "Histogram"
+// @Failure 400 {object} map[string]string "Invalid buckets, unknown currency or format"
+// @Failure 500 {object} map[string]string "Error computing statistics"
+// @Router /api/stats/histogram [get]
+func (h *StatsHandler) Histogram(c *gin.Context) {
+	format, ok := h.format(c)
+	if !ok {
+		return
+	}
+
+	var bounds []money.Amount
+	if s := c.Query("buckets"); s != "" {
+		for _, part := range strings.Split(s, ",") {
+			b, err := money.Parse(strings.TrimSpace(part))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%v: %v", service.ErrInvalidBuckets, err)})
+				return
+			}
+			bounds = append(bounds, b)
+		}
+	}
+
+	histogram, err := h.service.Histogram(c.Request.Context(), searchQuery(c), c.Query("currency"), bounds)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	if format == "json" {
+		c.JSON(http.StatusOK, histogram)
+		return
+	}
+
+	records := [][]string{{"from", "to", "currency", "count", "total"}}
+	for _, b := range histogram.Buckets {
+		records = append(records, []string{amountCell(b.From), amountCell(b.To), histogram.Currency, strconv.Itoa(b.Count), b.Total.String()})
+	}
+	h.writeCSV(c, records)
+}
+
+// format reads the format parameter, answering 400 when it is neither json
+// nor csv.
+func (h *StatsHandler) format(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown format %q", format)})
+		return "", false
+	}
+	return format, true
+}
+
+func (h *StatsHandler) writeCSV(c *gin.Context, records [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(records); err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Error writing statistics", logger.Err(err))
+	}
+}
+
+func (h *StatsHandler) fail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownCurrency),
+		errors.Is(err, service.ErrUnknownDimension),
+		errors.Is(err, service.ErrInvalidBuckets):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorContext(c.Request.Context(), "Error computing statistics", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error computing statistics"})
+	}
+}
+
+func amountCell(a *money.Amount) string {
+	if a == nil {
+		return ""
+	}
+	return a.String()
+}
+
+func ratioCell(r *float64) string {
+	if r == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*r, 'f', -1, 64)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VocabularyHandler struct {
+	service *service.VocabularyService
+	log     *slog.Logger
+}
+
+func NewVocabularyHandler(service *service.VocabularyService, log *slog.Logger) *VocabularyHandler {
+	return &VocabularyHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Countries lists the ISO 3166-1 countries.
+// @Summary Get countries
+// @Description Lists all countries ordered by name. Names are in the requested language where a translation exists and in English otherwise.
+// @Tags vocabularies
+// @Produce json
+// @Param lang query string false "Language of the names: en, ru or kk (default: en)"
+// @Success 200 {array} models.Country "Countries"
+// @Failure 500 {object} map[string]string "Error listing countries"
+// @Router /api/countries [get]
+func (h *VocabularyHandler) Countries(c *gin.Context) {
+	countries, err := h.service.Countries(c.Request.Context(), c.DefaultQuery("lang", "en"))
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Error listing countries", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error listing countries"})
+		return
+	}
+	c.JSON(http.StatusOK, countries)
+}
+
+// Country retrieves a country with its names in every language.
+// @Summary Get country by code
+// @Tags vocabularies
+// @Produce json
+// @Param code path string true "ISO 3166-1 alpha-2 code"
+// @Success 200 {object} models.Country "Country"
+// @Failure 404 {object} map[string]string "Country not found"
+// @Failure 500 {object} map[string]string "Error fetching country"
+// @Router /api/countries/{code} [get]
+func (h *VocabularyHandler) Country(c *gin.Context) {
+	country, err := h.service.Country(c.Request.Context(), c.Param("code"))
+	if errors.Is(err, service.ErrCountryNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Country not found"})
+		return
+	}
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Error fetching country", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching country"})
+		return
+	}
+	c.JSON(http.StatusOK, country)
+}
+
+// Industries returns the industry taxonomy.
+// @Summary Get industries
+// @Description Returns the industry taxonomy as a tree of top-level industries with their children.
+// @Tags vocabularies
+// @Produce json
+// @Success 200 {array} models.Industry "Industry tree"
+// @Failure 500 {object} map[string]string "Error listing industries"
+// @Router /api/industries [get]
+func (h *VocabularyHandler) Industries(c *gin.Context) {
+	industries, err := h.service.Industries(c.Request.Context())
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Error listing industries", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error listing industries"})
+		return
+	}
+	c.JSON(http.StatusOK, industries)
+}
+
+// Industry retrieves an industry with the industries below it.
+// @Summary Get industry by code
+// @Tags vocabularies
+// @Produce json
+// @Param code path string true "Industry code, e.g. technology.software"
+// @Success 200 {object} models.Industry "Industry with its children"
+// @Failure 404 {object} map[string]string "Industry not found"
+// @Failure 500 {object} map[string]string "Error fetching industry"
+// @Router /api/industries/{code} [get]
+func (h *VocabularyHandler) Industry(c *gin.Context) {
+	industry, err := h.service.Industry(c.Request.Context(), c.Param("code"))
+	if errors.Is(err, service.ErrIndustryNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Industry not found"})
+		return
+	}
+	if err != nil {
+		h.log.ErrorContext(c.Request.Context(), "Error fetching industry", logger.Err(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching industry"})
+		return
+	}
+	c.JSON(http.StatusOK, industry)
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	loggerKey struct{}
+	attrsKey  struct{}
+)
+
+// WithLogger returns a copy of ctx carrying l.
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// WithAttrs returns a copy of ctx whose request-scoped attributes include
+// attrs. An attribute replaces an earlier one with the same key, so e.g. an
+// auth middleware can overwrite the "user" set by the request middleware.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing := attrsFromContext(ctx)
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+
+	for _, a := range existing {
+		replaced := false
+		for _, n := range attrs {
+			if a.Key == n.Key {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, a)
+		}
+	}
+	merged = append(merged, attrs...)
+
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// Value returns the request-scoped attribute key stored by WithAttrs, e.g.
+// "user" or "request_id".
+func Value(ctx context.Context, key string) (slog.Value, bool) {
+	attrs := attrsFromContext(ctx)
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == key {
+			return attrs[i].Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// FromContext returns the request-scoped logger: the logger stored with
+// WithLogger (or slog.Default) with the context's attributes already
+// applied, so it can be used without the *Context methods.
+func FromContext(ctx context.Context) *slog.Logger {
+	l, ok := ctx.Value(loggerKey{}).(*slog.Logger)
+	if !ok {
+		l = slog.Default()
+	}
+
+	attrs := attrsFromContext(ctx)
+	if len(attrs) == 0 {
+		return l
+	}
+
+	if h, ok := l.Handler().(*ContextHandler); ok {
+		return slog.New(&ContextHandler{Handler: h.Handler.WithAttrs(attrs), bound: true})
+	}
+
+	args := make([]interface{}, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return l.With(args...)
+}
+
+// ContextHandler enriches records logged through the *Context methods
+// (InfoContext, ErrorContext, ...) with the request-scoped attributes stored
+// by WithAttrs and the trace and span IDs of the span active in that context.
+type ContextHandler struct {
+	slog.Handler
+
+	// bound is set when the request attributes were already applied via
+	// WithAttrs, to avoid logging them twice.
+	bound bool
+}
+
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.bound {
+		r.AddAttrs(attrsFromContext(ctx)...)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs), bound: h.bound}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name), bound: h.bound}
+}
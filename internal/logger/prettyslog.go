@@ -1,11 +1,18 @@
 package logger
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	stdLog "log"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
 )
 
 const (
@@ -24,85 +31,294 @@ func Colorize(color, text string) string {
 
 type PrettyHandlerOptions struct {
 	SlogOpts *slog.HandlerOptions
+
+	// NoColor disables ANSI colors. UseColor picks a sensible value for a
+	// given writer.
+	NoColor bool
 }
 
-type PrettyHandler struct {
-	slog.Handler
-	l     *stdLog.Logger
+// UseColor reports whether output to w should be colored: w must be a
+// terminal and NO_COLOR (https://no-color.org) must be unset.
+func UseColor(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// groupOrAttrs is one WithGroup or WithAttrs call, kept in call order so that
+// attributes end up nested under the groups opened before them.
+type groupOrAttrs struct {
+	group string
 	attrs []slog.Attr
 }
 
+// PrettyHandler is a human-readable slog.Handler for local development:
+//
+//	[15:04:05.000] INFO: message {
+//	  "key": "value"
+//	}
+//
+// Attributes keep the order in which they were added: handler attributes
+// first, then the record's own.
+type PrettyHandler struct {
+	opts PrettyHandlerOptions
+	l    *stdLog.Logger
+	goas []groupOrAttrs
+}
+
 func (opts PrettyHandlerOptions) NewPrettyHandler(
 	out io.Writer,
 ) *PrettyHandler {
+	if opts.SlogOpts == nil {
+		opts.SlogOpts = &slog.HandlerOptions{}
+	}
+
 	h := &PrettyHandler{
-		Handler: slog.NewJSONHandler(out, opts.SlogOpts),
-		l:       stdLog.New(out, "", 0),
+		opts: opts,
+		l:    stdLog.New(out, "", 0),
 	}
 
 	return h
 }
 
+func (h *PrettyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.SlogOpts.Level != nil {
+		minLevel = h.opts.SlogOpts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+func (h *PrettyHandler) colorize(color, text string) string {
+	if h.opts.NoColor {
+		return text
+	}
+	return Colorize(color, text)
+}
+
 func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	level := r.Level.String() + ":"
 
-	switch r.Level {
-	case slog.LevelDebug:
-		level = Colorize(Magenta, level)
-	case slog.LevelInfo:
-		level = Colorize(Blue, level)
-	case slog.LevelWarn:
-		level = Colorize(Yellow, level)
-	case slog.LevelError:
-		level = Colorize(Red, level)
+	switch {
+	case r.Level < slog.LevelInfo:
+		level = h.colorize(Magenta, level)
+	case r.Level < slog.LevelWarn:
+		level = h.colorize(Blue, level)
+	case r.Level < slog.LevelError:
+		level = h.colorize(Yellow, level)
+	default:
+		level = h.colorize(Red, level)
 	}
 
-	fields := make(map[string]interface{}, r.NumAttrs())
-
+	root := &node{}
+	current := root
+	var groups []string
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			current = current.child(goa.group)
+			groups = append(groups, goa.group)
+			continue
+		}
+		for _, a := range goa.attrs {
+			h.addAttr(current, groups, a)
+		}
+	}
 	r.Attrs(func(a slog.Attr) bool {
-		fields[a.Key] = a.Value.Any()
-
+		h.addAttr(current, groups, a)
 		return true
 	})
 
-	for _, a := range h.attrs {
-		fields[a.Key] = a.Value.Any()
+	parts := []string{}
+	if !r.Time.IsZero() {
+		parts = append(parts, r.Time.Format("[15:04:05.000]"))
+	}
+	parts = append(parts, level)
+
+	if h.opts.SlogOpts.AddSource && r.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{r.PC})
+		f, _ := frames.Next()
+		source := filepath.Join(filepath.Base(filepath.Dir(f.File)), filepath.Base(f.File)) + ":" + strconv.Itoa(f.Line)
+		parts = append(parts, h.colorize(White, source))
+	}
+
+	parts = append(parts, h.colorize(Cyan, r.Message))
+
+	if !root.empty() {
+		var buf bytes.Buffer
+		root.encode(&buf, "")
+		parts = append(parts, h.colorize(White, buf.String()))
+	}
+
+	args := make([]interface{}, len(parts))
+	for i, p := range parts {
+		args[i] = p
 	}
+	h.l.Println(args...)
 
-	var b []byte
-	var err error
+	return nil
+}
 
-	if len(fields) > 0 {
-		b, err = json.MarshalIndent(fields, "", "  ")
-		if err != nil {
-			return err
+func (h *PrettyHandler) addAttr(n *node, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return
+		}
+		// An inline group (empty key) merges its attributes into the parent.
+		if a.Key != "" {
+			n = n.child(a.Key)
+			groups = append(groups[:len(groups):len(groups)], a.Key)
 		}
+		for _, ga := range attrs {
+			h.addAttr(n, groups, ga)
+		}
+		return
 	}
 
-	timeStr := r.Time.Format("[15:05:05.000]")
-	msg := Colorize(Cyan, r.Message)
+	if h.opts.SlogOpts.ReplaceAttr != nil {
+		a = h.opts.SlogOpts.ReplaceAttr(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Equal(slog.Attr{}) {
+		return
+	}
 
-	h.l.Println(
-		timeStr,
-		level,
-		msg,
-		Colorize(White, string(b)),
-	)
+	n.set(a.Key, a.Value)
+}
 
-	return nil
+func (h *PrettyHandler) withGroupOrAttrs(goa groupOrAttrs) *PrettyHandler {
+	h2 := *h
+	h2.goas = make([]groupOrAttrs, len(h.goas)+1)
+	copy(h2.goas, h.goas)
+	h2.goas[len(h2.goas)-1] = goa
+	return &h2
 }
 
 func (h *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &PrettyHandler{
-		Handler: h.Handler,
-		l:       h.l,
-		attrs:   attrs,
+	if len(attrs) == 0 {
+		return h
 	}
+	return h.withGroupOrAttrs(groupOrAttrs{attrs: attrs})
 }
 
 func (h *PrettyHandler) WithGroup(name string) slog.Handler {
-	return &PrettyHandler{
-		Handler: h.Handler.WithGroup(name),
-		l:       h.l,
+	if name == "" {
+		return h
+	}
+	return h.withGroupOrAttrs(groupOrAttrs{group: name})
+}
+
+// node is an insertion-ordered JSON object.
+type node struct {
+	keys     []string
+	values   map[string]slog.Value
+	children map[string]*node
+}
+
+func (n *node) child(key string) *node {
+	if n.children == nil {
+		n.children = make(map[string]*node)
+	}
+	if c, ok := n.children[key]; ok {
+		return c
+	}
+	c := &node{}
+	n.children[key] = c
+	n.keys = append(n.keys, key)
+	return c
+}
+
+func (n *node) set(key string, v slog.Value) {
+	if n.values == nil {
+		n.values = make(map[string]slog.Value)
+	}
+	if _, ok := n.values[key]; !ok {
+		if _, isGroup := n.children[key]; !isGroup {
+			n.keys = append(n.keys, key)
+		}
+	}
+	n.values[key] = v
+}
+
+func (n *node) empty() bool {
+	if len(n.values) > 0 {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.empty() {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node) encode(buf *bytes.Buffer, indent string) {
+	buf.WriteString("{")
+	first := true
+	for _, key := range n.keys {
+		c, isGroup := n.children[key]
+		if isGroup && c.empty() {
+			continue
+		}
+
+		if !first {
+			buf.WriteString(",")
+		}
+		first = false
+
+		buf.WriteString("\n" + indent + "  ")
+		buf.WriteString(strconv.Quote(key))
+		buf.WriteString(": ")
+
+		if isGroup {
+			c.encode(buf, indent+"  ")
+			continue
+		}
+		buf.WriteString(encodeValue(n.values[key], indent+"  "))
+	}
+	buf.WriteString("\n" + indent + "}")
+}
+
+func encodeValue(v slog.Value, indent string) string {
+	switch v.Kind() {
+	case slog.KindString:
+		return strconv.Quote(v.String())
+	case slog.KindInt64:
+		return strconv.FormatInt(v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.FormatUint(v.Uint64(), 10)
+	case slog.KindFloat64:
+		return strconv.FormatFloat(v.Float64(), 'g', -1, 64)
+	case slog.KindBool:
+		return strconv.FormatBool(v.Bool())
+	case slog.KindDuration:
+		return strconv.Quote(v.Duration().String())
+	case slog.KindTime:
+		return strconv.Quote(v.Time().Format(time.RFC3339Nano))
+	}
+
+	val := v.Any()
+	if err, ok := val.(error); ok {
+		return strconv.Quote(err.Error())
+	}
+	if s, ok := val.(fmt.Stringer); ok {
+		return strconv.Quote(s.String())
+	}
+
+	b, err := json.MarshalIndent(val, indent, "  ")
+	if err != nil {
+		return strconv.Quote(fmt.Sprintf("%+v", val))
 	}
+	return string(b)
 }
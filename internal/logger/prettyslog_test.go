@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// recordTime is the time of every test record; only its clock is printed.
+var recordTime = time.Date(2026, 10, 19, 15, 4, 5, 123e6, time.UTC)
+
+// here returns the program counter of its caller, as slog.Logger records it.
+func here() uintptr {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	return pcs[0]
+}
+
+func TestPrettyHandlerGolden(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		opts PrettyHandlerOptions
+		log  func(h slog.Handler)
+	}{
+		{
+			name: "nested_groups",
+			opts: PrettyHandlerOptions{NoColor: true},
+			log: func(h slog.Handler) {
+				h = h.WithAttrs([]slog.Attr{slog.String("service", "wealthlist")}).
+					WithGroup("request").
+					WithAttrs([]slog.Attr{slog.String("id", "abc123")}).
+					WithGroup("db")
+				r := slog.NewRecord(recordTime, slog.LevelInfo, "query done", 0)
+				r.AddAttrs(
+					slog.Duration("took", 1500*time.Millisecond),
+					slog.Group("rows", slog.Int("read", 10), slog.Int("written", 2)),
+					slog.Group("", slog.Bool("inline", true)),
+					slog.Group("empty"),
+				)
+				h.Handle(context.Background(), r)
+			},
+		},
+		{
+			name: "accumulated_attrs",
+			opts: PrettyHandlerOptions{NoColor: true},
+			log: func(h slog.Handler) {
+				h = h.WithAttrs([]slog.Attr{slog.String("user", "anonymous"), slog.Int("attempt", 1)}).
+					WithAttrs([]slog.Attr{slog.String("request_id", "r-1")}).
+					WithAttrs(nil)
+				for _, msg := range []string{"first", "second"} {
+					r := slog.NewRecord(recordTime, slog.LevelWarn, msg, 0)
+					r.AddAttrs(slog.String("user", "admin"), slog.Any("error", errors.New("boom")))
+					h.Handle(context.Background(), r)
+				}
+			},
+		},
+		{
+			name: "add_source",
+			opts: PrettyHandlerOptions{SlogOpts: &slog.HandlerOptions{AddSource: true}, NoColor: true},
+			log: func(h slog.Handler) {
+				r := slog.NewRecord(recordTime, slog.LevelError, "with source", here())
+				r.AddAttrs(slog.Float64("ratio", 0.25))
+				h.Handle(context.Background(), r)
+				h.Handle(context.Background(), slog.NewRecord(recordTime, slog.LevelError, "without a PC", 0))
+			},
+		},
+		{
+			name: "color",
+			opts: PrettyHandlerOptions{},
+			log: func(h slog.Handler) {
+				for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+					r := slog.NewRecord(recordTime, level, "level "+level.String(), 0)
+					r.AddAttrs(slog.Int("n", int(level)))
+					h.Handle(context.Background(), r)
+				}
+			},
+		},
+		{
+			name: "key_order",
+			opts: PrettyHandlerOptions{NoColor: true},
+			log: func(h slog.Handler) {
+				h = h.WithAttrs([]slog.Attr{slog.String("zeta", "handler first")})
+				r := slog.NewRecord(recordTime, slog.LevelInfo, "ordered", 0)
+				r.AddAttrs(
+					slog.String("mike", "m"),
+					slog.String("alpha", "a"),
+					slog.Any("map", map[string]int{"b": 2, "a": 1, "c": 3}),
+					slog.Group("group", slog.String("y", "1"), slog.String("x", "2")),
+					slog.String("mike", "replaced in place"),
+				)
+				h.Handle(context.Background(), r)
+			},
+		},
+		{
+			name: "replace_attr",
+			opts: PrettyHandlerOptions{
+				NoColor: true,
+				SlogOpts: &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if a.Key == "password" {
+						return slog.String(a.Key, "[REDACTED]")
+					}
+					if a.Key == "drop" {
+						return slog.Attr{}
+					}
+					return a
+				}},
+			},
+			log: func(h slog.Handler) {
+				r := slog.NewRecord(recordTime, slog.LevelInfo, "login", 0)
+				r.AddAttrs(slog.Group("auth", slog.String("user", "admin"), slog.String("password", "secret")), slog.Bool("drop", true))
+				h.Handle(context.Background(), r)
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c.log(c.opts.NewPrettyHandler(&buf))
+			golden(t, filepath.Join("testdata", c.name+".golden"), buf.Bytes())
+		})
+	}
+}
+
+func TestPrettyHandlerEnabled(t *testing.T) {
+	h := PrettyHandlerOptions{}.NewPrettyHandler(&bytes.Buffer{})
+	if h.Enabled(context.Background(), slog.LevelDebug) || !h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("default level is not info")
+	}
+
+	h = PrettyHandlerOptions{SlogOpts: &slog.HandlerOptions{Level: slog.LevelWarn}}.NewPrettyHandler(&bytes.Buffer{})
+	if h.Enabled(context.Background(), slog.LevelInfo) || !h.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("configured level is not applied")
+	}
+}
+
+// golden compares got with the file at path, or rewrites the file with
+// -update.
+func golden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("update %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s (run with -update to create it): %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
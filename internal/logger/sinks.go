@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// MultiHandler fans records out to several handlers, e.g. stdout and a file.
+type MultiHandler struct {
+	handlers []slog.Handler
+}
+
+func NewMultiHandler(handlers ...slog.Handler) *MultiHandler {
+	return &MultiHandler{handlers: handlers}
+}
+
+func (h *MultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, hh := range h.handlers {
+		if hh.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *MultiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, hh := range h.handlers {
+		if !hh.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := hh.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *MultiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, hh := range h.handlers {
+		handlers[i] = hh.WithAttrs(attrs)
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+func (h *MultiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, hh := range h.handlers {
+		handlers[i] = hh.WithGroup(name)
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+// sampler counts records per message within the current interval. It is
+// shared by every handler derived from the same SamplingHandler.
+type sampler struct {
+	mu         sync.Mutex
+	first      int
+	thereafter int
+	interval   time.Duration
+	messages   map[string]bool
+	windows    map[string]*sampleWindow
+}
+
+type sampleWindow struct {
+	start time.Time
+	count int
+}
+
+func (s *sampler) allow(r slog.Record) bool {
+	if r.Level >= slog.LevelWarn {
+		return true
+	}
+	if !s.messages[r.Message] {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := r.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	w, ok := s.windows[r.Message]
+	if !ok || now.Sub(w.start) >= s.interval {
+		// Windows of messages that have not come up for an interval are
+		// done with; drop them rather than keep one per message forever.
+		for m, old := range s.windows {
+			if now.Sub(old.start) >= s.interval {
+				delete(s.windows, m)
+			}
+		}
+		w = &sampleWindow{start: now}
+		s.windows[r.Message] = w
+	}
+	w.count++
+
+	if w.count <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (w.count-s.first)%s.thereafter == 0
+}
+
+// SamplingHandler drops repetitive DEBUG and INFO records with one of the
+// given messages: per message and interval it keeps the first `first`
+// records and then every `thereafter`-th. Other messages, warnings and
+// errors are never dropped.
+type SamplingHandler struct {
+	slog.Handler
+	s *sampler
+}
+
+func NewSamplingHandler(h slog.Handler, messages []string, first, thereafter int, interval time.Duration) *SamplingHandler {
+	set := make(map[string]bool, len(messages))
+	for _, m := range messages {
+		set[m] = true
+	}
+
+	return &SamplingHandler{
+		Handler: h,
+		s: &sampler{
+			first:      first,
+			thereafter: thereafter,
+			interval:   interval,
+			messages:   set,
+			windows:    make(map[string]*sampleWindow),
+		},
+	}
+}
+
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.s.allow(r) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{Handler: h.Handler.WithAttrs(attrs), s: h.s}
+}
+
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{Handler: h.Handler.WithGroup(name), s: h.s}
+}
+
+// rotatingFile is a size-rotated log file with retention and compression,
+// optionally also rotated on a fixed interval.
+type rotatingFile struct {
+	*lumberjack.Logger
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int, compress bool, interval time.Duration) *rotatingFile {
+	f := &rotatingFile{
+		Logger: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSizeMB,
+			MaxBackups: maxBackups,
+			MaxAge:     maxAgeDays,
+			Compress:   compress,
+		},
+	}
+
+	if interval > 0 {
+		f.stop = make(chan struct{})
+		f.done = make(chan struct{})
+		go f.rotateEvery(interval)
+	}
+
+	return f
+}
+
+func (f *rotatingFile) rotateEvery(interval time.Duration) {
+	defer close(f.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.Rotate()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+func (f *rotatingFile) Close() error {
+	if f.stop != nil {
+		close(f.stop)
+		<-f.done
+	}
+	return f.Logger.Close()
+}
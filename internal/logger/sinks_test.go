@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// countingHandler counts the records it is handed.
+type countingHandler struct {
+	slog.Handler
+	n int
+}
+
+func (h *countingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *countingHandler) Handle(context.Context, slog.Record) error {
+	h.n++
+	return nil
+}
+
+func TestSamplingHandler(t *testing.T) {
+	next := &countingHandler{}
+	h := NewSamplingHandler(next, []string{"noisy"}, 2, 3, time.Second)
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	handle := func(at time.Duration, level slog.Level, msg string) {
+		t.Helper()
+		if err := h.Handle(context.Background(), slog.NewRecord(start.Add(at), level, msg, 0)); err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+	}
+
+	// The first 2, then every 3rd: records 1, 2, 5 and 8 of 9.
+	for i := 0; i < 9; i++ {
+		handle(0, slog.LevelInfo, "noisy")
+	}
+	if next.n != 4 {
+		t.Errorf("noisy records kept: %d, want 4", next.n)
+	}
+
+	next.n = 0
+	for i := 0; i < 9; i++ {
+		handle(0, slog.LevelInfo, "quiet")
+		handle(0, slog.LevelWarn, "noisy")
+	}
+	if next.n != 18 {
+		t.Errorf("unlisted messages and warnings kept: %d, want 18", next.n)
+	}
+
+	// A new interval starts over.
+	next.n = 0
+	handle(time.Second, slog.LevelInfo, "noisy")
+	if next.n != 1 {
+		t.Errorf("records kept in a new interval: %d, want 1", next.n)
+	}
+}
+
+func TestSamplingHandlerEvictsWindows(t *testing.T) {
+	messages := make([]string, 100)
+	for i := range messages {
+		messages[i] = fmt.Sprintf("message %d", i)
+	}
+	h := NewSamplingHandler(&countingHandler{}, messages, 1, 0, time.Second)
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	for i, m := range messages {
+		h.Handle(context.Background(), slog.NewRecord(start.Add(time.Duration(i)*time.Second), slog.LevelInfo, m, 0))
+	}
+	if n := len(h.s.windows); n != 1 {
+		t.Errorf("windows kept: %d, want only the current one", n)
+	}
+}
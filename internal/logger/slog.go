@@ -1,8 +1,15 @@
 package logger
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"wealthlist/config"
 )
 
 const (
@@ -11,6 +18,21 @@ const (
 	envProd  = "prod"
 )
 
+const (
+	SinkStdout = "stdout"
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+)
+
+var (
+	// level is shared by every sink so the threshold can be changed at
+	// runtime with SetLevel.
+	level = new(slog.LevelVar)
+
+	closersMu sync.Mutex
+	closers   []io.Closer
+)
+
 func Err(err error) slog.Attr {
 	return slog.Attr{
 		Key:   "error",
@@ -18,36 +40,125 @@ func Err(err error) slog.Attr {
 	}
 }
 
-func SetupLogger(env string) *slog.Logger {
-	var log *slog.Logger
+// Level returns the current minimum level.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetLevel changes the minimum level of every logger built by SetupLogger.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
 
+// ParseLevel accepts debug, info, warn, error (case-insensitive) and offsets
+// such as info+2.
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", s)
+	}
+	return l, nil
+}
+
+func defaultLevel(env string) slog.Level {
 	switch env {
-	case envLocal:
-		log = setupPrettySlog()
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
+	case envLocal, envDev:
+		return slog.LevelDebug
 	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+		return slog.LevelInfo
 	default:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}),
-		)
+		return slog.LevelWarn
 	}
-	return log
 }
 
-func setupPrettySlog() *slog.Logger {
-	opts := PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
+func SetupLogger(env string, cfg config.LogConfig) (*slog.Logger, error) {
+	lvl := defaultLevel(env)
+	if cfg.Level != "" {
+		parsed, err := ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, err
+		}
+		lvl = parsed
+	}
+	level.Set(lvl)
+
+	sinks := cfg.Sinks
+	if len(sinks) == 0 {
+		sinks = []string{SinkStdout}
+	}
+
+	var handlers []slog.Handler
+	for _, sink := range sinks {
+		switch sink {
+		case SinkStdout:
+			handlers = append(handlers, consoleHandler(env, os.Stdout))
+		case SinkStderr:
+			handlers = append(handlers, consoleHandler(env, os.Stderr))
+		case SinkFile:
+			h, err := fileHandler(cfg.File)
+			if err != nil {
+				return nil, err
+			}
+			handlers = append(handlers, h)
+		default:
+			return nil, fmt.Errorf("unknown log sink %q", sink)
+		}
 	}
 
-	handler := opts.NewPrettyHandler(os.Stdout)
+	var handler slog.Handler
+	if len(handlers) == 1 {
+		handler = handlers[0]
+	} else {
+		handler = NewMultiHandler(handlers...)
+	}
 
-	return slog.New(handler)
+	if s := cfg.Sampling; s.First > 0 {
+		handler = NewSamplingHandler(handler, s.Messages, s.First, s.Thereafter, s.Interval)
+	}
+
+	return slog.New(NewContextHandler(handler)), nil
+}
+
+// consoleHandler writes pretty output in the local environment and JSON
+// everywhere else.
+func consoleHandler(env string, out *os.File) slog.Handler {
+	if env == envLocal {
+		opts := PrettyHandlerOptions{
+			SlogOpts: &slog.HandlerOptions{
+				Level:     level,
+				AddSource: true,
+			},
+			NoColor: !UseColor(out),
+		}
+		return opts.NewPrettyHandler(out)
+	}
+
+	return slog.NewJSONHandler(out, &slog.HandlerOptions{Level: level})
+}
+
+func fileHandler(cfg config.LogFileConfig) (slog.Handler, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f := newRotatingFile(cfg.Path, cfg.MaxSizeMB, cfg.MaxBackups, cfg.MaxAgeDays, cfg.Compress, cfg.RotateInterval)
+
+	closersMu.Lock()
+	closers = append(closers, f)
+	closersMu.Unlock()
+
+	return slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level}), nil
+}
+
+// Close flushes and closes file sinks opened by SetupLogger.
+func Close() error {
+	closersMu.Lock()
+	defer closersMu.Unlock()
+
+	var errs []error
+	for _, c := range closers {
+		errs = append(errs, c.Close())
+	}
+	closers = nil
+	return errors.Join(errs...)
 }
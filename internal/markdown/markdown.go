@@ -0,0 +1,35 @@
+// Package markdown renders user-supplied Markdown to HTML that is safe to
+// embed in a page.
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// policy allows the formatting Markdown produces and drops scripts,
+	// styles, event handlers and unsafe URLs. External links get
+	// rel="nofollow noopener" and open in a new tab.
+	policy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy()
+		p.RequireNoFollowOnLinks(true)
+		p.AddTargetBlankToFullyQualifiedLinks(true)
+		return p
+	}()
+)
+
+// Render converts src to sanitized HTML. Raw HTML in src is dropped by the
+// renderer; the sanitizer is a second line of defence for links and images.
+func Render(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		return "", err
+	}
+	return policy.Sanitize(buf.String()), nil
+}
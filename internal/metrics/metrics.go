@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "wealthlist"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Repository method latency.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	dbReplicaHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "db_replica_healthy",
+		Help:      "Whether a read replica is in rotation (1) or not (0).",
+	}, []string{"replica"})
+
+	FeedbackSent = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "feedback_sent_total",
+		Help:      "Feedback emails sent successfully.",
+	})
+
+	FeedbackFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "feedback_failed_total",
+		Help:      "Feedback emails that could not be sent.",
+	})
+
+	PhotosUploaded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "photos_uploaded_total",
+		Help:      "Photos stored on disk.",
+	})
+
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cached lookups by cache and result: hit, miss, bypass or error.",
+	}, []string{"cache", "result"})
+
+	CacheInvalidations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_invalidations_total",
+		Help:      "Times the response cache was invalidated by a write.",
+	})
+
+	MillionairesCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "millionaires_created_total",
+		Help:      "Millionaire records created.",
+	})
+)
+
+// Handler serves the default registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDBStats exposes sql.DBStats (open, idle, in-use connections and
+// wait counts) for the given pool.
+func RegisterDBStats(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveQuery records the time since start for a repository method. It is
+// meant to be deferred: defer metrics.ObserveQuery("photo", "GetPhotoPath", time.Now()).
+func ObserveQuery(repository, method string, start time.Time) {
+	dbQueryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+}
+
+// ObserveCache counts a lookup in the named cache.
+func ObserveCache(cache, result string) {
+	cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// SetReplicaHealthy records the outcome of the latest replica health check.
+func SetReplicaHealthy(replica string, healthy bool) {
+	v := 0.0
+	if healthy {
+		v = 1
+	}
+	dbReplicaHealthy.WithLabelValues(replica).Set(v)
+}
+
+// Middleware records request count, latency and in-flight requests. Routes
+// are labelled by their template (/api/millionaires/:id) to keep cardinality
+// bounded.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		httpRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		httpDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+	"wealthlist/internal/money"
+)
+
+type Company struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name" binding:"required,max=500"`
+	LegalID   *string   `json:"legalId,omitempty" binding:"omitempty,max=100"`
+	Country   *string   `json:"country,omitempty"`
+	Industry  *string   `json:"industry,omitempty"`
+	Ticker    *string   `json:"ticker,omitempty" binding:"omitempty,max=20"`
+	Founded   *string   `json:"founded,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Website   *string   `json:"website,omitempty" binding:"omitempty,http_url"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type PaginationCompanyDto struct {
+	Companies []Company `json:"companies"`
+	Total     int       `json:"total"`
+	Page      int       `json:"page"`
+	PageSize  int       `json:"pageSize"`
+}
+
+// Holding is a millionaire's role in a company. Value is the part of the
+// millionaire's net worth the holding accounts for; EndedOn is empty while
+// the holding lasts.
+type Holding struct {
+	ID            int           `json:"id"`
+	MillionaireID int           `json:"millionaireId"`
+	CompanyID     int           `json:"companyId" binding:"required"`
+	CompanyName   string        `json:"companyName,omitempty" readonly:"true"`
+	Role          string        `json:"role" binding:"required,oneof=founder ceo shareholder" enums:"founder,ceo,shareholder"`
+	StakePercent  *float64      `json:"stakePercent,omitempty" binding:"omitempty,gt=0,lte=100"`
+	Value         *money.Amount `json:"value,omitempty" swaggertype:"number"`
+	StartedOn     *string       `json:"startedOn,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	EndedOn       *string       `json:"endedOn,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// CompanyPerson is a holding together with the name of its holder.
+type CompanyPerson struct {
+	Holding
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// NetWorthBreakdown splits a millionaire's net worth across current
+// holdings. Other is what no holding accounts for. Holding values are taken
+// to be in the net worth's currency.
+type NetWorthBreakdown struct {
+	MillionaireID int          `json:"millionaireId"`
+	NetWorth      money.Amount `json:"netWorth" swaggertype:"number"`
+	Currency      string       `json:"currency"`
+	Holdings      []Holding    `json:"holdings"`
+	Other         money.Amount `json:"other" swaggertype:"number"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "wealthlist/internal/buildinfo"
+
+type HealthCheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type ReadinessDto struct {
+	Status string              `json:"status"`
+	Checks []HealthCheckResult `json:"checks"`
+}
+
+type VersionDto struct {
+	buildinfo.Info
+	SchemaVersion         int64 `json:"schemaVersion"`
+	ExpectedSchemaVersion int64 `json:"expectedSchemaVersion"`
+}
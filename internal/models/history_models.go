@@ -0,0 +1,11 @@
+package models
+
+import "wealthlist/internal/money"
+
+// NetWorthPoint is a millionaire's net worth on a given day (YYYY-MM-DD) in
+// an ISO 4217 currency.
+type NetWorthPoint struct {
+	Date     string       `json:"date"`
+	NetWorth money.Amount `json:"netWorth" swaggertype:"number"`
+	Currency string       `json:"currency"`
+}
@@ -1,5 +1,64 @@
 package models
 
+import (
+	"time"
+	"wealthlist/internal/money"
+)
+
+// Kinds of homepage section.
+const (
+	SectionTop       = "top"
+	SectionNewcomers = "newcomers"
+	SectionGainers   = "gainers"
+	SectionLosers    = "losers"
+	SectionFeatured  = "featured"
+)
+
+// HomeSection defines one section of the homepage. Top, newcomers, gainers
+// and losers list up to Size millionaires, optionally only those in
+// Countries or Industries (codes; an industry includes the ones below it).
+// Gainers and losers compare net worth with WindowDays ago. Featured lists
+// MillionaireIDs in their order. Sections appear by Position; hidden ones
+// are kept but not shown.
+type HomeSection struct {
+	ID             int       `json:"id"`
+	Position       int       `json:"position" binding:"min=0"`
+	Kind           string    `json:"kind" binding:"required,oneof=top newcomers gainers losers featured" example:"top"`
+	Title          string    `json:"title" binding:"required,max=200" example:"Richest in Kazakhstan"`
+	Size           int       `json:"size" binding:"omitempty,min=1,max=100" example:"10"`
+	Countries      []string  `json:"countries,omitempty" example:"KZ"`
+	Industries     []string  `json:"industries,omitempty" example:"technology"`
+	WindowDays     *int      `json:"windowDays,omitempty" binding:"omitempty,min=1" example:"365"`
+	MillionaireIDs []int     `json:"millionaireIds,omitempty"`
+	Hidden         bool      `json:"hidden"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// NetWorthChange is how a net worth moved from one day to today, both ends
+// in Currency.
+type NetWorthChange struct {
+	Since    string       `json:"since"`
+	From     money.Amount `json:"from" swaggertype:"number"`
+	To       money.Amount `json:"to" swaggertype:"number"`
+	Amount   money.Amount `json:"amount" swaggertype:"number"`
+	Currency string       `json:"currency"`
+}
+
+// HomeEntry is a millionaire in a homepage section; Change is set in
+// gainers and losers.
+type HomeEntry struct {
+	Millionaire Millionaire     `json:"millionaire"`
+	Change      *NetWorthChange `json:"change,omitempty"`
+}
+
+type HomeSectionDto struct {
+	ID      int         `json:"id"`
+	Kind    string      `json:"kind"`
+	Title   string      `json:"title"`
+	Entries []HomeEntry `json:"entries"`
+}
+
 type HomePageDto struct {
-	TopMillionaires []Millionaire `json:"topMillionaires"`
+	Sections []HomeSectionDto `json:"sections"`
 }
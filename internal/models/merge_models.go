@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Sides of a merge a field can be taken from.
+const (
+	MergeTarget = "target"
+	MergeSource = "source"
+)
+
+// MergeRequest merges SourceID into TargetID, which keeps its ID. Fields
+// picks by JSON name which side each field is taken from; a field not
+// listed keeps the target's value unless that is empty. NetWorth brings
+// its currency and valuation day along, Country and Industry their codes.
+type MergeRequest struct {
+	TargetID int               `json:"targetId" binding:"required,min=1" example:"12"`
+	SourceID int               `json:"sourceId" binding:"required,min=1" example:"48"`
+	Fields   map[string]string `json:"fields,omitempty" binding:"omitempty,dive,oneof=target source"`
+}
+
+// MergeResult is the merged millionaire and the JSON names of the fields
+// it took from the source.
+type MergeResult struct {
+	Millionaire Millionaire `json:"millionaire"`
+	FromSource  []string    `json:"fromSource"`
+}
+
+// DuplicatePair is two millionaires that may be the same person. Name is
+// the similarity (0 to 1) of their normalized names, BirthDate and Company
+// how well those agree; they are left out when either side lacks them.
+// Score weighs the three together.
+type DuplicatePair struct {
+	Score        float64       `json:"score" example:"0.94"`
+	Name         float64       `json:"name" example:"0.97"`
+	BirthDate    *float64      `json:"birthDate,omitempty" example:"1"`
+	Company      *float64      `json:"company,omitempty" example:"0.8"`
+	Millionaires []Millionaire `json:"millionaires"`
+}
+
+// Audited actions.
+const AuditMerge = "millionaire.merge"
+
+// AuditEntry records a change made by Actor during the request RequestID.
+// Details depend on Action.
+type AuditEntry struct {
+	ID        int             `json:"id"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"requestId"`
+	Details   json.RawMessage `json:"details" swaggertype:"object"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
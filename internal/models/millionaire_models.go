@@ -1,19 +1,46 @@
 package models
 
-import "time"
+import (
+	"time"
+	"wealthlist/internal/money"
+)
 
 type Millionaire struct {
-	ID          int       `json:"id"`
-	LastName    string    `json:"lastName"`
-	FirstName   string    `json:"firstName"`
-	MiddleName  *string   `json:"middleName,omitempty"`
-	BirthDate   *string   `json:"birthDate,omitempty"`
-	BirthPlace  *string   `json:"birthPlace,omitempty"`
-	Company     *string   `json:"company,omitempty"`
-	NetWorth    *float64  `json:"netWorth,omitempty"`
-	Industry    *string   `json:"industry,omitempty"`
-	Country     *string   `json:"country,omitempty"`
-	PathToPhoto *string   `json:"pathToPhoto,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID         int     `json:"id"`
+	LastName   string  `json:"lastName"`
+	FirstName  string  `json:"firstName"`
+	MiddleName *string `json:"middleName,omitempty"`
+	BirthDate  *string `json:"birthDate,omitempty"`
+	BirthPlace *string `json:"birthPlace,omitempty"`
+	Company    *string `json:"company,omitempty"`
+	// NetWorth is valued in NetWorthCurrency (ISO 4217) on NetWorthAsOf;
+	// they default to USD and today. It may have as many decimal places as
+	// the currency has minor units.
+	NetWorth         *money.Amount `json:"netWorth,omitempty" swaggertype:"number"`
+	NetWorthCurrency string        `json:"netWorthCurrency,omitempty" binding:"omitempty,iso4217"`
+	NetWorthAsOf     *string       `json:"netWorthAsOf,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Industry         *string       `json:"industry,omitempty"`
+	Country          *string       `json:"country,omitempty"`
+	// CountryCode (ISO 3166-1 alpha-2) and IndustryCode are looked up from
+	// Country and Industry when they are not given.
+	CountryCode  *string `json:"countryCode,omitempty"`
+	IndustryCode *string `json:"industryCode,omitempty"`
+	// Biography is Markdown; BiographyHTML is its sanitized rendering and is
+	// only filled in when a single millionaire is fetched.
+	Biography     *string           `json:"biography,omitempty"`
+	BiographyHTML string            `json:"biographyHtml,omitempty" readonly:"true"`
+	Education     *string           `json:"education,omitempty"`
+	Citizenships  []string          `json:"citizenships,omitempty" binding:"omitempty,dive,required,max=100"`
+	MaritalStatus *string           `json:"maritalStatus,omitempty" binding:"omitempty,oneof=single married divorced widowed partnered" enums:"single,married,divorced,widowed,partnered"`
+	ChildrenCount *int              `json:"childrenCount,omitempty" binding:"omitempty,min=0,max=200"`
+	ResidenceCity *string           `json:"residenceCity,omitempty"`
+	Website       *string           `json:"website,omitempty" binding:"omitempty,http_url"`
+	SocialHandles map[string]string `json:"socialHandles,omitempty" binding:"omitempty,dive,keys,oneof=x linkedin instagram facebook telegram youtube tiktok github,endkeys,required,max=100"`
+	// PathToPhoto is stored as a path under uploads/photos; responses carry
+	// the absolute URL the photo is served at, which is accepted back, and
+	// ThumbnailURL that of its thumbnail.
+	PathToPhoto  *string   `json:"pathToPhoto,omitempty" example:"https://api.example.com/api/photo/12_0123456789abcdef.jpg"`
+	ThumbnailURL string    `json:"thumbnailUrl,omitempty" readonly:"true" example:"https://api.example.com/api/photo/12_0123456789abcdef.jpg?size=thumb"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
 }
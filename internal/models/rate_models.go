@@ -0,0 +1,11 @@
+package models
+
+import "wealthlist/internal/money"
+
+// ExchangeRate is how many units of Currency one euro bought on Date
+// (YYYY-MM-DD), as the ECB quotes its reference rates.
+type ExchangeRate struct {
+	Currency string       `json:"currency"`
+	Date     string       `json:"date"`
+	Rate     money.Amount `json:"rate" swaggertype:"number"`
+}
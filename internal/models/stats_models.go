@@ -0,0 +1,56 @@
+package models
+
+import "wealthlist/internal/money"
+
+// NetWorthStats summarizes a set of net worths in one currency. Amounts are
+// exact; means and interpolated percentiles are rounded to the currency's
+// minor units. Everything but Count and Total is omitted for an empty set,
+// and the ratios also when the total is not positive.
+type NetWorthStats struct {
+	Key         string                  `json:"key,omitempty"`
+	Count       int                     `json:"count"`
+	Total       money.Amount            `json:"total" swaggertype:"number"`
+	Mean        *money.Amount           `json:"mean,omitempty" swaggertype:"number"`
+	Median      *money.Amount           `json:"median,omitempty" swaggertype:"number"`
+	Min         *money.Amount           `json:"min,omitempty" swaggertype:"number"`
+	Max         *money.Amount           `json:"max,omitempty" swaggertype:"number"`
+	Percentiles map[string]money.Amount `json:"percentiles,omitempty" swaggertype:"object,number"`
+	// Gini is the Gini coefficient, 0 when everyone is equally rich.
+	Gini *float64 `json:"gini,omitempty"`
+	// Top1Share and Top10Share are the parts of Total held by the richest
+	// 1% and 10%, at least one person each.
+	Top1Share  *float64 `json:"top1Share,omitempty"`
+	Top10Share *float64 `json:"top10Share,omitempty"`
+}
+
+// StatsSummary is the statistics of every matching millionaire. Unconverted
+// counts those left out because their net worth could not be converted to
+// Currency.
+type StatsSummary struct {
+	Currency    string `json:"currency"`
+	Unconverted int    `json:"unconverted"`
+	NetWorthStats
+}
+
+// StatsGroups splits the statistics by Dimension, one entry per key.
+type StatsGroups struct {
+	Currency    string          `json:"currency"`
+	Unconverted int             `json:"unconverted"`
+	Dimension   string          `json:"dimension"`
+	Groups      []NetWorthStats `json:"groups"`
+}
+
+// HistogramBucket counts net worths from From up to but not including To;
+// the first bucket has no lower bound and the last no upper one.
+type HistogramBucket struct {
+	From  *money.Amount `json:"from,omitempty" swaggertype:"number"`
+	To    *money.Amount `json:"to,omitempty" swaggertype:"number"`
+	Count int           `json:"count"`
+	Total money.Amount  `json:"total" swaggertype:"number"`
+}
+
+type Histogram struct {
+	Currency    string            `json:"currency"`
+	Unconverted int               `json:"unconverted"`
+	Buckets     []HistogramBucket `json:"buckets"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// Country is an ISO 3166-1 country. Name is localized when a language was
+// asked for; Names holds every translation and is only filled in for a
+// single country.
+type Country struct {
+	Code   string            `json:"code"`
+	Alpha3 string            `json:"alpha3"`
+	Name   string            `json:"name"`
+	Names  map[string]string `json:"names,omitempty"`
+}
+
+// Industry is a node of the industry taxonomy. Codes are dotted paths, so a
+// child's code starts with its parent's code and a dot.
+type Industry struct {
+	Code       string     `json:"code"`
+	ParentCode *string    `json:"parentCode,omitempty"`
+	Name       string     `json:"name"`
+	Children   []Industry `json:"children,omitempty"`
+}
+
+// UnmatchedValue is a free-text country or industry that no code was found
+// for, with the number of millionaires using it.
+type UnmatchedValue struct {
+	Kind         string `json:"kind"`
+	Value        string `json:"value"`
+	Millionaires int    `json:"millionaires"`
+}
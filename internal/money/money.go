@@ -0,0 +1,252 @@
+// Package money holds exact decimal amounts. An Amount keeps the digits it
+// was written with, so "1200.50" is stored and printed as "1200.50", and
+// arithmetic only rounds where a method says so.
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// JSONEncoding is how amounts encode as JSON. Amounts decode from either
+// form regardless.
+type JSONEncoding int32
+
+const (
+	// JSONNumbers writes amounts as JSON numbers, the default.
+	JSONNumbers JSONEncoding = iota
+	// JSONStrings writes amounts as JSON strings, for clients that would
+	// read numbers as float64.
+	JSONStrings
+)
+
+var jsonEncoding atomic.Int32
+
+// SetJSONEncoding chooses how every amount encodes from now on. The server
+// sets it once at startup from its configuration.
+func SetJSONEncoding(e JSONEncoding) { jsonEncoding.Store(int32(e)) }
+
+var (
+	ErrSyntax = errors.New("invalid decimal")
+
+	decimalSyntax = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+	ten           = big.NewInt(10)
+)
+
+// maxExponent bounds the exponent Parse accepts, so that a short input
+// cannot ask for an enormous number.
+const maxExponent = 100
+
+// Amount is coef × 10^-scale. The zero value is 0.
+type Amount struct {
+	coef  *big.Int // never changed once set; nil is zero
+	scale int
+}
+
+// Parse reads a plain decimal such as "-1200.50" or "1.5e6". An exponent is
+// folded into the digits; otherwise the scale is the number of digits after
+// the point.
+func Parse(s string) (Amount, error) {
+	if !decimalSyntax.MatchString(s) {
+		return Amount{}, fmt.Errorf("%w %q", ErrSyntax, s)
+	}
+
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.Atoi(s[i+1:]); err != nil || exp > maxExponent || exp < -maxExponent {
+			return Amount{}, fmt.Errorf("%w %q", ErrSyntax, s)
+		}
+		s = s[:i]
+	}
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = len(s) - i - 1
+		s = s[:i] + s[i+1:]
+	}
+
+	coef, _ := new(big.Int).SetString(s, 10)
+	a := Amount{coef: coef, scale: scale - exp}
+	if a.scale < 0 {
+		a = a.rescale(0)
+	}
+	return a, nil
+}
+
+// MustParse is Parse for constants; it panics on invalid input.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// FromInt returns the whole amount v.
+func FromInt(v int64) Amount {
+	return Amount{coef: big.NewInt(v)}
+}
+
+func (a Amount) int() *big.Int {
+	if a.coef == nil {
+		return new(big.Int)
+	}
+	return a.coef
+}
+
+// rescale returns a with the given scale. Growing the scale is exact;
+// shrinking it truncates, see Round for rounding.
+func (a Amount) rescale(scale int) Amount {
+	switch {
+	case scale > a.scale:
+		shift := new(big.Int).Exp(ten, big.NewInt(int64(scale-a.scale)), nil)
+		return Amount{coef: new(big.Int).Mul(a.int(), shift), scale: scale}
+	case scale < a.scale:
+		shift := new(big.Int).Exp(ten, big.NewInt(int64(a.scale-scale)), nil)
+		return Amount{coef: new(big.Int).Quo(a.int(), shift), scale: scale}
+	}
+	return a
+}
+
+// String prints the amount without an exponent, with exactly Scale digits
+// after the point.
+func (a Amount) String() string {
+	digits := new(big.Int).Abs(a.int()).String()
+	sign := ""
+	if a.Sign() < 0 {
+		sign = "-"
+	}
+	if a.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= a.scale {
+		digits = strings.Repeat("0", a.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - a.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Scale is the number of digits after the point.
+func (a Amount) Scale() int { return a.scale }
+
+func (a Amount) Sign() int { return a.int().Sign() }
+
+func (a Amount) IsZero() bool { return a.Sign() == 0 }
+
+// Cmp compares values, ignoring scale: 1.50 and 1.5 are equal.
+func (a Amount) Cmp(b Amount) int {
+	scale := max(a.scale, b.scale)
+	return a.rescale(scale).int().Cmp(b.rescale(scale).int())
+}
+
+// Add and Sub keep the larger scale of the two.
+func (a Amount) Add(b Amount) Amount {
+	scale := max(a.scale, b.scale)
+	return Amount{coef: new(big.Int).Add(a.rescale(scale).int(), b.rescale(scale).int()), scale: scale}
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	scale := max(a.scale, b.scale)
+	return Amount{coef: new(big.Int).Sub(a.rescale(scale).int(), b.rescale(scale).int()), scale: scale}
+}
+
+// Mul is exact; the scale is the sum of both scales.
+func (a Amount) Mul(b Amount) Amount {
+	return Amount{coef: new(big.Int).Mul(a.int(), b.int()), scale: a.scale + b.scale}
+}
+
+// Quo divides a by b, rounding half away from zero to scale digits after
+// the point. It panics when b is zero.
+func (a Amount) Quo(b Amount, scale int) Amount {
+	// a/b × 10^scale = a.coef × 10^(b.scale+scale-a.scale) / b.coef
+	num, den := new(big.Int).Set(a.int()), new(big.Int).Set(b.int())
+	if shift := b.scale + scale - a.scale; shift >= 0 {
+		num.Mul(num, new(big.Int).Exp(ten, big.NewInt(int64(shift)), nil))
+	} else {
+		den.Mul(den, new(big.Int).Exp(ten, big.NewInt(int64(-shift)), nil))
+	}
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 && new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign() == den.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return Amount{coef: q, scale: scale}
+}
+
+// Round rounds half away from zero to scale digits after the point; it
+// never adds digits.
+func (a Amount) Round(scale int) Amount {
+	if scale >= a.scale {
+		return a
+	}
+	return a.Quo(FromInt(1), scale)
+}
+
+// Sum adds amounts exactly; the sum of none is 0.
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, a := range amounts {
+		total = total.Add(a)
+	}
+	return total
+}
+
+// MarshalJSON writes the amount in the encoding chosen by SetJSONEncoding.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if JSONEncoding(jsonEncoding.Load()) == JSONStrings {
+		return []byte(`"` + a.String() + `"`), nil
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a number or a string holding one; null leaves the
+// amount unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value sends the amount as text, which Postgres reads into NUMERIC
+// without loss.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte, string:
+		parsed, err := Parse(fmt.Sprintf("%s", v))
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	case int64:
+		*a = FromInt(v)
+		return nil
+	default:
+		return fmt.Errorf("money: cannot scan %T into Amount", src)
+	}
+}
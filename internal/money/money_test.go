@@ -0,0 +1,153 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, c := range []struct {
+		in, want string
+		scale    int
+	}{
+		{"0", "0", 0},
+		{"1200.50", "1200.50", 2},
+		{"-0.05", "-0.05", 2},
+		{"0.000", "0.000", 3},
+		{"1.5e6", "1500000", 0},
+		{"1.5e-3", "0.0015", 4},
+		{"12E2", "1200", 0},
+		{"123456789012345678901234567890.12", "123456789012345678901234567890.12", 2},
+	} {
+		a, err := Parse(c.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", c.in, err)
+			continue
+		}
+		if a.String() != c.want || a.Scale() != c.scale {
+			t.Errorf("Parse(%q) = %s with scale %d, want %s with scale %d", c.in, a, a.Scale(), c.want, c.scale)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1.", ".5", "+1", "1,5", "1e", "1e101", "0x10", " 1"} {
+		if _, err := Parse(in); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q): %v, want ErrSyntax", in, err)
+		}
+	}
+}
+
+func TestQuo(t *testing.T) {
+	for _, c := range []struct {
+		a, b  string
+		scale int
+		want  string
+	}{
+		{"1", "3", 2, "0.33"},
+		{"2", "3", 2, "0.67"},
+		{"-2", "3", 2, "-0.67"},
+		{"2", "-3", 2, "-0.67"},
+		{"0.125", "1", 2, "0.13"},
+		{"-0.125", "1", 2, "-0.13"},
+		{"0.124", "1", 2, "0.12"},
+		{"10", "4", 0, "3"},
+		{"-10", "4", 0, "-3"},
+		{"1000", "0.5", 0, "2000"},
+		{"1", "8", 5, "0.12500"},
+	} {
+		if got := MustParse(c.a).Quo(MustParse(c.b), c.scale).String(); got != c.want {
+			t.Errorf("%s / %s to %d places = %s, want %s", c.a, c.b, c.scale, got, c.want)
+		}
+	}
+
+	if got := MustParse("2.345").Round(2).String(); got != "2.35" {
+		t.Errorf("Round(2.345, 2) = %s, want 2.35", got)
+	}
+	if got := MustParse("2.3").Round(2).String(); got != "2.3" {
+		t.Errorf("Round(2.3, 2) = %s, want 2.3 unchanged", got)
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	for _, src := range []interface{}{"1200.50", []byte("1200.50")} {
+		var a Amount
+		if err := a.Scan(src); err != nil || a.String() != "1200.50" {
+			t.Errorf("Scan(%#v) = %s, %v", src, a, err)
+		}
+		v, err := a.Value()
+		if err != nil || v != "1200.50" {
+			t.Errorf("Value() = %#v, %v; want the text it was scanned from", v, err)
+		}
+	}
+
+	var a Amount
+	if err := a.Scan(int64(42)); err != nil || a.String() != "42" {
+		t.Errorf("Scan(int64) = %s, %v", a, err)
+	}
+	if err := a.Scan(1.5); err == nil {
+		t.Error("Scan(float64) succeeded; floats are not exact")
+	}
+	if err := a.Scan("1,5"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Scan(invalid) = %v, want ErrSyntax", err)
+	}
+}
+
+type valuation struct {
+	NetWorth Amount  `json:"netWorth"`
+	Previous *Amount `json:"previous,omitempty"`
+}
+
+func TestJSON(t *testing.T) {
+	t.Cleanup(func() { SetJSONEncoding(JSONNumbers) })
+
+	for _, c := range []struct {
+		encoding JSONEncoding
+		want     string
+	}{
+		{JSONNumbers, `{"netWorth":1234567890123456789.10,"previous":-0.050}`},
+		{JSONStrings, `{"netWorth":"1234567890123456789.10","previous":"-0.050"}`},
+	} {
+		SetJSONEncoding(c.encoding)
+		previous := MustParse("-0.050")
+		out, err := json.Marshal(valuation{NetWorth: MustParse("1234567890123456789.10"), Previous: &previous})
+		if err != nil || string(out) != c.want {
+			t.Errorf("encoding %d: %s, %v; want %s", c.encoding, out, err, c.want)
+			continue
+		}
+
+		// Whatever the encoding, amounts come back with the same digits
+		// and encode to the same bytes again.
+		var back valuation
+		if err := json.Unmarshal(out, &back); err != nil {
+			t.Fatalf("decode %s: %v", out, err)
+		}
+		again, err := json.Marshal(back)
+		if err != nil || string(again) != string(out) {
+			t.Errorf("round trip of %s = %s, %v", out, again, err)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for in, want := range map[string]string{
+		`1200.50`:   "1200.50",
+		`"1200.50"`: "1200.50",
+		`1.5e3`:     "1500",
+		`"-7"`:      "-7",
+	} {
+		var a Amount
+		if err := json.Unmarshal([]byte(in), &a); err != nil || a.String() != want {
+			t.Errorf("decode %s = %s, %v; want %s", in, a, err, want)
+		}
+	}
+
+	a := MustParse("1")
+	if err := json.Unmarshal([]byte(`null`), &a); err != nil || a.String() != "1" {
+		t.Errorf("decode null = %s, %v; want the amount unchanged", a, err)
+	}
+	for _, in := range []string{`"abc"`, `true`, `"1.2.3"`} {
+		if err := json.Unmarshal([]byte(in), &a); err == nil {
+			t.Errorf("decode %s succeeded", in)
+		}
+	}
+}
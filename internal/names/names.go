@@ -0,0 +1,145 @@
+// Package names compares personal names written in different scripts and
+// spellings, e.g. "Назарбаев", "Nazarbayev" and "Nazarbaev".
+package names
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cyrillic transliterates Russian, Kazakh and Ukrainian letters.
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+	'ә': "a", 'ғ': "g", 'қ': "k", 'ң': "n", 'ө': "o", 'ұ': "u", 'ү': "u", 'һ': "h",
+	'і': "i", 'є': "ye", 'ї': "yi", 'ґ': "g",
+}
+
+// latin folds accented Latin letters to plain ones.
+var latin = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ä': "a", 'ã': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'č': "c", 'ć': "c",
+	'ď': "d", 'đ': "d", 'ð': "d",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ě': "e", 'ę': "e",
+	'ğ': "g",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'ł': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ó': "o", 'ò': "o", 'ô': "o", 'ö': "o", 'õ': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'ř': "r",
+	'š': "s", 'ś': "s", 'ş': "s", 'ß': "ss",
+	'ť': "t", 'ţ': "t",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y",
+	'ž': "z", 'ź': "z", 'ż': "z",
+	'æ': "ae", 'œ': "oe",
+}
+
+// spelling folds letter groups that transliteration schemes disagree on,
+// longest first.
+var spelling = strings.NewReplacer(
+	"shch", "sh", "dzh", "j",
+	"kh", "h", "zh", "j", "ts", "c", "tz", "c", "ph", "f", "ck", "k",
+	"iy", "i", "yi", "i", "ye", "e", "yo", "o", "yu", "u", "ya", "a",
+	"y", "i", "w", "v", "q", "k", "x", "ks",
+)
+
+// Normalize lowercases s, transliterates it to Latin letters without
+// accents and folds common spelling variants, so that different spellings
+// of a name normalize alike. Words are separated by single spaces; other
+// characters are dropped.
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case cyrillic[r] != "" || r == 'ъ' || r == 'ь':
+			b.WriteString(cyrillic[r])
+		case latin[r] != "":
+			b.WriteString(latin[r])
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case unicode.IsLetter(r):
+			// A script we do not transliterate is kept as is.
+			b.WriteRune(r)
+		default:
+			b.WriteByte(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	for i, w := range words {
+		words[i] = squeeze(spelling.Replace(w))
+	}
+	return strings.Join(words, " ")
+}
+
+// squeeze collapses runs of the same letter, e.g. "abramovvich".
+func squeeze(w string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range w {
+		if r != prev {
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// Similarity returns the Jaro-Winkler similarity of a and b: 1 for equal
+// strings, 0 for strings with nothing in common.
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if !tMatched[j] && s[i] == t[j] {
+				sMatched[i], tMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s), len(t)) && s[prefix] == t[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,234 @@
+// Package publicurl builds the absolute URLs clients use to reach the API.
+// Behind a reverse proxy these differ from the address the server sees, so
+// they come from a configured public URL or from the forwarding headers of
+// trusted proxies. Photo URLs may also be signed, so that they expire.
+package publicurl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PhotoPath is where photos are served, see PhotoHandler.GetPhoto.
+const PhotoPath = "/api/photo/"
+
+var validHost = regexp.MustCompile(`^([A-Za-z0-9.-]+|\[[0-9A-Fa-f:.]+\])(:[0-9]{1,5})?$`)
+
+type Builder struct {
+	base    string
+	trusted []netip.Prefix
+	signer  *Signer
+}
+
+// New returns a builder that uses publicURL (e.g. https://example.com/wealth)
+// when set. Otherwise the scheme and host are those of the request, taken
+// from the Forwarded or X-Forwarded-Proto and X-Forwarded-Host headers when
+// the request comes from one of trustedProxies (addresses or CIDR ranges).
+func New(publicURL string, trustedProxies []string) (*Builder, error) {
+	b := &Builder{}
+	if publicURL != "" {
+		if err := checkPublicURL(publicURL); err != nil {
+			return nil, err
+		}
+		b.base = strings.TrimSuffix(publicURL, "/")
+	}
+	for _, p := range trustedProxies {
+		prefix, err := parseProxy(p)
+		if err != nil {
+			return nil, err
+		}
+		b.trusted = append(b.trusted, prefix)
+	}
+	return b, nil
+}
+
+// checkPublicURL accepts absolute http and https URLs without query or
+// fragment.
+func checkPublicURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute http or https URL", s)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("%q must not have credentials, a query or a fragment", s)
+	}
+	return nil
+}
+
+// parseProxy parses an address or a CIDR range.
+func parseProxy(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// SignPhotos makes the builder's photo URLs signed with secret, see
+// NewSigner.
+func (b *Builder) SignPhotos(secret string, ttl time.Duration) {
+	b.signer = NewSigner(secret, ttl)
+}
+
+// Signer returns the signer of photo URLs, nil when they are not signed.
+func (b *Builder) Signer() *Signer {
+	return b.signer
+}
+
+// TrustedProxies returns the trusted proxies as CIDR ranges.
+func (b *Builder) TrustedProxies() []string {
+	proxies := make([]string, len(b.trusted))
+	for i, p := range b.trusted {
+		proxies[i] = p.String()
+	}
+	return proxies
+}
+
+// Base returns the URL the client sent r to, without the path, e.g.
+// https://example.com.
+func (b *Builder) Base(r *http.Request) string {
+	if b.base != "" {
+		return b.base
+	}
+
+	scheme, host := "http", r.Host
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if b.trustedPeer(r) {
+		proto, fwdHost := forwarded(r.Header)
+		if proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if validHost.MatchString(fwdHost) {
+			host = fwdHost
+		}
+	}
+	return scheme + "://" + host
+}
+
+func (b *Builder) trustedPeer(r *http.Request) bool {
+	if len(b.trusted) == 0 {
+		return false
+	}
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, p := range b.trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwarded reads the protocol and host the first proxy received, from
+// Forwarded (RFC 7239) or else the X-Forwarded-* headers.
+func forwarded(h http.Header) (proto, host string) {
+	if v := h.Get("Forwarded"); v != "" {
+		first, _, _ := strings.Cut(v, ",")
+		for _, pair := range strings.Split(first, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				continue
+			}
+			value = strings.Trim(value, `"`)
+			switch strings.ToLower(name) {
+			case "proto":
+				proto = strings.ToLower(value)
+			case "host":
+				host = value
+			}
+		}
+		return proto, host
+	}
+
+	proto, _, _ = strings.Cut(h.Get("X-Forwarded-Proto"), ",")
+	host, _, _ = strings.Cut(h.Get("X-Forwarded-Host"), ",")
+	return strings.ToLower(strings.TrimSpace(proto)), strings.TrimSpace(host)
+}
+
+type baseKey struct{}
+
+// WithBase returns a context carrying the base URL of the request.
+func WithBase(ctx context.Context, base string) context.Context {
+	return context.WithValue(ctx, baseKey{}, base)
+}
+
+// BaseFrom returns the base URL stored by WithBase, or "".
+func BaseFrom(ctx context.Context) string {
+	base, _ := ctx.Value(baseKey{}).(string)
+	return base
+}
+
+// Photo returns the URL of a stored photo, absolute when ctx carries a base
+// URL and signed when it carries a Signer. URLs stored instead of a path are
+// returned unchanged.
+func Photo(ctx context.Context, path string) string {
+	if external(path) {
+		return path
+	}
+	return photoURL(ctx, path, nil)
+}
+
+// Thumbnail returns the URL of the thumbnail of a stored photo, like Photo.
+// URLs stored instead of a path have none, and Thumbnail returns "".
+func Thumbnail(ctx context.Context, path string) string {
+	if external(path) {
+		return ""
+	}
+	return photoURL(ctx, path, url.Values{"size": {"thumb"}})
+}
+
+func external(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+func photoURL(ctx context.Context, path string, query url.Values) string {
+	name := filepath.Base(path)
+	for k, v := range SignerFrom(ctx).sign(name) {
+		if query == nil {
+			query = url.Values{}
+		}
+		query[k] = v
+	}
+	u := BaseFrom(ctx) + PhotoPath + url.PathEscape(name)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// PhotoName returns the name of the photo a URL made by Photo points to,
+// so that a URL sent back by a client can be stored as a path again.
+func PhotoName(photoURL string) (string, bool) {
+	u, err := url.Parse(photoURL)
+	if err != nil {
+		return "", false
+	}
+	i := strings.LastIndex(u.Path, PhotoPath)
+	if i < 0 {
+		return "", false
+	}
+	name := u.Path[i+len(PhotoPath):]
+	if name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	return name, true
+}
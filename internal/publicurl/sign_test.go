@@ -0,0 +1,85 @@
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+	s := NewSigner("0123456789abcdef0123456789abcdef", time.Hour)
+	s.now = func() time.Time { return now }
+
+	query := s.sign("1_abc.jpg")
+	until, err := s.Verify("1_abc.jpg", query)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if want := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC); !until.Equal(want) {
+		t.Errorf("expires %v, want %v: the hour rounded up and another", until, want)
+	}
+
+	// Within the hour the URL stays the same.
+	now = now.Add(29 * time.Minute)
+	if again := s.sign("1_abc.jpg"); again.Encode() != query.Encode() {
+		t.Errorf("signed again: %s, want %s", again.Encode(), query.Encode())
+	}
+
+	for _, c := range []struct {
+		what  string
+		name  string
+		query url.Values
+		want  error
+	}{
+		{"unsigned", "1_abc.jpg", nil, ErrUnsigned},
+		{"another photo", "2_abc.jpg", query, ErrBadSignature},
+		{"another expiry", "1_abc.jpg", url.Values{"expires": {"4102444800"}, "signature": query["signature"]}, ErrBadSignature},
+		{"another key", "1_abc.jpg", NewSigner(strings.Repeat("x", 32), time.Hour).sign("1_abc.jpg"), ErrBadSignature},
+	} {
+		if _, err := s.Verify(c.name, c.query); !errors.Is(err, c.want) {
+			t.Errorf("%s: %v, want %v", c.what, err, c.want)
+		}
+	}
+
+	now = until
+	if _, err := s.Verify("1_abc.jpg", query); !errors.Is(err, ErrExpired) {
+		t.Errorf("at expiry: %v, want ErrExpired", err)
+	}
+
+	var unsigned *Signer
+	if _, err := unsigned.Verify("1_abc.jpg", nil); err != nil {
+		t.Errorf("nil signer: %v, want any URL accepted", err)
+	}
+}
+
+func TestPhotoURLs(t *testing.T) {
+	ctx := WithBase(context.Background(), "https://example.com/wealth")
+	if got, want := Photo(ctx, "uploads/photos/1_abc.jpg"), "https://example.com/wealth/api/photo/1_abc.jpg"; got != want {
+		t.Errorf("photo %q, want %q", got, want)
+	}
+	if got, want := Thumbnail(ctx, "uploads/photos/1_abc.jpg"), "https://example.com/wealth/api/photo/1_abc.jpg?size=thumb"; got != want {
+		t.Errorf("thumbnail %q, want %q", got, want)
+	}
+	if got := Thumbnail(ctx, "https://cdn.example.com/1.jpg"); got != "" {
+		t.Errorf("thumbnail of an external URL: %q, want none", got)
+	}
+
+	s := NewSigner("0123456789abcdef0123456789abcdef", time.Hour)
+	u, err := url.Parse(Thumbnail(WithSigner(ctx, s), "uploads/photos/1_abc.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Query().Get("size") != "thumb" {
+		t.Errorf("signed thumbnail %s lost its size", u)
+	}
+	if name, ok := PhotoName(u.String()); !ok || name != "1_abc.jpg" {
+		t.Errorf("name of %s: %q", u, name)
+	}
+	if _, err := s.Verify("1_abc.jpg", u.Query()); err != nil {
+		t.Errorf("verify %s: %v", u, err)
+	}
+}
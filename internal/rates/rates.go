@@ -0,0 +1,129 @@
+// Package rates reads exchange rate files for the rates import command:
+// CSV with date, currency and rate columns, or the ECB's eurofxref XML.
+// Rates are units of the currency per euro either way.
+package rates
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatXML = "xml"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// FormatOf picks the format from a file extension, defaulting to CSV.
+func FormatOf(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		return FormatXML
+	}
+	return FormatCSV
+}
+
+// Read decodes and checks exchange rates. Errors name the offending CSV line
+// or XML day.
+func Read(r io.Reader, format string) ([]models.ExchangeRate, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatXML:
+		return readXML(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q, use %s or %s", format, FormatCSV, FormatXML)
+	}
+}
+
+func readCSV(r io.Reader) ([]models.ExchangeRate, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.TrimSpace(h)] = i
+	}
+	for _, required := range []string{"date", "currency", "rate"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("header: missing column %s", required)
+		}
+	}
+
+	var rates []models.ExchangeRate
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string { return strings.TrimSpace(record[index[name]]) }
+		rate, err := parse(field("date"), field("currency"), field("rate"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// ecbEnvelope is the layout of eurofxref-daily.xml and eurofxref-hist.xml:
+// one Cube per day holding one Cube per currency.
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+func readXML(r io.Reader) ([]models.ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, err
+	}
+
+	var rates []models.ExchangeRate
+	for _, day := range envelope.Days {
+		for _, c := range day.Rates {
+			rate, err := parse(day.Time, c.Currency, c.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", day.Time, err)
+			}
+			rates = append(rates, rate)
+		}
+	}
+	return rates, nil
+}
+
+func parse(date, currency, rate string) (models.ExchangeRate, error) {
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return models.ExchangeRate{}, fmt.Errorf("invalid date %q", date)
+	}
+	currency = strings.ToUpper(currency)
+	if !currencyCode.MatchString(currency) || currency == "EUR" {
+		return models.ExchangeRate{}, fmt.Errorf("invalid currency %q", currency)
+	}
+	v, err := money.Parse(rate)
+	if err != nil || v.Sign() <= 0 {
+		return models.ExchangeRate{}, fmt.Errorf("invalid rate %q", rate)
+	}
+	return models.ExchangeRate{Currency: currency, Date: date, Rate: v}, nil
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+)
+
+type AuditRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+// NewAuditRepo sends everything to conn's writer.
+func NewAuditRepo(conn Conn, log *slog.Logger) *AuditRepo {
+	return &AuditRepo{conn: conn, log: log}
+}
+
+// Add records e and fills in its ID and creation time.
+func (r *AuditRepo) Add(ctx context.Context, e *models.AuditEntry) error {
+	defer metrics.ObserveQuery("audit", "Add", time.Now())
+	query := `
+		INSERT INTO audit_log (action, actor, request_id, details)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	ctx, span := tracing.StartQuery(ctx, "AuditRepo.Add", "INSERT", query)
+	defer span.End()
+
+	details := string(e.Details)
+	if details == "" {
+		details = "{}"
+	}
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query, e.Action, e.Actor, e.RequestID, details).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to add audit entry", slog.String("action", e.Action), logger.Err(err))
+	}
+	return err
+}
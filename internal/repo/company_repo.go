@@ -0,0 +1,246 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrCompanyExists      = errors.New("company with this legal ID already exists")
+	ErrCompanyHasHoldings = errors.New("company still has holdings")
+)
+
+// foreignKeyViolation is the PostgreSQL error code for a missing or still
+// referenced row.
+const foreignKeyViolation = "23503"
+
+const companyQuery = `SELECT id, name, legal_id, country, industry, ticker, to_char(founded, 'YYYY-MM-DD'), website, created_at, updated_at FROM companies`
+
+// Companies stores companies. CompanyRepo keeps them in Postgres; the
+// memory version is for tests.
+type Companies interface {
+	// Create returns ErrCompanyExists for a legal ID already in use.
+	Create(ctx context.Context, c *models.Company) error
+	GetByID(ctx context.Context, id int) (*models.Company, error)
+	List(ctx context.Context, name string, page, pageSize int) (models.PaginationCompanyDto, error)
+	Update(ctx context.Context, c *models.Company) error
+	// Delete returns ErrCompanyHasHoldings while holdings refer to the
+	// company.
+	Delete(ctx context.Context, id int) error
+	People(ctx context.Context, companyID int) ([]models.CompanyPerson, error)
+}
+
+var _ Companies = (*CompanyRepo)(nil)
+
+type CompanyRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+// NewCompanyRepo sends GetByID, List and People to conn's reader and
+// everything else to its writer.
+func NewCompanyRepo(conn Conn, log *slog.Logger) *CompanyRepo {
+	return &CompanyRepo{conn: conn, log: log}
+}
+
+func scanCompany(row interface{ Scan(...interface{}) error }, c *models.Company) error {
+	return row.Scan(
+		&c.ID, &c.Name, &c.LegalID, &c.Country, &c.Industry,
+		&c.Ticker, &c.Founded, &c.Website, &c.CreatedAt, &c.UpdatedAt,
+	)
+}
+
+func (r *CompanyRepo) Create(ctx context.Context, c *models.Company) error {
+	defer metrics.ObserveQuery("company", "Create", time.Now())
+	query := `
+		INSERT INTO companies (name, legal_id, country, industry, ticker, founded, website, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	ctx, span := tracing.StartQuery(ctx, "CompanyRepo.Create", "INSERT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query,
+		c.Name, c.LegalID, c.Country, c.Industry, c.Ticker, c.Founded, c.Website,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrCompanyExists
+		}
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to create company", logger.Err(err))
+	}
+	return err
+}
+
+// GetByID returns sql.ErrNoRows for an unknown ID.
+func (r *CompanyRepo) GetByID(ctx context.Context, id int) (*models.Company, error) {
+	defer metrics.ObserveQuery("company", "GetByID", time.Now())
+	query := companyQuery + " WHERE id = $1"
+
+	ctx, span := tracing.StartQuery(ctx, "CompanyRepo.GetByID", "SELECT", query)
+	defer span.End()
+
+	c := &models.Company{}
+	err := scanCompany(r.conn.Reader(ctx).QueryRowContext(ctx, query, id), c)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			tracing.RecordError(span, err)
+			r.log.ErrorContext(ctx, "Failed to fetch company", slog.Int("id", id), logger.Err(err))
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// List returns a page of companies ordered by name. A non-empty name keeps
+// only companies whose name contains it, ignoring case.
+func (r *CompanyRepo) List(ctx context.Context, name string, page, pageSize int) (models.PaginationCompanyDto, error) {
+	defer metrics.ObserveQuery("company", "List", time.Now())
+	result := models.PaginationCompanyDto{Page: page, PageSize: pageSize}
+
+	where, args := "", []interface{}{}
+	if name != "" {
+		where, args = " WHERE name ILIKE $1", []interface{}{"%" + name + "%"}
+	}
+	query := companyQuery + where + fmt.Sprintf(" ORDER BY lower(name), id LIMIT %d OFFSET %d", pageSize, (page-1)*pageSize)
+
+	ctx, span := tracing.StartQuery(ctx, "CompanyRepo.List", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list companies", logger.Err(err))
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.Company
+		if err := scanCompany(rows, &c); err != nil {
+			tracing.RecordError(span, err)
+			return result, err
+		}
+		result.Companies = append(result.Companies, c)
+	}
+	if err := rows.Err(); err != nil {
+		tracing.RecordError(span, err)
+		return result, err
+	}
+
+	err = r.conn.Reader(ctx).QueryRowContext(ctx, "SELECT COUNT(*) FROM companies"+where, args...).Scan(&result.Total)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to count companies", logger.Err(err))
+	}
+	return result, err
+}
+
+// Update returns sql.ErrNoRows for an unknown ID.
+func (r *CompanyRepo) Update(ctx context.Context, c *models.Company) error {
+	defer metrics.ObserveQuery("company", "Update", time.Now())
+	query := `
+		UPDATE companies
+		SET name = $1, legal_id = $2, country = $3, industry = $4,
+		    ticker = $5, founded = $6, website = $7, updated_at = NOW()
+		WHERE id = $8`
+
+	ctx, span := tracing.StartQuery(ctx, "CompanyRepo.Update", "UPDATE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query,
+		c.Name, c.LegalID, c.Country, c.Industry, c.Ticker, c.Founded, c.Website, c.ID,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrCompanyExists
+		}
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to update company", slog.Int("id", c.ID), logger.Err(err))
+		return err
+	}
+	return requireRow(res)
+}
+
+// Delete returns sql.ErrNoRows for an unknown ID and ErrCompanyHasHoldings
+// while holdings still refer to the company.
+func (r *CompanyRepo) Delete(ctx context.Context, id int) error {
+	defer metrics.ObserveQuery("company", "Delete", time.Now())
+	query := "DELETE FROM companies WHERE id = $1"
+
+	ctx, span := tracing.StartQuery(ctx, "CompanyRepo.Delete", "DELETE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return ErrCompanyHasHoldings
+		}
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to delete company", slog.Int("id", id), logger.Err(err))
+		return err
+	}
+	return requireRow(res)
+}
+
+// People returns the holdings in a company with the names of their
+// holders, current holdings first and the most valuable first among them.
+func (r *CompanyRepo) People(ctx context.Context, companyID int) ([]models.CompanyPerson, error) {
+	defer metrics.ObserveQuery("company", "People", time.Now())
+	query := `
+		SELECT ` + holdingColumns + `, m.first_name, m.last_name
+		FROM holdings h
+		JOIN companies c ON c.id = h.company_id
+		JOIN millionaires m ON m.id = h.millionaire_id
+		WHERE h.company_id = $1
+		ORDER BY (h.ended_on IS NULL OR h.ended_on >= CURRENT_DATE) DESC, h.value DESC NULLS LAST, h.id`
+
+	ctx, span := tracing.StartQuery(ctx, "CompanyRepo.People", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, companyID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list company people", slog.Int("companyId", companyID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var people []models.CompanyPerson
+	for rows.Next() {
+		var p models.CompanyPerson
+		if err := rows.Scan(append(holdingTargets(&p.Holding), &p.FirstName, &p.LastName)...); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		people = append(people, p)
+	}
+	return people, rows.Err()
+}
+
+// requireRow turns an UPDATE or DELETE that matched nothing into
+// sql.ErrNoRows.
+func requireRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+	"wealthlist/internal/metrics"
+)
+
+// Querier is the part of *sql.DB and *sql.Tx that repositories use.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Conn tells a repository where to send statements: mutations go to
+// Writer, read-only queries may go to Reader.
+type Conn interface {
+	Writer(ctx context.Context) Querier
+	Reader(ctx context.Context) Querier
+}
+
+type primaryKey struct{}
+
+// WithPrimary makes reads in ctx go to the primary, so a request sees its
+// own writes despite replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether WithPrimary was applied to ctx.
+func UsesPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
+
+type direct struct {
+	db *sql.DB
+}
+
+// Direct sends every statement to db.
+func Direct(db *sql.DB) Conn {
+	return direct{db: db}
+}
+
+func (d direct) Writer(context.Context) Querier { return d.db }
+func (d direct) Reader(context.Context) Querier { return d.db }
+
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// ClusterOptions tune replica health checks. A replica is taken out of
+// rotation when it cannot be reached within CheckTimeout or, if MaxLag is
+// set, when it replays the primary's changes more than MaxLag behind.
+type ClusterOptions struct {
+	CheckTimeout time.Duration
+	MaxLag       time.Duration
+}
+
+// Cluster routes reads round-robin across healthy replicas and falls back to
+// the primary when none is healthy.
+type Cluster struct {
+	primary  *sql.DB
+	replicas []*replica
+	opts     ClusterOptions
+	next     atomic.Uint64
+	log      *slog.Logger
+}
+
+// NewCluster starts with every replica out of rotation; call CheckReplicas
+// or Monitor to bring them in.
+func NewCluster(primary *sql.DB, replicas []*sql.DB, opts ClusterOptions, log *slog.Logger) *Cluster {
+	c := &Cluster{primary: primary, opts: opts, log: log}
+	for i, db := range replicas {
+		c.replicas = append(c.replicas, &replica{name: fmt.Sprintf("replica-%d", i+1), db: db})
+	}
+	return c
+}
+
+func (c *Cluster) Writer(context.Context) Querier {
+	return c.primary
+}
+
+func (c *Cluster) Reader(ctx context.Context) Querier {
+	if len(c.replicas) == 0 || UsesPrimary(ctx) {
+		return c.primary
+	}
+
+	start := c.next.Add(1)
+	for i := range c.replicas {
+		r := c.replicas[(start+uint64(i))%uint64(len(c.replicas))]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return c.primary
+}
+
+// CheckReplicas pings every replica and updates its place in the rotation.
+func (c *Cluster) CheckReplicas(ctx context.Context) {
+	for _, r := range c.replicas {
+		err := c.checkReplica(ctx, r)
+		healthy := err == nil
+
+		if was := r.healthy.Swap(healthy); was != healthy {
+			if healthy {
+				c.log.InfoContext(ctx, "Replica back in rotation", slog.String("replica", r.name))
+			} else {
+				c.log.WarnContext(ctx, "Replica out of rotation", slog.String("replica", r.name), slog.String("error", err.Error()))
+			}
+		}
+		metrics.SetReplicaHealthy(r.name, healthy)
+	}
+}
+
+func (c *Cluster) checkReplica(ctx context.Context, r *replica) error {
+	if c.opts.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.CheckTimeout)
+		defer cancel()
+	}
+
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+	if c.opts.MaxLag <= 0 {
+		return nil
+	}
+
+	var lagSeconds float64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT CASE WHEN pg_is_in_recovery()
+			THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+			ELSE 0 END`).Scan(&lagSeconds)
+	if err != nil {
+		return err
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	if lag > c.opts.MaxLag {
+		return fmt.Errorf("replication lag %s exceeds %s", lag.Round(time.Millisecond), c.opts.MaxLag)
+	}
+	return nil
+}
+
+// Monitor runs CheckReplicas every interval until ctx is done.
+func (c *Cluster) Monitor(ctx context.Context, interval time.Duration) {
+	if len(c.replicas) == 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CheckReplicas(ctx)
+		}
+	}
+}
+
+// Close closes the replica pools. The primary belongs to the caller.
+func (c *Cluster) Close() error {
+	var errs []error
+	for _, r := range c.replicas {
+		errs = append(errs, r.db.Close())
+	}
+	return errors.Join(errs...)
+}
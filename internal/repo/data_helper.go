@@ -1,24 +1,53 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"wealthlist/internal/models"
+
+	"github.com/lib/pq"
 )
 
-func (r *millionaireRepo) ScanRows(rows *sql.Rows) ([]models.Millionaire, error) {
+// millionaireColumns is the column list of baseQuery; scanTargets returns
+// the matching destinations.
+const millionaireColumns = `id, last_name, first_name, middle_name, birth_date, birth_place, company,
+	net_worth, net_worth_currency, to_char(net_worth_as_of, 'YYYY-MM-DD'), industry, country,
+	country_code, industry_code,
+	biography, education, citizenships, marital_status, children_count, residence_city, website, social_handles,
+	path_to_photo, created_at, updated_at`
+
+// listColumns are millionaireColumns with NULL for the biography and
+// education, which list views leave out; they scan into the same targets.
+const listColumns = `id, last_name, first_name, middle_name, birth_date, birth_place, company,
+	net_worth, net_worth_currency, to_char(net_worth_as_of, 'YYYY-MM-DD'), industry, country,
+	country_code, industry_code,
+	NULL, NULL, citizenships, marital_status, children_count, residence_city, website, social_handles,
+	path_to_photo, created_at, updated_at`
+
+func scanTargets(m *models.Millionaire) []interface{} {
+	return []interface{}{
+		&m.ID, &m.LastName, &m.FirstName, &m.MiddleName,
+		&m.BirthDate, &m.BirthPlace, &m.Company,
+		&m.NetWorth, &m.NetWorthCurrency, &m.NetWorthAsOf,
+		&m.Industry, &m.Country, &m.CountryCode, &m.IndustryCode,
+		&m.Biography, &m.Education, pq.Array(&m.Citizenships), &m.MaritalStatus,
+		&m.ChildrenCount, &m.ResidenceCity, &m.Website, jsonObject{&m.SocialHandles},
+		&m.PathToPhoto, &m.CreatedAt, &m.UpdatedAt,
+	}
+}
+
+func (r *millionaireRepo) ScanRows(ctx context.Context, rows *sql.Rows) ([]models.Millionaire, error) {
 
 	var millionaires []models.Millionaire
 	for rows.Next() {
 		var m models.Millionaire
-		err := rows.Scan(
-			&m.ID, &m.LastName, &m.FirstName, &m.MiddleName,
-			&m.BirthDate, &m.BirthPlace, &m.Company, &m.NetWorth,
-			&m.Industry, &m.Country, &m.PathToPhoto,
-			&m.CreatedAt, &m.UpdatedAt,
-		)
+		err := rows.Scan(scanTargets(&m)...)
 		if err != nil {
-			r.log.Error("Error scanning row", slog.String("error", err.Error()))
+			r.log.ErrorContext(ctx, "Error scanning row", slog.String("error", err.Error()))
 			return nil, err
 		}
 		millionaires = append(millionaires, m)
@@ -26,3 +55,30 @@ func (r *millionaireRepo) ScanRows(rows *sql.Rows) ([]models.Millionaire, error)
 
 	return millionaires, rows.Err()
 }
+
+// jsonObject reads and writes a map as a JSONB column; an empty map is
+// stored as NULL.
+type jsonObject struct {
+	m *map[string]string
+}
+
+func (j jsonObject) Value() (driver.Value, error) {
+	if len(*j.m) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(*j.m)
+}
+
+func (j jsonObject) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*j.m = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, j.m)
+	case string:
+		return json.Unmarshal([]byte(v), j.m)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON object", src)
+	}
+}
@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+
+	"github.com/lib/pq"
+)
+
+// History keeps each millionaire's net worth by day.
+type History interface {
+	// Add records points; days that already have a value keep it.
+	Add(ctx context.Context, millionaireID int, points []models.NetWorthPoint) error
+	// Record sets the value of one day, replacing what was there.
+	Record(ctx context.Context, millionaireID int, p models.NetWorthPoint) error
+	// Copy adds the history of from to that of to. On days both have a
+	// value, from's replaces to's when overwrite is set.
+	Copy(ctx context.Context, from, to int, overwrite bool) error
+	// List returns a millionaire's history, oldest first.
+	List(ctx context.Context, millionaireID int) ([]models.NetWorthPoint, error)
+}
+
+type HistoryRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+var _ History = (*HistoryRepo)(nil)
+
+// NewHistoryRepo sends List to conn's reader and everything else to its
+// writer.
+func NewHistoryRepo(conn Conn, log *slog.Logger) *HistoryRepo {
+	return &HistoryRepo{conn: conn, log: log}
+}
+
+// Add records net worth points in one statement. Days that already have a
+// value keep it.
+func (r *HistoryRepo) Add(ctx context.Context, millionaireID int, points []models.NetWorthPoint) error {
+	defer metrics.ObserveQuery("history", "Add", time.Now())
+	query := `
+		INSERT INTO net_worth_history (millionaire_id, recorded_on, net_worth, currency)
+		SELECT $1, unnest($2::date[]), unnest($3::numeric[]), unnest($4::char(3)[])
+		ON CONFLICT (millionaire_id, recorded_on) DO NOTHING`
+
+	ctx, span := tracing.StartQuery(ctx, "HistoryRepo.Add", "INSERT", query)
+	defer span.End()
+
+	dates := make([]string, len(points))
+	values := make([]string, len(points))
+	currencies := make([]string, len(points))
+	for i, p := range points {
+		dates[i] = p.Date
+		values[i] = p.NetWorth.String()
+		currencies[i] = p.Currency
+	}
+
+	_, err := r.conn.Writer(ctx).ExecContext(ctx, query, millionaireID, pq.Array(dates), pq.Array(values), pq.Array(currencies))
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to add net worth history", slog.Int("millionaireId", millionaireID), logger.Err(err))
+	}
+	return err
+}
+
+func (r *HistoryRepo) Record(ctx context.Context, millionaireID int, p models.NetWorthPoint) error {
+	defer metrics.ObserveQuery("history", "Record", time.Now())
+	query := `
+		INSERT INTO net_worth_history (millionaire_id, recorded_on, net_worth, currency)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (millionaire_id, recorded_on) DO UPDATE SET net_worth = EXCLUDED.net_worth, currency = EXCLUDED.currency`
+
+	ctx, span := tracing.StartQuery(ctx, "HistoryRepo.Record", "INSERT", query)
+	defer span.End()
+
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query, millionaireID, p.Date, p.NetWorth, p.Currency); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to record net worth", slog.Int("millionaireId", millionaireID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *HistoryRepo) Copy(ctx context.Context, from, to int, overwrite bool) error {
+	defer metrics.ObserveQuery("history", "Copy", time.Now())
+	conflict := "DO NOTHING"
+	if overwrite {
+		conflict = "DO UPDATE SET net_worth = EXCLUDED.net_worth, currency = EXCLUDED.currency"
+	}
+	query := `
+		INSERT INTO net_worth_history (millionaire_id, recorded_on, net_worth, currency)
+		SELECT $2, recorded_on, net_worth, currency
+		FROM net_worth_history
+		WHERE millionaire_id = $1
+		ON CONFLICT (millionaire_id, recorded_on) ` + conflict
+
+	ctx, span := tracing.StartQuery(ctx, "HistoryRepo.Copy", "INSERT", query)
+	defer span.End()
+
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query, from, to); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to copy net worth history", slog.Int("from", from), slog.Int("to", to), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// List returns a millionaire's net worth history, oldest first.
+func (r *HistoryRepo) List(ctx context.Context, millionaireID int) ([]models.NetWorthPoint, error) {
+	defer metrics.ObserveQuery("history", "List", time.Now())
+	query := `
+		SELECT to_char(recorded_on, 'YYYY-MM-DD'), net_worth, currency
+		FROM net_worth_history
+		WHERE millionaire_id = $1
+		ORDER BY recorded_on`
+
+	ctx, span := tracing.StartQuery(ctx, "HistoryRepo.List", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, millionaireID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list net worth history", slog.Int("millionaireId", millionaireID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []models.NetWorthPoint
+	for rows.Next() {
+		var p models.NetWorthPoint
+		if err := rows.Scan(&p.Date, &p.NetWorth, &p.Currency); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
@@ -0,0 +1,162 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+)
+
+// holdingColumns selects a holding joined with its company as h and c.
+const holdingColumns = `h.id, h.millionaire_id, h.company_id, c.name, h.role, h.stake_percent, h.value,
+	to_char(h.started_on, 'YYYY-MM-DD'), to_char(h.ended_on, 'YYYY-MM-DD')`
+
+func holdingTargets(h *models.Holding) []interface{} {
+	return []interface{}{
+		&h.ID, &h.MillionaireID, &h.CompanyID, &h.CompanyName, &h.Role,
+		&h.StakePercent, &h.Value, &h.StartedOn, &h.EndedOn,
+	}
+}
+
+// Holdings stores the holdings of millionaires in companies. Holdings
+// listed carry their company's name.
+type Holdings interface {
+	Add(ctx context.Context, h *models.Holding) error
+	Update(ctx context.Context, h *models.Holding) error
+	Delete(ctx context.Context, millionaireID, id int) error
+	Move(ctx context.Context, from, to int) error
+	List(ctx context.Context, millionaireID int, currentOnly bool) ([]models.Holding, error)
+}
+
+var _ Holdings = (*HoldingRepo)(nil)
+
+type HoldingRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+// NewHoldingRepo sends List to conn's reader and everything else to its
+// writer.
+func NewHoldingRepo(conn Conn, log *slog.Logger) *HoldingRepo {
+	return &HoldingRepo{conn: conn, log: log}
+}
+
+func (r *HoldingRepo) Add(ctx context.Context, h *models.Holding) error {
+	defer metrics.ObserveQuery("holding", "Add", time.Now())
+	query := `
+		INSERT INTO holdings (millionaire_id, company_id, role, stake_percent, value, started_on, ended_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	ctx, span := tracing.StartQuery(ctx, "HoldingRepo.Add", "INSERT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query,
+		h.MillionaireID, h.CompanyID, h.Role, h.StakePercent, h.Value, h.StartedOn, h.EndedOn,
+	).Scan(&h.ID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to add holding", slog.Int("millionaireId", h.MillionaireID), logger.Err(err))
+	}
+	return err
+}
+
+// Update returns sql.ErrNoRows unless the holding belongs to
+// h.MillionaireID.
+func (r *HoldingRepo) Update(ctx context.Context, h *models.Holding) error {
+	defer metrics.ObserveQuery("holding", "Update", time.Now())
+	query := `
+		UPDATE holdings
+		SET company_id = $1, role = $2, stake_percent = $3, value = $4, started_on = $5, ended_on = $6
+		WHERE id = $7 AND millionaire_id = $8`
+
+	ctx, span := tracing.StartQuery(ctx, "HoldingRepo.Update", "UPDATE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query,
+		h.CompanyID, h.Role, h.StakePercent, h.Value, h.StartedOn, h.EndedOn, h.ID, h.MillionaireID,
+	)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to update holding", slog.Int("id", h.ID), logger.Err(err))
+		return err
+	}
+	return requireRow(res)
+}
+
+// Delete returns sql.ErrNoRows unless the holding belongs to millionaireID.
+func (r *HoldingRepo) Delete(ctx context.Context, millionaireID, id int) error {
+	defer metrics.ObserveQuery("holding", "Delete", time.Now())
+	query := "DELETE FROM holdings WHERE id = $1 AND millionaire_id = $2"
+
+	ctx, span := tracing.StartQuery(ctx, "HoldingRepo.Delete", "DELETE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query, id, millionaireID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to delete holding", slog.Int("id", id), logger.Err(err))
+		return err
+	}
+	return requireRow(res)
+}
+
+// Move hands the holdings of from over to to, except those duplicating a
+// holding to already has in the same company and role.
+func (r *HoldingRepo) Move(ctx context.Context, from, to int) error {
+	defer metrics.ObserveQuery("holding", "Move", time.Now())
+	query := `
+		UPDATE holdings h
+		SET millionaire_id = $2
+		WHERE h.millionaire_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM holdings t
+			WHERE t.millionaire_id = $2 AND t.company_id = h.company_id AND t.role = h.role
+		)`
+
+	ctx, span := tracing.StartQuery(ctx, "HoldingRepo.Move", "UPDATE", query)
+	defer span.End()
+
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query, from, to); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to move holdings", slog.Int("from", from), slog.Int("to", to), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// List returns a millionaire's holdings, most valuable first. With
+// currentOnly, holdings that ended before today are left out.
+func (r *HoldingRepo) List(ctx context.Context, millionaireID int, currentOnly bool) ([]models.Holding, error) {
+	defer metrics.ObserveQuery("holding", "List", time.Now())
+	query := `
+		SELECT ` + holdingColumns + `
+		FROM holdings h
+		JOIN companies c ON c.id = h.company_id
+		WHERE h.millionaire_id = $1 AND (NOT $2 OR h.ended_on IS NULL OR h.ended_on >= CURRENT_DATE)
+		ORDER BY h.value DESC NULLS LAST, h.id`
+
+	ctx, span := tracing.StartQuery(ctx, "HoldingRepo.List", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, millionaireID, currentOnly)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list holdings", slog.Int("millionaireId", millionaireID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holdings []models.Holding
+	for rows.Next() {
+		var h models.Holding
+		if err := rows.Scan(holdingTargets(&h)...); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		holdings = append(holdings, h)
+	}
+	return holdings, rows.Err()
+}
@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+
+	"github.com/lib/pq"
+)
+
+// HomeSections keeps the section definitions of the homepage.
+type HomeSections interface {
+	// List returns every section by position, then ID.
+	List(ctx context.Context) ([]models.HomeSection, error)
+	// GetByID, Update and Delete return sql.ErrNoRows for an unknown ID.
+	GetByID(ctx context.Context, id int) (*models.HomeSection, error)
+	Create(ctx context.Context, s *models.HomeSection) error
+	Update(ctx context.Context, s *models.HomeSection) error
+	Delete(ctx context.Context, id int) error
+}
+
+const homeSectionQuery = `SELECT id, position, kind, title, size, countries, industries, window_days, millionaire_ids, hidden, created_at, updated_at FROM home_sections`
+
+type HomeSectionRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+var _ HomeSections = (*HomeSectionRepo)(nil)
+
+// NewHomeSectionRepo sends List and GetByID to conn's reader and everything
+// else to its writer.
+func NewHomeSectionRepo(conn Conn, log *slog.Logger) *HomeSectionRepo {
+	return &HomeSectionRepo{conn: conn, log: log}
+}
+
+func scanHomeSection(row interface{ Scan(...interface{}) error }, s *models.HomeSection) error {
+	var ids pq.Int64Array
+	err := row.Scan(
+		&s.ID, &s.Position, &s.Kind, &s.Title, &s.Size, pq.Array(&s.Countries), pq.Array(&s.Industries),
+		&s.WindowDays, &ids, &s.Hidden, &s.CreatedAt, &s.UpdatedAt,
+	)
+	s.MillionaireIDs = nil
+	for _, id := range ids {
+		s.MillionaireIDs = append(s.MillionaireIDs, int(id))
+	}
+	if len(s.Countries) == 0 {
+		s.Countries = nil
+	}
+	if len(s.Industries) == 0 {
+		s.Industries = nil
+	}
+	return err
+}
+
+// textArray stores a nil slice as an empty array, which the NOT NULL
+// columns require.
+func textArray(values []string) interface{} {
+	if values == nil {
+		values = []string{}
+	}
+	return pq.Array(values)
+}
+
+func intArray(values []int) interface{} {
+	ids := make(pq.Int64Array, len(values))
+	for i, v := range values {
+		ids[i] = int64(v)
+	}
+	return ids
+}
+
+func (r *HomeSectionRepo) List(ctx context.Context) ([]models.HomeSection, error) {
+	defer metrics.ObserveQuery("home_section", "List", time.Now())
+	query := homeSectionQuery + " ORDER BY position, id"
+
+	ctx, span := tracing.StartQuery(ctx, "HomeSectionRepo.List", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list home sections", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []models.HomeSection
+	for rows.Next() {
+		var s models.HomeSection
+		if err := scanHomeSection(rows, &s); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		sections = append(sections, s)
+	}
+	return sections, rows.Err()
+}
+
+func (r *HomeSectionRepo) GetByID(ctx context.Context, id int) (*models.HomeSection, error) {
+	defer metrics.ObserveQuery("home_section", "GetByID", time.Now())
+	query := homeSectionQuery + " WHERE id = $1"
+
+	ctx, span := tracing.StartQuery(ctx, "HomeSectionRepo.GetByID", "SELECT", query)
+	defer span.End()
+
+	s := &models.HomeSection{}
+	err := scanHomeSection(r.conn.Reader(ctx).QueryRowContext(ctx, query, id), s)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			tracing.RecordError(span, err)
+			r.log.ErrorContext(ctx, "Failed to fetch home section", slog.Int("id", id), logger.Err(err))
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *HomeSectionRepo) Create(ctx context.Context, s *models.HomeSection) error {
+	defer metrics.ObserveQuery("home_section", "Create", time.Now())
+	query := `
+		INSERT INTO home_sections (position, kind, title, size, countries, industries, window_days, millionaire_ids, hidden, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	ctx, span := tracing.StartQuery(ctx, "HomeSectionRepo.Create", "INSERT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query,
+		s.Position, s.Kind, s.Title, s.Size, textArray(s.Countries), textArray(s.Industries),
+		s.WindowDays, intArray(s.MillionaireIDs), s.Hidden,
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to create home section", logger.Err(err))
+	}
+	return err
+}
+
+func (r *HomeSectionRepo) Update(ctx context.Context, s *models.HomeSection) error {
+	defer metrics.ObserveQuery("home_section", "Update", time.Now())
+	query := `
+		UPDATE home_sections
+		SET position = $1, kind = $2, title = $3, size = $4, countries = $5, industries = $6,
+		    window_days = $7, millionaire_ids = $8, hidden = $9, updated_at = NOW()
+		WHERE id = $10`
+
+	ctx, span := tracing.StartQuery(ctx, "HomeSectionRepo.Update", "UPDATE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query,
+		s.Position, s.Kind, s.Title, s.Size, textArray(s.Countries), textArray(s.Industries),
+		s.WindowDays, intArray(s.MillionaireIDs), s.Hidden, s.ID,
+	)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to update home section", slog.Int("id", s.ID), logger.Err(err))
+		return err
+	}
+	return requireRow(res)
+}
+
+func (r *HomeSectionRepo) Delete(ctx context.Context, id int) error {
+	defer metrics.ObserveQuery("home_section", "Delete", time.Now())
+	query := "DELETE FROM home_sections WHERE id = $1"
+
+	ctx, span := tracing.StartQuery(ctx, "HomeSectionRepo.Delete", "DELETE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to delete home section", slog.Int("id", id), logger.Err(err))
+		return err
+	}
+	return requireRow(res)
+}
+
+// ReplaceMillionaire puts to in from's place in featured sections; sections
+// that already feature to just drop from.
+func (r *HomeSectionRepo) ReplaceMillionaire(ctx context.Context, from, to int) error {
+	defer metrics.ObserveQuery("home_section", "ReplaceMillionaire", time.Now())
+	query := `
+		UPDATE home_sections
+		SET millionaire_ids = CASE
+		        WHEN $2 = ANY(millionaire_ids) THEN array_remove(millionaire_ids, $1)
+		        ELSE array_replace(millionaire_ids, $1, $2)
+		    END,
+		    updated_at = NOW()
+		WHERE $1 = ANY(millionaire_ids)`
+
+	ctx, span := tracing.StartQuery(ctx, "HomeSectionRepo.ReplaceMillionaire", "UPDATE", query)
+	defer span.End()
+
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query, from, to); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to replace featured millionaire", slog.Int("from", from), slog.Int("to", to), logger.Err(err))
+		return err
+	}
+	return nil
+}
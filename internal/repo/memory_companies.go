@@ -0,0 +1,273 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wealthlist/internal/models"
+)
+
+// memoryCompanies keeps companies and holdings in maps, with IDs from
+// sequences. Like the joins of the queries, listed holdings carry their
+// company's name and People the names of the holders, looked up in
+// millionaires.
+type memoryCompanies struct {
+	mu           sync.RWMutex
+	millionaires MillionaireRepository
+	companies    map[int]models.Company
+	holdings     map[int]models.Holding
+	nextCompany  int
+	nextHolding  int
+}
+
+var _ Companies = (*memoryCompanies)(nil)
+
+// NewMemoryCompanies is meant for tests; it is safe for concurrent use. The
+// holdings are those of Holdings.
+func NewMemoryCompanies(millionaires MillionaireRepository) *memoryCompanies {
+	return &memoryCompanies{
+		millionaires: millionaires,
+		companies:    make(map[int]models.Company),
+		holdings:     make(map[int]models.Holding),
+		nextCompany:  1,
+		nextHolding:  1,
+	}
+}
+
+// Holdings returns the holdings in these companies.
+func (r *memoryCompanies) Holdings() Holdings {
+	return memoryHoldings{r}
+}
+
+// legalIDTaken reports whether another company than id has c's legal ID.
+func (r *memoryCompanies) legalIDTaken(c *models.Company, id int) bool {
+	if c.LegalID == nil {
+		return false
+	}
+	for _, other := range r.companies {
+		if other.ID != id && other.LegalID != nil && *other.LegalID == *c.LegalID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryCompanies) Create(ctx context.Context, c *models.Company) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.legalIDTaken(c, 0) {
+		return ErrCompanyExists
+	}
+	now := time.Now()
+	c.ID, c.CreatedAt, c.UpdatedAt = r.nextCompany, now, now
+	r.nextCompany++
+	r.companies[c.ID] = *c
+	return nil
+}
+
+func (r *memoryCompanies) GetByID(ctx context.Context, id int) (*models.Company, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.companies[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &c, nil
+}
+
+func (r *memoryCompanies) List(ctx context.Context, name string, page, pageSize int) (models.PaginationCompanyDto, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.Company
+	for _, c := range r.companies {
+		if strings.Contains(strings.ToLower(c.Name), strings.ToLower(name)) {
+			matches = append(matches, c)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := strings.ToLower(matches[i].Name), strings.ToLower(matches[j].Name)
+		if a != b {
+			return a < b
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	result := models.PaginationCompanyDto{Total: len(matches), Page: page, PageSize: pageSize}
+	if start := (page - 1) * pageSize; start < len(matches) {
+		result.Companies = matches[start:min(start+pageSize, len(matches))]
+	}
+	return result, nil
+}
+
+func (r *memoryCompanies) Update(ctx context.Context, c *models.Company) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.companies[c.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	if r.legalIDTaken(c, c.ID) {
+		return ErrCompanyExists
+	}
+	c.CreatedAt, c.UpdatedAt = old.CreatedAt, time.Now()
+	r.companies[c.ID] = *c
+	return nil
+}
+
+func (r *memoryCompanies) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.companies[id]; !ok {
+		return sql.ErrNoRows
+	}
+	for _, h := range r.holdings {
+		if h.CompanyID == id {
+			return ErrCompanyHasHoldings
+		}
+	}
+	delete(r.companies, id)
+	return nil
+}
+
+func (r *memoryCompanies) People(ctx context.Context, companyID int) ([]models.CompanyPerson, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var holdings []models.Holding
+	for _, h := range r.holdings {
+		if h.CompanyID == companyID {
+			holdings = append(holdings, r.named(h))
+		}
+	}
+	today := time.Now().Format(time.DateOnly)
+	sortHoldings(holdings)
+	sort.SliceStable(holdings, func(i, j int) bool {
+		return current(holdings[i], today) && !current(holdings[j], today)
+	})
+
+	var people []models.CompanyPerson
+	for _, h := range holdings {
+		m, err := r.millionaires.GetByID(ctx, h.MillionaireID)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, models.CompanyPerson{Holding: h, FirstName: m.FirstName, LastName: m.LastName})
+	}
+	return people, nil
+}
+
+// named returns h with its company's name.
+func (r *memoryCompanies) named(h models.Holding) models.Holding {
+	h.CompanyName = r.companies[h.CompanyID].Name
+	return h
+}
+
+// current reports whether h has not ended before today.
+func current(h models.Holding, today string) bool {
+	return h.EndedOn == nil || *h.EndedOn >= today
+}
+
+// sortHoldings orders holdings the way the queries do, most valuable first
+// and those without a value last, and returns them.
+func sortHoldings(holdings []models.Holding) []models.Holding {
+	sort.Slice(holdings, func(i, j int) bool {
+		a, b := holdings[i], holdings[j]
+		switch {
+		case a.Value == nil && b.Value == nil:
+		case a.Value == nil:
+			return false
+		case b.Value == nil:
+			return true
+		default:
+			if c := a.Value.Cmp(*b.Value); c != 0 {
+				return c > 0
+			}
+		}
+		return a.ID < b.ID
+	})
+	return holdings
+}
+
+// memoryHoldings is the Holdings side of memoryCompanies.
+type memoryHoldings struct {
+	r *memoryCompanies
+}
+
+var _ Holdings = memoryHoldings{}
+
+func (h memoryHoldings) Add(ctx context.Context, holding *models.Holding) error {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	holding.ID = h.r.nextHolding
+	h.r.nextHolding++
+	h.r.holdings[holding.ID] = *holding
+	return nil
+}
+
+func (h memoryHoldings) Update(ctx context.Context, holding *models.Holding) error {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	if old, ok := h.r.holdings[holding.ID]; !ok || old.MillionaireID != holding.MillionaireID {
+		return sql.ErrNoRows
+	}
+	h.r.holdings[holding.ID] = *holding
+	return nil
+}
+
+func (h memoryHoldings) Delete(ctx context.Context, millionaireID, id int) error {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	if old, ok := h.r.holdings[id]; !ok || old.MillionaireID != millionaireID {
+		return sql.ErrNoRows
+	}
+	delete(h.r.holdings, id)
+	return nil
+}
+
+func (h memoryHoldings) Move(ctx context.Context, from, to int) error {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	type part struct {
+		companyID int
+		role      string
+	}
+	held := make(map[part]bool)
+	for _, holding := range h.r.holdings {
+		if holding.MillionaireID == to {
+			held[part{holding.CompanyID, holding.Role}] = true
+		}
+	}
+	for id, holding := range h.r.holdings {
+		if holding.MillionaireID == from && !held[part{holding.CompanyID, holding.Role}] {
+			holding.MillionaireID = to
+			h.r.holdings[id] = holding
+		}
+	}
+	return nil
+}
+
+func (h memoryHoldings) List(ctx context.Context, millionaireID int, currentOnly bool) ([]models.Holding, error) {
+	h.r.mu.RLock()
+	defer h.r.mu.RUnlock()
+
+	today := time.Now().Format(time.DateOnly)
+	var holdings []models.Holding
+	for _, holding := range h.r.holdings {
+		if holding.MillionaireID == millionaireID && (!currentOnly || current(holding, today)) {
+			holdings = append(holdings, h.r.named(holding))
+		}
+	}
+	return sortHoldings(holdings), nil
+}
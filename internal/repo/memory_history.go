@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"wealthlist/internal/models"
+)
+
+// memoryHistory keeps net worth points in a map per millionaire. Unlike the
+// table, it does not drop the history of a deleted millionaire.
+type memoryHistory struct {
+	mu     sync.RWMutex
+	points map[int]map[string]models.NetWorthPoint
+}
+
+var _ History = (*memoryHistory)(nil)
+
+// NewMemoryHistory is meant for tests; it is safe for concurrent use.
+func NewMemoryHistory() *memoryHistory {
+	return &memoryHistory{points: make(map[int]map[string]models.NetWorthPoint)}
+}
+
+func (r *memoryHistory) set(millionaireID int, p models.NetWorthPoint, overwrite bool) {
+	days := r.points[millionaireID]
+	if days == nil {
+		days = make(map[string]models.NetWorthPoint)
+		r.points[millionaireID] = days
+	}
+	if _, ok := days[p.Date]; ok && !overwrite {
+		return
+	}
+	days[p.Date] = p
+}
+
+func (r *memoryHistory) Add(ctx context.Context, millionaireID int, points []models.NetWorthPoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range points {
+		r.set(millionaireID, p, false)
+	}
+	return nil
+}
+
+func (r *memoryHistory) Record(ctx context.Context, millionaireID int, p models.NetWorthPoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.set(millionaireID, p, true)
+	return nil
+}
+
+func (r *memoryHistory) Copy(ctx context.Context, from, to int, overwrite bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.points[from] {
+		r.set(to, p, overwrite)
+	}
+	return nil
+}
+
+func (r *memoryHistory) List(ctx context.Context, millionaireID int) ([]models.NetWorthPoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var points []models.NetWorthPoint
+	for _, p := range r.points[millionaireID] {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Date < points[j].Date })
+	return points, nil
+}
+
+// memoryUnitOfWork hands fn repositories that write straight through:
+// nothing is isolated or rolled back.
+type memoryUnitOfWork struct {
+	repos Repos
+}
+
+var _ Transactor = memoryUnitOfWork{}
+
+// NewMemoryUnitOfWork is meant for tests of code that needs a Transactor.
+// fn is passed repos, whose WithTx must not be called; repositories left
+// nil in it are not available.
+func NewMemoryUnitOfWork(repos Repos) Transactor {
+	return memoryUnitOfWork{repos: repos}
+}
+
+func (u memoryUnitOfWork) WithTx(ctx context.Context, fn func(tx Repos) error) error {
+	return fn(u.repos)
+}
@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+	"wealthlist/internal/models"
+)
+
+// memoryHomeSections keeps homepage sections in a map. Like a migrated
+// database, it starts with the global top 10.
+type memoryHomeSections struct {
+	mu     sync.RWMutex
+	rows   map[int]models.HomeSection
+	nextID int
+}
+
+var _ HomeSections = (*memoryHomeSections)(nil)
+
+// NewMemoryHomeSections is meant for tests; it is safe for concurrent use.
+func NewMemoryHomeSections() *memoryHomeSections {
+	r := &memoryHomeSections{rows: make(map[int]models.HomeSection), nextID: 1}
+	_ = r.Create(context.Background(), &models.HomeSection{Position: 1, Kind: models.SectionTop, Title: "Top 10", Size: 10})
+	return r
+}
+
+func cloneSection(s models.HomeSection) models.HomeSection {
+	s.Countries = slices.Clone(s.Countries)
+	s.Industries = slices.Clone(s.Industries)
+	s.MillionaireIDs = slices.Clone(s.MillionaireIDs)
+	if s.WindowDays != nil {
+		v := *s.WindowDays
+		s.WindowDays = &v
+	}
+	return s
+}
+
+func (r *memoryHomeSections) List(ctx context.Context) ([]models.HomeSection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sections []models.HomeSection
+	for _, s := range r.rows {
+		sections = append(sections, cloneSection(s))
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].Position != sections[j].Position {
+			return sections[i].Position < sections[j].Position
+		}
+		return sections[i].ID < sections[j].ID
+	})
+	return sections, nil
+}
+
+func (r *memoryHomeSections) GetByID(ctx context.Context, id int) (*models.HomeSection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.rows[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	s = cloneSection(s)
+	return &s, nil
+}
+
+func (r *memoryHomeSections) Create(ctx context.Context, s *models.HomeSection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	s.ID, s.CreatedAt, s.UpdatedAt = r.nextID, now, now
+	r.nextID++
+	r.rows[s.ID] = cloneSection(*s)
+	return nil
+}
+
+func (r *memoryHomeSections) Update(ctx context.Context, s *models.HomeSection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.rows[s.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	row := cloneSection(*s)
+	row.CreatedAt, row.UpdatedAt = old.CreatedAt, time.Now().UTC().Truncate(time.Microsecond)
+	r.rows[s.ID] = row
+	return nil
+}
+
+func (r *memoryHomeSections) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rows[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.rows, id)
+	return nil
+}
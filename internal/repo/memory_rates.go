@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+)
+
+// memoryRates keeps exchange rates by currency and day.
+type memoryRates struct {
+	mu    sync.RWMutex
+	rates map[string]map[string]money.Amount
+}
+
+var _ Rates = (*memoryRates)(nil)
+
+// NewMemoryRates is meant for tests; it is safe for concurrent use.
+func NewMemoryRates(rates ...models.ExchangeRate) *memoryRates {
+	r := &memoryRates{rates: make(map[string]map[string]money.Amount)}
+	_ = r.Save(context.Background(), rates)
+	return r
+}
+
+func (r *memoryRates) Rate(ctx context.Context, currency, on string) (money.Amount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest string
+	for day := range r.rates[currency] {
+		if day <= on && day > latest {
+			latest = day
+		}
+	}
+	if latest == "" {
+		return money.Amount{}, sql.ErrNoRows
+	}
+	return r.rates[currency][latest], nil
+}
+
+func (r *memoryRates) Save(ctx context.Context, rates []models.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rate := range rates {
+		if r.rates[rate.Currency] == nil {
+			r.rates[rate.Currency] = make(map[string]money.Amount)
+		}
+		r.rates[rate.Currency][rate.Date] = rate.Rate
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// memoryRedirects keeps redirects in a map.
+type memoryRedirects struct {
+	mu sync.RWMutex
+	to map[int]int
+}
+
+var _ Redirects = (*memoryRedirects)(nil)
+
+// NewMemoryRedirects is meant for tests; it is safe for concurrent use.
+func NewMemoryRedirects() *memoryRedirects {
+	return &memoryRedirects{to: make(map[int]int)}
+}
+
+func (r *memoryRedirects) Add(ctx context.Context, from, to int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for old, id := range r.to {
+		if id == from {
+			r.to[old] = to
+		}
+	}
+	r.to[from] = to
+	return nil
+}
+
+func (r *memoryRedirects) Resolve(ctx context.Context, from int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	to, ok := r.to[from]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return to, nil
+}
@@ -0,0 +1,348 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"wealthlist/internal/models"
+)
+
+const (
+	// maxNameLength is the VARCHAR limit of the name columns.
+	maxNameLength = 500
+	// maxMaritalStatusLength is the VARCHAR limit of marital_status.
+	maxMaritalStatusLength = 20
+)
+
+var (
+	errNetWorthRequired = errors.New(`null value in column "net_worth" violates not-null constraint`)
+	errNegativeOffset   = errors.New("OFFSET must not be negative")
+	errNegativeLimit    = errors.New("LIMIT must not be negative")
+	errChildrenCount    = errors.New(`new row for relation "millionaires" violates check constraint "millionaires_children_count_check"`)
+	errCurrency         = errors.New(`new row for relation "millionaires" violates check constraint "millionaires_net_worth_currency_check"`)
+	errAsOfRequired     = errors.New(`null value in column "net_worth_as_of" violates not-null constraint`)
+)
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// memoryMillionaireRepo keeps millionaires in a map and mirrors what the
+// Postgres repository does with the same calls: IDs come from a sequence,
+// filters are case-insensitive LIKE matches, lists are ordered by ID and the
+// top list by net worth. It behaves like a database without exchange rates
+// or net worth history, so only valuations in RankingCurrency are ranked
+// before the rest and no one has gained or lost.
+type memoryMillionaireRepo struct {
+	mu     sync.RWMutex
+	rows   map[int]models.Millionaire
+	nextID int
+}
+
+var _ MillionaireRepository = (*memoryMillionaireRepo)(nil)
+
+// NewMemoryMillionaireRepo is meant for tests and local experiments; it is
+// safe for concurrent use.
+func NewMemoryMillionaireRepo() *memoryMillionaireRepo {
+	return &memoryMillionaireRepo{rows: make(map[int]models.Millionaire), nextID: 1}
+}
+
+func (r *memoryMillionaireRepo) Create(ctx context.Context, m *models.Millionaire) error {
+	row, err := checkRow(*m)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	row.ID = r.nextID
+	row.CreatedAt, row.UpdatedAt = now, now
+	r.nextID++
+	r.rows[row.ID] = row
+
+	m.ID = row.ID
+	return nil
+}
+
+func (r *memoryMillionaireRepo) GetByID(ctx context.Context, id int) (*models.Millionaire, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	row, ok := r.rows[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	m := clone(row)
+	return &m, nil
+}
+
+// Update changes nothing and reports no error for an unknown ID, like an
+// UPDATE that matches no rows.
+func (r *memoryMillionaireRepo) Update(ctx context.Context, m *models.Millionaire) error {
+	row, err := checkRow(*m)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.rows[m.ID]
+	if !ok {
+		return nil
+	}
+	row.CreatedAt = old.CreatedAt
+	row.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	r.rows[m.ID] = row
+	return nil
+}
+
+func (r *memoryMillionaireRepo) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.rows, id)
+	return nil
+}
+
+func (r *memoryMillionaireRepo) Search(ctx context.Context, filter MillionaireFilter, page int, pageSize int) (models.PaginationMillionaireDto, error) {
+	return r.page(filter, page, pageSize)
+}
+
+func (r *memoryMillionaireRepo) SearchAll(ctx context.Context, filter MillionaireFilter) ([]models.Millionaire, error) {
+	return r.sorted(filter, func(a, b models.Millionaire) bool { return a.ID < b.ID }), nil
+}
+
+func (r *memoryMillionaireRepo) GetAll(ctx context.Context, page int, pageSize int) (models.PaginationMillionaireDto, error) {
+	return r.page(MillionaireFilter{}, page, pageSize)
+}
+
+func (r *memoryMillionaireRepo) Top(ctx context.Context, filter MillionaireFilter, limit int) ([]models.Millionaire, error) {
+	millionaires := r.sorted(filter, func(a, b models.Millionaire) bool {
+		aRanked, bRanked := a.NetWorthCurrency == RankingCurrency, b.NetWorthCurrency == RankingCurrency
+		if aRanked != bRanked {
+			return aRanked
+		}
+		if c := a.NetWorth.Cmp(*b.NetWorth); aRanked && c != 0 {
+			return c > 0
+		}
+		return a.ID < b.ID
+	})
+	return first(millionaires, limit)
+}
+
+func (r *memoryMillionaireRepo) Newest(ctx context.Context, filter MillionaireFilter, limit int) ([]models.Millionaire, error) {
+	millionaires := r.sorted(filter, func(a, b models.Millionaire) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+	return first(millionaires, limit)
+}
+
+func (r *memoryMillionaireRepo) GetByIDs(ctx context.Context, ids []int) ([]models.Millionaire, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var millionaires []models.Millionaire
+	for _, id := range ids {
+		if row, ok := r.rows[id]; ok {
+			millionaires = append(millionaires, clone(row))
+		}
+	}
+	return millionaires, nil
+}
+
+// Movers finds no one: there is no net worth history to compare with.
+func (r *memoryMillionaireRepo) Movers(ctx context.Context, filter MillionaireFilter, since string, limit int, gainers bool) ([]models.HomeEntry, error) {
+	if _, err := time.Parse(time.DateOnly, since); err != nil {
+		return nil, fmt.Errorf("query execution failed: invalid input syntax for type date: %q", since)
+	}
+	return nil, nil
+}
+
+func (r *memoryMillionaireRepo) page(filter MillionaireFilter, page, pageSize int) (models.PaginationMillionaireDto, error) {
+	result := models.PaginationMillionaireDto{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	offset := (page - 1) * pageSize
+	if offset < 0 {
+		return result, fmt.Errorf("query execution failed: %w", errNegativeOffset)
+	}
+	if pageSize < 0 {
+		return result, fmt.Errorf("query execution failed: %w", errNegativeLimit)
+	}
+
+	matches := r.sorted(filter, func(a, b models.Millionaire) bool { return a.ID < b.ID })
+	result.Total = len(matches)
+	if offset < len(matches) {
+		matches = matches[offset:min(offset+pageSize, len(matches))]
+		for _, m := range matches {
+			m.Biography, m.Education = nil, nil
+			result.Millionaires = append(result.Millionaires, m)
+		}
+	}
+	return result, nil
+}
+
+// first returns up to limit of ms, failing like LIMIT on a negative one.
+func first(ms []models.Millionaire, limit int) ([]models.Millionaire, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("query execution failed: %w", errNegativeLimit)
+	}
+	return ms[:min(limit, len(ms))], nil
+}
+
+// sorted returns copies of the rows matching filter in the given order.
+func (r *memoryMillionaireRepo) sorted(filter MillionaireFilter, less func(a, b models.Millionaire) bool) []models.Millionaire {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.Millionaire
+	for _, row := range r.rows {
+		if matchesFilter(row, filter) {
+			matches = append(matches, clone(row))
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return less(matches[i], matches[j]) })
+	return matches
+}
+
+// matchesFilter applies the conditions of BuildWhereClause: each set text
+// field must match the column with ILIKE '%value%' and codes must be equal,
+// or for industries a prefix. NULL columns never match.
+func matchesFilter(m models.Millionaire, f MillionaireFilter) bool {
+	for _, c := range []struct {
+		value  string
+		column *string
+	}{
+		{f.LastName, &m.LastName},
+		{f.FirstName, &m.FirstName},
+		{f.MiddleName, m.MiddleName},
+		{f.Country, m.Country},
+		{f.Industry, m.Industry},
+	} {
+		if c.value == "" {
+			continue
+		}
+		if c.column == nil || !ilike(*c.column, "%"+c.value+"%") {
+			return false
+		}
+	}
+	if f.CountryCode != "" && (m.CountryCode == nil || *m.CountryCode != f.CountryCode) {
+		return false
+	}
+	if f.IndustryCode != "" && !inIndustry(m.IndustryCode, f.IndustryCode) {
+		return false
+	}
+	if len(f.CountryCodes) > 0 && (m.CountryCode == nil || !slices.Contains(f.CountryCodes, *m.CountryCode)) {
+		return false
+	}
+	if len(f.IndustryCodes) > 0 && !slices.ContainsFunc(f.IndustryCodes, func(code string) bool { return inIndustry(m.IndustryCode, code) }) {
+		return false
+	}
+	return true
+}
+
+// inIndustry reports whether industry is code or one of the industries
+// below it.
+func inIndustry(industry *string, code string) bool {
+	return industry != nil && (*industry == code || strings.HasPrefix(*industry, code+"."))
+}
+
+// ilike matches s against a LIKE pattern case-insensitively: % is any
+// sequence, _ any character and a backslash escapes the next character.
+func ilike(s, pattern string) bool {
+	var re strings.Builder
+	re.WriteString("(?is)^")
+	for i := 0; i < len(pattern); {
+		c, size := utf8.DecodeRuneInString(pattern[i:])
+		i += size
+		switch c {
+		case '%':
+			re.WriteString(".*")
+		case '_':
+			re.WriteString(".")
+		case '\\':
+			if i < len(pattern) {
+				c, size = utf8.DecodeRuneInString(pattern[i:])
+				i += size
+			}
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	return regexp.MustCompile(re.String()).MatchString(s)
+}
+
+// checkRow enforces the column constraints and conversions Postgres would
+// apply and returns the row as it would be stored.
+func checkRow(m models.Millionaire) (models.Millionaire, error) {
+	if m.NetWorth == nil {
+		return m, errNetWorthRequired
+	}
+	for column, v := range map[string]*string{"last_name": &m.LastName, "first_name": &m.FirstName, "middle_name": m.MiddleName} {
+		if v != nil && utf8.RuneCountInString(*v) > maxNameLength {
+			return m, fmt.Errorf("value too long for type character varying(%d) in column %q", maxNameLength, column)
+		}
+	}
+	if m.MaritalStatus != nil && utf8.RuneCountInString(*m.MaritalStatus) > maxMaritalStatusLength {
+		return m, fmt.Errorf("value too long for type character varying(%d)", maxMaritalStatusLength)
+	}
+	if m.ChildrenCount != nil && *m.ChildrenCount < 0 {
+		return m, errChildrenCount
+	}
+	if !currencyPattern.MatchString(m.NetWorthCurrency) {
+		return m, errCurrency
+	}
+	if m.NetWorthAsOf == nil {
+		return m, errAsOfRequired
+	}
+	if _, err := time.Parse(time.DateOnly, *m.NetWorthAsOf); err != nil {
+		return m, fmt.Errorf("invalid input syntax for type date: %q", *m.NetWorthAsOf)
+	}
+	if len(m.SocialHandles) == 0 {
+		m.SocialHandles = nil
+	}
+	m.BiographyHTML, m.ThumbnailURL = "", ""
+	return clone(m), nil
+}
+
+// clone copies m so that callers cannot change stored rows through the
+// pointer fields.
+func clone(m models.Millionaire) models.Millionaire {
+	for _, p := range []**string{
+		&m.MiddleName, &m.BirthDate, &m.BirthPlace, &m.Company, &m.NetWorthAsOf, &m.Industry, &m.Country, &m.CountryCode, &m.IndustryCode,
+		&m.Biography, &m.Education, &m.MaritalStatus, &m.ResidenceCity, &m.Website, &m.PathToPhoto,
+	} {
+		if *p != nil {
+			v := **p
+			*p = &v
+		}
+	}
+	if m.NetWorth != nil {
+		v := *m.NetWorth
+		m.NetWorth = &v
+	}
+	if m.ChildrenCount != nil {
+		v := *m.ChildrenCount
+		m.ChildrenCount = &v
+	}
+	m.Citizenships = slices.Clone(m.Citizenships)
+	m.SocialHandles = maps.Clone(m.SocialHandles)
+	return m
+}
@@ -0,0 +1,47 @@
+package repo
+
+import "context"
+
+// memorySeed runs the seed operations on a millionaire repository. Unlike
+// the Postgres one, DeleteAll leaves history, holdings and redirects kept
+// elsewhere alone and does not restart the IDs.
+type memorySeed struct {
+	millionaires MillionaireRepository
+}
+
+var _ Seed = (*memorySeed)(nil)
+
+// NewMemorySeed is meant for tests; it is as safe for concurrent use as
+// millionaires.
+func NewMemorySeed(millionaires MillionaireRepository) *memorySeed {
+	return &memorySeed{millionaires: millionaires}
+}
+
+func (r *memorySeed) Keys(ctx context.Context) (map[MillionaireKey]bool, error) {
+	all, err := r.millionaires.SearchAll(ctx, MillionaireFilter{})
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[MillionaireKey]bool, len(all))
+	for _, m := range all {
+		k := MillionaireKey{LastName: m.LastName, FirstName: m.FirstName}
+		if m.BirthDate != nil {
+			k.BirthDate = *m.BirthDate
+		}
+		keys[k] = true
+	}
+	return keys, nil
+}
+
+func (r *memorySeed) DeleteAll(ctx context.Context) error {
+	all, err := r.millionaires.SearchAll(ctx, MillionaireFilter{})
+	if err != nil {
+		return err
+	}
+	for _, m := range all {
+		if err := r.millionaires.Delete(ctx, m.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
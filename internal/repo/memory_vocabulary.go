@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"strings"
+	"wealthlist/internal/models"
+)
+
+// memoryVocabulary resolves values the way the country_lookup and
+// industry_lookup views do, over the entries it was created with.
+type memoryVocabulary struct {
+	countries  map[string]string
+	industries map[string]string
+}
+
+var _ Vocabulary = (*memoryVocabulary)(nil)
+
+// NewMemoryVocabulary is meant for tests. Countries are found by code,
+// alpha-3, name and the names in Names; industries by code and name.
+func NewMemoryVocabulary(countries []models.Country, industries []models.Industry) *memoryVocabulary {
+	v := &memoryVocabulary{countries: make(map[string]string), industries: make(map[string]string)}
+	for _, c := range countries {
+		keys := []string{c.Code, c.Alpha3, c.Name}
+		for _, name := range c.Names {
+			keys = append(keys, name)
+		}
+		addKeys(v.countries, c.Code, keys...)
+	}
+	for _, i := range industries {
+		addKeys(v.industries, i.Code, i.Code, i.Name)
+	}
+	return v
+}
+
+// addKeys maps each key to code. A key already used for another code maps
+// to "", like a lookup key shared by two entries.
+func addKeys(lookup map[string]string, code string, keys ...string) {
+	for _, k := range keys {
+		k = strings.ToLower(k)
+		if existing, ok := lookup[k]; ok && existing != code {
+			lookup[k] = ""
+			continue
+		}
+		lookup[k] = code
+	}
+}
+
+func (v *memoryVocabulary) ResolveCountry(_ context.Context, value string) (string, error) {
+	return v.countries[strings.ToLower(strings.TrimSpace(value))], nil
+}
+
+func (v *memoryVocabulary) ResolveIndustry(_ context.Context, value string) (string, error) {
+	return v.industries[strings.ToLower(strings.TrimSpace(value))], nil
+}
@@ -1,88 +1,140 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
-	"path/filepath"
+	"time"
+	"wealthlist/internal/metrics"
 	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/tracing"
+
+	"github.com/lib/pq"
 )
 
 type MillionaireRepository interface {
-	Create(m *models.Millionaire) error
-	GetByID(id int) (*models.Millionaire, error)
-	Search(filter MillionaireFilter, page int, pageSize int) (models.PaginationMillionaireDto, error)
-	GetAll(page int, pageSize int) (models.PaginationMillionaireDto, error)
-	Update(m *models.Millionaire) error
-	Delete(id int) error
-	ScanRows(rows *sql.Rows) ([]models.Millionaire, error)
-	GetTopMillionaires(baseURL string) ([]models.Millionaire, error)
+	Create(ctx context.Context, m *models.Millionaire) error
+	GetByID(ctx context.Context, id int) (*models.Millionaire, error)
+	// Search and GetAll page through millionaires for list views, leaving
+	// out the Biography and Education.
+	Search(ctx context.Context, filter MillionaireFilter, page int, pageSize int) (models.PaginationMillionaireDto, error)
+	// SearchAll returns every millionaire matching filter, ordered by ID.
+	SearchAll(ctx context.Context, filter MillionaireFilter) ([]models.Millionaire, error)
+	GetAll(ctx context.Context, page int, pageSize int) (models.PaginationMillionaireDto, error)
+	Update(ctx context.Context, m *models.Millionaire) error
+	Delete(ctx context.Context, id int) error
+	// Top ranks the millionaires matching filter by net worth in
+	// RankingCurrency, converted at the rates of each valuation's day;
+	// valuations that cannot be converted come last.
+	Top(ctx context.Context, filter MillionaireFilter, limit int) ([]models.Millionaire, error)
+	// Newest returns the millionaires matching filter that were added last,
+	// newest first.
+	Newest(ctx context.Context, filter MillionaireFilter, limit int) ([]models.Millionaire, error)
+	// GetByIDs returns the millionaires with the given IDs in their order,
+	// leaving out unknown ones.
+	GetByIDs(ctx context.Context, ids []int) ([]models.Millionaire, error)
+	// Movers ranks the millionaires matching filter by how much their net
+	// worth in RankingCurrency changed since a day (YYYY-MM-DD): the biggest
+	// gains first or, without gainers, the biggest losses. The change runs
+	// from the latest history point on or before that day, each end
+	// converted at the rates of its own day. Millionaires without such a
+	// point, or whose change goes the other way, are left out.
+	Movers(ctx context.Context, filter MillionaireFilter, since string, limit int, gainers bool) ([]models.HomeEntry, error)
 }
 
+// RankingCurrency is the currency net worths are normalized to for ranking.
+const RankingCurrency = "USD"
+
+// MillionaireFilter keeps millionaires matching every non-empty field. Text
+// fields match case-insensitively anywhere in the column; IndustryCode also
+// matches the industries below it. CountryCodes and IndustryCodes keep
+// millionaires matching any of their codes the same way.
 type MillionaireFilter struct {
-	LastName   string
-	FirstName  string
-	MiddleName string
-	Country    string
+	LastName      string
+	FirstName     string
+	MiddleName    string
+	Country       string
+	Industry      string
+	CountryCode   string
+	IndustryCode  string
+	CountryCodes  []string
+	IndustryCodes []string
 }
 
 type millionaireRepo struct {
-	db  *sql.DB
-	log *slog.Logger
+	conn Conn
+	log  *slog.Logger
 }
 
 const (
-	baseQuery  = `SELECT id, last_name, first_name, middle_name, birth_date, birth_place, company, net_worth, industry, country, path_to_photo, created_at, updated_at FROM millionaires`
+	baseQuery  = `SELECT ` + millionaireColumns + ` FROM millionaires`
+	listQuery  = `SELECT ` + listColumns + ` FROM millionaires`
 	countQuery = `SELECT COUNT(*) FROM millionaires`
 )
 
-func NewMillionaireRepo(db *sql.DB, log *slog.Logger) *millionaireRepo {
-	return &millionaireRepo{db: db, log: log}
+// NewMillionaireRepo sends GetByID, the lists and the rankings to conn's
+// reader and everything else to its writer.
+func NewMillionaireRepo(conn Conn, log *slog.Logger) *millionaireRepo {
+	return &millionaireRepo{conn: conn, log: log}
 }
 
-func (r *millionaireRepo) Create(m *models.Millionaire) error {
-	r.log.Info("Creating millionaire", slog.String("name", m.FirstName+" "+m.LastName))
+func (r *millionaireRepo) Create(ctx context.Context, m *models.Millionaire) error {
+	defer metrics.ObserveQuery("millionaire", "Create", time.Now())
+	r.log.InfoContext(ctx, "Creating millionaire", slog.String("name", m.FirstName+" "+m.LastName))
 	query := `
     INSERT INTO millionaires (
-        last_name, first_name, middle_name, birth_date, 
-        birth_place, company, net_worth, industry, 
-        country, path_to_photo, created_at, updated_at
-    ) 
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW()) 
+        last_name, first_name, middle_name, birth_date,
+        birth_place, company, net_worth, industry,
+        country, country_code, industry_code, biography,
+        education, citizenships, marital_status, children_count,
+        residence_city, website, social_handles, path_to_photo,
+        net_worth_currency, net_worth_as_of, created_at, updated_at
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, NOW(), NOW())
     RETURNING id`
 
-	err := r.db.QueryRow(query,
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.Create", "INSERT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query,
 		m.LastName, m.FirstName, m.MiddleName, m.BirthDate,
 		m.BirthPlace, m.Company, m.NetWorth, m.Industry,
-		m.Country, m.PathToPhoto,
+		m.Country, m.CountryCode, m.IndustryCode, m.Biography,
+		m.Education, pq.Array(m.Citizenships), m.MaritalStatus, m.ChildrenCount,
+		m.ResidenceCity, m.Website, jsonObject{&m.SocialHandles}, m.PathToPhoto,
+		m.NetWorthCurrency, m.NetWorthAsOf,
 	).Scan(&m.ID)
 
 	if err != nil {
-		r.log.Error("Failed to create millionaire", slog.String("error", err.Error()))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to create millionaire", slog.String("error", err.Error()))
 	}
 
 	return err
 }
 
-func (r *millionaireRepo) GetByID(id int) (*models.Millionaire, error) {
-	r.log.Info("Fetching millionaire by ID", slog.Int("id", id))
+func (r *millionaireRepo) GetByID(ctx context.Context, id int) (*models.Millionaire, error) {
+	defer metrics.ObserveQuery("millionaire", "GetByID", time.Now())
+	r.log.InfoContext(ctx, "Fetching millionaire by ID", slog.Int("id", id))
 	query := baseQuery + " WHERE id = $1"
-	row := r.db.QueryRow(query, id)
+
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.GetByID", "SELECT", query)
+	defer span.End()
+
+	row := r.conn.Reader(ctx).QueryRowContext(ctx, query, id)
 
 	m := &models.Millionaire{}
-	r.log.Info("Scanning millionaire", slog.Any("query", query))
-	err := row.Scan(
-		&m.ID, &m.LastName, &m.FirstName, &m.MiddleName,
-		&m.BirthDate, &m.BirthPlace, &m.Company, &m.NetWorth,
-		&m.Industry, &m.Country, &m.PathToPhoto,
-		&m.CreatedAt, &m.UpdatedAt,
-	)
+	r.log.InfoContext(ctx, "Scanning millionaire", slog.Any("query", query))
+	err := row.Scan(scanTargets(m)...)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			r.log.Warn("Millionaire not found", slog.Int("id", id))
+			r.log.WarnContext(ctx, "Millionaire not found", slog.Int("id", id))
 		} else {
-			r.log.Error("Failed to fetch millionaire", slog.String("error", err.Error()))
+			tracing.RecordError(span, err)
+			r.log.ErrorContext(ctx, "Failed to fetch millionaire", slog.String("error", err.Error()))
 		}
 		return nil, err
 	}
@@ -90,63 +142,88 @@ func (r *millionaireRepo) GetByID(id int) (*models.Millionaire, error) {
 	return m, nil
 }
 
-func (r *millionaireRepo) Update(m *models.Millionaire) error {
-	r.log.Info("Updating millionaire", slog.Int("id", m.ID))
+func (r *millionaireRepo) Update(ctx context.Context, m *models.Millionaire) error {
+	defer metrics.ObserveQuery("millionaire", "Update", time.Now())
+	r.log.InfoContext(ctx, "Updating millionaire", slog.Int("id", m.ID))
 	query := `
-		UPDATE millionaires 
+		UPDATE millionaires
 		SET last_name = $1, first_name = $2, middle_name = $3,
 		    birth_date = $4, birth_place = $5, company = $6,
-		    net_worth = $7, industry = $8, country = $9, path_to_photo = $10,
-		    updated_at = NOW()
-		WHERE id = $11`
-
-	_, err := r.db.Exec(query,
+		    net_worth = $7, industry = $8, country = $9,
+		    country_code = $10, industry_code = $11, biography = $12,
+		    education = $13, citizenships = $14, marital_status = $15,
+		    children_count = $16, residence_city = $17, website = $18,
+		    social_handles = $19, path_to_photo = $20, net_worth_currency = $21,
+		    net_worth_as_of = $22, updated_at = NOW()
+		WHERE id = $23`
+
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.Update", "UPDATE", query)
+	defer span.End()
+
+	_, err := r.conn.Writer(ctx).ExecContext(ctx, query,
 		m.LastName, m.FirstName, m.MiddleName, m.BirthDate,
 		m.BirthPlace, m.Company, m.NetWorth, m.Industry,
-		m.Country, m.PathToPhoto, m.ID,
+		m.Country, m.CountryCode, m.IndustryCode, m.Biography,
+		m.Education, pq.Array(m.Citizenships), m.MaritalStatus, m.ChildrenCount,
+		m.ResidenceCity, m.Website, jsonObject{&m.SocialHandles}, m.PathToPhoto,
+		m.NetWorthCurrency, m.NetWorthAsOf, m.ID,
 	)
 
 	if err != nil {
-		r.log.Error("Failed to update millionaire", slog.Int("id", m.ID), slog.String("error", err.Error()))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to update millionaire", slog.Int("id", m.ID), slog.String("error", err.Error()))
 	}
 
 	return err
 }
 
-func (r *millionaireRepo) Delete(id int) error {
-	r.log.Info("Deleting millionaire", slog.Int("id", id))
-	_, err := r.db.Exec("DELETE FROM millionaires WHERE id = $1", id)
+func (r *millionaireRepo) Delete(ctx context.Context, id int) error {
+	defer metrics.ObserveQuery("millionaire", "Delete", time.Now())
+	r.log.InfoContext(ctx, "Deleting millionaire", slog.Int("id", id))
+	query := "DELETE FROM millionaires WHERE id = $1"
+
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.Delete", "DELETE", query)
+	defer span.End()
+
+	_, err := r.conn.Writer(ctx).ExecContext(ctx, query, id)
 
 	if err != nil {
-		r.log.Error("Failed to delete millionaire", slog.Int("id", id), slog.String("error", err.Error()))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to delete millionaire", slog.Int("id", id), slog.String("error", err.Error()))
 	}
 
 	return err
 }
 
-func (r *millionaireRepo) Search(filter MillionaireFilter, page int, pageSize int) (models.PaginationMillionaireDto, error) {
-	r.log.Info("Searching millionaires", slog.Int("page", page), slog.Int("pageSize", pageSize))
+func (r *millionaireRepo) Search(ctx context.Context, filter MillionaireFilter, page int, pageSize int) (models.PaginationMillionaireDto, error) {
+	defer metrics.ObserveQuery("millionaire", "Search", time.Now())
+	r.log.InfoContext(ctx, "Searching millionaires", slog.Int("page", page), slog.Int("pageSize", pageSize))
 	result := models.PaginationMillionaireDto{
 		Page:     page,
 		PageSize: pageSize,
 	}
 
 	where, args := BuildWhereClause(filter)
-	query := baseQuery + where + fmt.Sprintf(" LIMIT %d OFFSET %d", pageSize, (page-1)*pageSize)
+	query := listQuery + where + fmt.Sprintf(" ORDER BY id LIMIT %d OFFSET %d", pageSize, (page-1)*pageSize)
 
-	rows, err := r.db.Query(query, args...)
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.Search", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
-		r.log.Error("Query execution failed", slog.String("error", err.Error()))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Query execution failed", slog.String("error", err.Error()))
 		return result, fmt.Errorf("query execution failed: %w", err)
 	}
 	defer rows.Close()
 
-	millionaires, err := r.ScanRows(rows)
+	millionaires, err := r.ScanRows(ctx, rows)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return result, err
 	}
 
-	total, err := r.GetTotalCount(where, args...)
+	total, err := r.GetTotalCount(ctx, where, args...)
 	if err != nil {
 		return result, err
 	}
@@ -156,27 +233,40 @@ func (r *millionaireRepo) Search(filter MillionaireFilter, page int, pageSize in
 	return result, nil
 }
 
-func (r *millionaireRepo) GetAll(page int, pageSize int) (models.PaginationMillionaireDto, error) {
-	r.log.Info("Fetching all millionaires", slog.Int("page", page), slog.Int("pageSize", pageSize))
+func (r *millionaireRepo) SearchAll(ctx context.Context, filter MillionaireFilter) ([]models.Millionaire, error) {
+	defer metrics.ObserveQuery("millionaire", "SearchAll", time.Now())
+	where, args := BuildWhereClause(filter)
+	return r.query(ctx, "SearchAll", baseQuery+where+" ORDER BY id", args...)
+}
+
+func (r *millionaireRepo) GetAll(ctx context.Context, page int, pageSize int) (models.PaginationMillionaireDto, error) {
+	defer metrics.ObserveQuery("millionaire", "GetAll", time.Now())
+	r.log.InfoContext(ctx, "Fetching all millionaires", slog.Int("page", page), slog.Int("pageSize", pageSize))
 	result := models.PaginationMillionaireDto{
 		Page:     page,
 		PageSize: pageSize,
 	}
 
-	query := baseQuery + fmt.Sprintf(" LIMIT %d OFFSET %d", pageSize, (page-1)*pageSize)
-	rows, err := r.db.Query(query)
+	query := listQuery + fmt.Sprintf(" ORDER BY id LIMIT %d OFFSET %d", pageSize, (page-1)*pageSize)
+
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.GetAll", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query)
 	if err != nil {
-		r.log.Error("Query execution failed", slog.String("error", err.Error()))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Query execution failed", slog.String("error", err.Error()))
 		return result, fmt.Errorf("query execution failed: %w", err)
 	}
 	defer rows.Close()
 
-	millionaires, err := r.ScanRows(rows)
+	millionaires, err := r.ScanRows(ctx, rows)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return result, err
 	}
 
-	total, err := r.GetTotalCount("")
+	total, err := r.GetTotalCount(ctx, "")
 	if err != nil {
 		return result, err
 	}
@@ -186,32 +276,123 @@ func (r *millionaireRepo) GetAll(page int, pageSize int) (models.PaginationMilli
 	return result, nil
 }
 
-func (r *millionaireRepo) GetTopMillionaires(baseURL string) ([]models.Millionaire, error) {
-	var millionaires []models.Millionaire
-	query := `
-	SELECT id, last_name, first_name, middle_name, birth_date, birth_place,
-		   company, net_worth, industry, country, path_to_photo, created_at, updated_at
-	FROM millionaires ORDER BY net_worth DESC LIMIT 10`
+// Page returns a page of whole millionaires, ordered by ID, for export.
+func (r *millionaireRepo) Page(ctx context.Context, page int, pageSize int) ([]models.Millionaire, error) {
+	defer metrics.ObserveQuery("millionaire", "Page", time.Now())
+	return r.query(ctx, "Page", baseQuery+fmt.Sprintf(" ORDER BY id LIMIT %d OFFSET %d", pageSize, (page-1)*pageSize))
+}
 
-	rows, err := r.db.Query(query)
+// query runs a SELECT returning millionaireColumns or listColumns.
+func (r *millionaireRepo) query(ctx context.Context, method, query string, args ...interface{}) ([]models.Millionaire, error) {
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo."+method, "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
-		r.log.Error("Error fetching top millionaires", slog.Any("error", err))
-		return nil, err
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Query execution failed", slog.String("method", method), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("query execution failed: %w", err)
 	}
 	defer rows.Close()
 
-	millionaires, err = r.ScanRows(rows)
+	millionaires, err := r.ScanRows(ctx, rows)
 	if err != nil {
-		r.log.Error("Error scanning top millionaires", slog.Any("error", err))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
+	return millionaires, nil
+}
 
-	for i := range millionaires {
-		if millionaires[i].PathToPhoto != nil && *millionaires[i].PathToPhoto != "" {
-			fullURL := fmt.Sprintf("%s/api/photo/%s", baseURL, filepath.Base(*millionaires[i].PathToPhoto))
-			millionaires[i].PathToPhoto = &fullURL
-		}
+func (r *millionaireRepo) Top(ctx context.Context, filter MillionaireFilter, limit int) ([]models.Millionaire, error) {
+	defer metrics.ObserveQuery("millionaire", "Top", time.Now())
+	where, args := BuildWhereClause(filter)
+	query := baseQuery + where + fmt.Sprintf(
+		" ORDER BY convert_money(net_worth, net_worth_currency, $%d, net_worth_as_of) DESC NULLS LAST, id LIMIT %d", len(args)+1, limit)
+	return r.query(ctx, "Top", query, append(args, RankingCurrency)...)
+}
+
+func (r *millionaireRepo) Newest(ctx context.Context, filter MillionaireFilter, limit int) ([]models.Millionaire, error) {
+	defer metrics.ObserveQuery("millionaire", "Newest", time.Now())
+	where, args := BuildWhereClause(filter)
+	query := baseQuery + where + fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT %d", limit)
+	return r.query(ctx, "Newest", query, args...)
+}
+
+func (r *millionaireRepo) GetByIDs(ctx context.Context, ids []int) ([]models.Millionaire, error) {
+	defer metrics.ObserveQuery("millionaire", "GetByIDs", time.Now())
+	found, err := r.query(ctx, "GetByIDs", baseQuery+" WHERE id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return nil, err
 	}
 
+	byID := make(map[int]models.Millionaire, len(found))
+	for _, m := range found {
+		byID[m.ID] = m
+	}
+	var millionaires []models.Millionaire
+	for _, id := range ids {
+		if m, ok := byID[id]; ok {
+			millionaires = append(millionaires, m)
+		}
+	}
 	return millionaires, nil
 }
+
+func (r *millionaireRepo) Movers(ctx context.Context, filter MillionaireFilter, since string, limit int, gainers bool) ([]models.HomeEntry, error) {
+	defer metrics.ObserveQuery("millionaire", "Movers", time.Now())
+	where, args := BuildWhereClause(filter)
+	change, order := "current_worth - past_worth > 0", "DESC"
+	if !gainers {
+		change, order = "current_worth - past_worth < 0", "ASC"
+	}
+	if where == "" {
+		where = " WHERE " + change
+	} else {
+		where += " AND " + change
+	}
+
+	// The filter comes first in args, so the CTE takes the next two.
+	currency, day := len(args)+1, len(args)+2
+	query := fmt.Sprintf(`
+		WITH past AS (
+			SELECT DISTINCT ON (millionaire_id) millionaire_id, recorded_on,
+			       convert_money(net_worth, currency, $%[1]d, recorded_on) AS worth
+			FROM net_worth_history
+			WHERE recorded_on <= $%[2]d
+			ORDER BY millionaire_id, recorded_on DESC
+		)
+		SELECT `+millionaireColumns+`, to_char(since, 'YYYY-MM-DD'), past_worth, current_worth
+		FROM (
+			SELECT millionaires.*, past.recorded_on AS since, past.worth AS past_worth,
+			       convert_money(millionaires.net_worth, millionaires.net_worth_currency, $%[1]d, millionaires.net_worth_as_of) AS current_worth
+			FROM millionaires JOIN past ON past.millionaire_id = millionaires.id
+		) movers`, currency, day) + where + fmt.Sprintf(" ORDER BY current_worth - past_worth %s, id LIMIT %d", order, limit)
+	args = append(args, RankingCurrency, since)
+
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.Movers", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Query execution failed", slog.String("method", "Movers"), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	units := money.MinorUnits(RankingCurrency)
+	var entries []models.HomeEntry
+	for rows.Next() {
+		var m models.Millionaire
+		c := models.NetWorthChange{Currency: RankingCurrency}
+		if err := rows.Scan(append(scanTargets(&m), &c.Since, &c.From, &c.To)...); err != nil {
+			tracing.RecordError(span, err)
+			r.log.ErrorContext(ctx, "Error scanning row", slog.String("error", err.Error()))
+			return nil, err
+		}
+		c.From, c.To = c.From.Round(units), c.To.Round(units)
+		c.Amount = c.To.Sub(c.From)
+		entries = append(entries, models.HomeEntry{Millionaire: m, Change: &c})
+	}
+	return entries, rows.Err()
+}
@@ -1,18 +1,27 @@
 package repo
 
-import "log/slog"
+import (
+	"context"
+	"log/slog"
+	"wealthlist/internal/tracing"
+)
 
-func (r *millionaireRepo) GetTotalCount(whereClause string, args ...interface{}) (int, error) {
-	r.log.Debug("Executing count query", slog.String("query", countQuery+whereClause), slog.Any("args", args))
+func (r *millionaireRepo) GetTotalCount(ctx context.Context, whereClause string, args ...interface{}) (int, error) {
+	r.log.DebugContext(ctx, "Executing count query", slog.String("query", countQuery+whereClause), slog.Any("args", args))
 
 	query := countQuery + whereClause
+
+	ctx, span := tracing.StartQuery(ctx, "millionaireRepo.GetTotalCount", "SELECT", query)
+	defer span.End()
+
 	var total int
-	err := r.db.QueryRow(query, args...).Scan(&total)
+	err := r.conn.Reader(ctx).QueryRowContext(ctx, query, args...).Scan(&total)
 	if err != nil {
-		r.log.Error("Failed to get total count", slog.String("query", query), slog.Any("args", args), slog.Any("error", err))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to get total count", slog.String("query", query), slog.Any("args", args), slog.Any("error", err))
 		return 0, err
 	}
 
-	r.log.Info("Total count retrieved", slog.Int("total", total))
+	r.log.InfoContext(ctx, "Total count retrieved", slog.Int("total", total))
 	return total, nil
 }
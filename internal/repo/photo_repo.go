@@ -1,58 +1,135 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"log/slog"
+	"time"
 	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/tracing"
 )
 
 type PhotoRepo struct {
-	DB  *sql.DB
-	log *slog.Logger
+	conn Conn
+	log  *slog.Logger
 }
 
-func NewPhotoRepo(db *sql.DB, log *slog.Logger) *PhotoRepo {
+// NewPhotoRepo always uses conn's writer: photo paths are read right before
+// they are changed or compared with the disk.
+func NewPhotoRepo(conn Conn, log *slog.Logger) *PhotoRepo {
 	return &PhotoRepo{
-		DB:  db,
-		log: log,
+		conn: conn,
+		log:  log,
 	}
 }
 
-func (r *PhotoRepo) UpdatePhotoPath(millionaireID int, filePath string) error {
+func (r *PhotoRepo) UpdatePhotoPath(ctx context.Context, millionaireID int, filePath string) error {
+	defer metrics.ObserveQuery("photo", "UpdatePhotoPath", time.Now())
 	query := `
 		UPDATE millionaires
 		SET path_to_photo = $1, updated_at = NOW()
 		WHERE id = $2
 	`
-	_, err := r.DB.Exec(query, filePath, millionaireID)
+
+	ctx, span := tracing.StartQuery(ctx, "PhotoRepo.UpdatePhotoPath", "UPDATE", query)
+	defer span.End()
+
+	_, err := r.conn.Writer(ctx).ExecContext(ctx, query, filePath, millionaireID)
 	if err != nil {
-		r.log.Error("Error updating millionaire photo path", logger.Err(err))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Error updating millionaire photo path", logger.Err(err))
 		return err
 	}
 	return nil
 }
 
-func (r *PhotoRepo) GetPhotoPath(millionaireID int) (string, error) {
+func (r *PhotoRepo) GetPhotoPath(ctx context.Context, millionaireID int) (string, error) {
+	defer metrics.ObserveQuery("photo", "GetPhotoPath", time.Now())
 	var photoPath string
-	query := `SELECT path_to_photo FROM millionaires WHERE id = $1`
-	err := r.DB.QueryRow(query, millionaireID).Scan(&photoPath)
+	query := `SELECT COALESCE(path_to_photo, '') FROM millionaires WHERE id = $1`
+
+	ctx, span := tracing.StartQuery(ctx, "PhotoRepo.GetPhotoPath", "SELECT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query, millionaireID).Scan(&photoPath)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return "", nil
 		}
-		r.log.Error("Error fetching photo path", logger.Err(err))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Error fetching photo path", logger.Err(err))
 		return "", err
 	}
 	return photoPath, nil
 }
 
-func (r *PhotoRepo) ClearPhotoPath(millionaireID int) error {
+// LockPhotoPath returns the stored photo path and locks the millionaire's row
+// until the surrounding transaction ends, so concurrent photo changes are
+// applied one after the other. It returns sql.ErrNoRows for an unknown ID.
+func (r *PhotoRepo) LockPhotoPath(ctx context.Context, millionaireID int) (string, error) {
+	defer metrics.ObserveQuery("photo", "LockPhotoPath", time.Now())
+	var photoPath string
+	query := `SELECT COALESCE(path_to_photo, '') FROM millionaires WHERE id = $1 FOR UPDATE`
+
+	ctx, span := tracing.StartQuery(ctx, "PhotoRepo.LockPhotoPath", "SELECT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query, millionaireID).Scan(&photoPath)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			tracing.RecordError(span, err)
+			r.log.ErrorContext(ctx, "Error locking photo path", logger.Err(err))
+		}
+		return "", err
+	}
+	return photoPath, nil
+}
+
+func (r *PhotoRepo) ClearPhotoPath(ctx context.Context, millionaireID int) error {
+	defer metrics.ObserveQuery("photo", "ClearPhotoPath", time.Now())
 	query := `UPDATE millionaires SET path_to_photo = NULL, updated_at = NOW() WHERE id = $1`
-	_, err := r.DB.Exec(query, millionaireID)
+
+	ctx, span := tracing.StartQuery(ctx, "PhotoRepo.ClearPhotoPath", "UPDATE", query)
+	defer span.End()
+
+	_, err := r.conn.Writer(ctx).ExecContext(ctx, query, millionaireID)
 	if err != nil {
-		r.log.Error("Error clearing photo path", logger.Err(err))
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Error clearing photo path", logger.Err(err))
 		return err
 	}
 	return nil
 }
+
+// ListPhotoPaths returns the stored photo path of every millionaire that has
+// one, keyed by millionaire ID.
+func (r *PhotoRepo) ListPhotoPaths(ctx context.Context) (map[int]string, error) {
+	defer metrics.ObserveQuery("photo", "ListPhotoPaths", time.Now())
+	query := `SELECT id, path_to_photo FROM millionaires WHERE path_to_photo IS NOT NULL AND path_to_photo <> ''`
+
+	ctx, span := tracing.StartQuery(ctx, "PhotoRepo.ListPhotoPaths", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Writer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Error listing photo paths", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	paths := make(map[int]string)
+	for rows.Next() {
+		var (
+			id   int
+			path string
+		)
+		if err := rows.Scan(&id, &path); err != nil {
+			return nil, err
+		}
+		paths[id] = path
+	}
+	return paths, rows.Err()
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/tracing"
+
+	"github.com/lib/pq"
+)
+
+// Rates keeps exchange rates as units of a currency per euro. The euro
+// itself is not stored.
+type Rates interface {
+	// Rate returns the rate in effect on a day (YYYY-MM-DD): the latest one
+	// published on or before it, or sql.ErrNoRows when there is none.
+	Rate(ctx context.Context, currency, on string) (money.Amount, error)
+	// Save adds rates, replacing those already stored for the same
+	// currency and day.
+	Save(ctx context.Context, rates []models.ExchangeRate) error
+}
+
+type RateRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+var _ Rates = (*RateRepo)(nil)
+
+// NewRateRepo sends Rate to conn's reader and Save to its writer.
+func NewRateRepo(conn Conn, log *slog.Logger) *RateRepo {
+	return &RateRepo{conn: conn, log: log}
+}
+
+func (r *RateRepo) Rate(ctx context.Context, currency, on string) (money.Amount, error) {
+	defer metrics.ObserveQuery("rate", "Rate", time.Now())
+	query := `
+		SELECT rate FROM exchange_rates
+		WHERE currency = $1 AND rate_date <= $2
+		ORDER BY rate_date DESC
+		LIMIT 1`
+
+	ctx, span := tracing.StartQuery(ctx, "RateRepo.Rate", "SELECT", query)
+	defer span.End()
+
+	var rate money.Amount
+	err := r.conn.Reader(ctx).QueryRowContext(ctx, query, currency, on).Scan(&rate)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	return rate, err
+}
+
+func (r *RateRepo) Save(ctx context.Context, rates []models.ExchangeRate) error {
+	defer metrics.ObserveQuery("rate", "Save", time.Now())
+	query := `
+		INSERT INTO exchange_rates (currency, rate_date, rate)
+		SELECT unnest($1::char(3)[]), unnest($2::date[]), unnest($3::numeric[])
+		ON CONFLICT (currency, rate_date) DO UPDATE SET rate = EXCLUDED.rate`
+
+	ctx, span := tracing.StartQuery(ctx, "RateRepo.Save", "INSERT", query)
+	defer span.End()
+
+	currencies := make([]string, len(rates))
+	dates := make([]string, len(rates))
+	values := make([]string, len(rates))
+	for i, rate := range rates {
+		currencies[i], dates[i], values[i] = rate.Currency, rate.Date, rate.Rate.String()
+	}
+
+	_, err := r.conn.Writer(ctx).ExecContext(ctx, query, pq.Array(currencies), pq.Array(dates), pq.Array(values))
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to save exchange rates", slog.Int("count", len(rates)), logger.Err(err))
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/tracing"
+)
+
+// Redirects remembers where merged millionaires went.
+type Redirects interface {
+	// Add redirects from, and every ID that redirected to from, to to.
+	Add(ctx context.Context, from, to int) error
+	// Resolve returns the ID from redirects to, or sql.ErrNoRows.
+	Resolve(ctx context.Context, from int) (int, error)
+}
+
+type RedirectRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+var _ Redirects = (*RedirectRepo)(nil)
+
+// NewRedirectRepo sends Resolve to conn's reader and Add to its writer.
+func NewRedirectRepo(conn Conn, log *slog.Logger) *RedirectRepo {
+	return &RedirectRepo{conn: conn, log: log}
+}
+
+// Add must run before from is deleted, whose redirects would go with it.
+func (r *RedirectRepo) Add(ctx context.Context, from, to int) error {
+	defer metrics.ObserveQuery("redirect", "Add", time.Now())
+	query := `
+		WITH repointed AS (
+			UPDATE millionaire_redirects SET new_id = $2 WHERE new_id = $1
+		)
+		INSERT INTO millionaire_redirects (old_id, new_id) VALUES ($1, $2)
+		ON CONFLICT (old_id) DO UPDATE SET new_id = EXCLUDED.new_id, created_at = NOW()`
+
+	ctx, span := tracing.StartQuery(ctx, "RedirectRepo.Add", "INSERT", query)
+	defer span.End()
+
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query, from, to); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to add redirect", slog.Int("from", from), slog.Int("to", to), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RedirectRepo) Resolve(ctx context.Context, from int) (int, error) {
+	defer metrics.ObserveQuery("redirect", "Resolve", time.Now())
+	query := `SELECT new_id FROM millionaire_redirects WHERE old_id = $1`
+
+	ctx, span := tracing.StartQuery(ctx, "RedirectRepo.Resolve", "SELECT", query)
+	defer span.End()
+
+	var to int
+	if err := r.conn.Reader(ctx).QueryRowContext(ctx, query, from).Scan(&to); err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	return to, nil
+}
@@ -0,0 +1,572 @@
+// Package repotest holds the behaviour every MillionaireRepository has to
+// share, so the in-memory repository can stand in for Postgres in tests:
+//
+//	func TestMemory(t *testing.T)   { repotest.MillionaireContract(t, repotest.Memory) }
+//	func TestPostgres(t *testing.T) { repotest.MillionaireContract(t, repotest.Postgres) }
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/repo"
+)
+
+// Factory returns an empty repository for one subtest.
+type Factory func(t *testing.T) repo.MillionaireRepository
+
+// MillionaireContract runs the shared MillionaireRepository checks against
+// repositories made by newRepo.
+func MillionaireContract(t *testing.T, newRepo Factory) {
+	for _, c := range []struct {
+		name string
+		run  func(t *testing.T, r repo.MillionaireRepository)
+	}{
+		{"CreateAndGetByID", testCreateAndGetByID},
+		{"ProfileFields", testProfileFields},
+		{"CreateRequiresNetWorth", testCreateRequiresNetWorth},
+		{"NetWorthIsExact", testNetWorthIsExact},
+		{"Valuation", testValuation},
+		{"GetByIDUnknown", testGetByIDUnknown},
+		{"Update", testUpdate},
+		{"Delete", testDelete},
+		{"GetAllPages", testGetAllPages},
+		{"NegativePage", testNegativePage},
+		{"Search", testSearch},
+		{"TopMillionaires", testTopMillionaires},
+		{"TopMillionairesCurrencies", testTopMillionairesCurrencies},
+		{"Newest", testNewest},
+		{"GetByIDs", testGetByIDs},
+		{"MoversWithoutHistory", testMoversWithoutHistory},
+		{"ConcurrentCreate", testConcurrentCreate},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepo(t))
+		})
+	}
+}
+
+// New returns a valid millionaire worth a whole amount; tests adjust the
+// fields they care about.
+func New(firstName, lastName string, netWorth int64) models.Millionaire {
+	return models.Millionaire{
+		FirstName: firstName,
+		LastName:  lastName,
+		NetWorth:  ptr(money.FromInt(netWorth)),
+		// The currency and day the service fills in when they are missing.
+		NetWorthCurrency: "USD",
+		NetWorthAsOf:     ptr("2025-06-30"),
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func create(t *testing.T, r repo.MillionaireRepository, ms ...models.Millionaire) []int {
+	t.Helper()
+	ids := make([]int, len(ms))
+	for i := range ms {
+		if err := r.Create(context.Background(), &ms[i]); err != nil {
+			t.Fatalf("Create(%s %s): %v", ms[i].FirstName, ms[i].LastName, err)
+		}
+		ids[i] = ms[i].ID
+	}
+	return ids
+}
+
+func ids(ms []models.Millionaire) []int {
+	out := make([]int, len(ms))
+	for i, m := range ms {
+		out[i] = m.ID
+	}
+	return out
+}
+
+func equalIDs(t *testing.T, what string, got, want []int) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%s: got IDs %v, want %v", what, got, want)
+	}
+}
+
+func str(p *string) string {
+	if p == nil {
+		return "<nil>"
+	}
+	return *p
+}
+
+func testCreateAndGetByID(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+
+	m := New("Ada", "Lovelace", 1_000_000)
+	m.MiddleName = ptr("King")
+	m.BirthPlace = ptr("London")
+	m.Company = ptr("Analytical Engines")
+	m.Industry = ptr("Technology")
+	m.Country = ptr("United Kingdom")
+	m.PathToPhoto = ptr("uploads/photos/1_abc.jpg")
+	other := New("Charles", "Babbage", 2_000_000)
+	created := create(t, r, m, other)
+
+	if created[0] <= 0 || created[1] <= created[0] {
+		t.Fatalf("Create: got IDs %v, want positive and increasing", created)
+	}
+
+	got, err := r.GetByID(ctx, created[0])
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.ID != created[0] || got.FirstName != "Ada" || got.LastName != "Lovelace" || got.NetWorth.Cmp(money.FromInt(1_000_000)) != 0 {
+		t.Errorf("GetByID: got %d %s %s %v", got.ID, got.FirstName, got.LastName, *got.NetWorth)
+	}
+	for name, pair := range map[string][2]*string{
+		"MiddleName":  {got.MiddleName, m.MiddleName},
+		"BirthPlace":  {got.BirthPlace, m.BirthPlace},
+		"Company":     {got.Company, m.Company},
+		"Industry":    {got.Industry, m.Industry},
+		"Country":     {got.Country, m.Country},
+		"PathToPhoto": {got.PathToPhoto, m.PathToPhoto},
+	} {
+		if str(pair[0]) != str(pair[1]) {
+			t.Errorf("GetByID: %s = %q, want %q", name, str(pair[0]), str(pair[1]))
+		}
+	}
+	if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("GetByID: timestamps not set: %v %v", got.CreatedAt, got.UpdatedAt)
+	}
+
+	// The stored row must not follow later changes to the caller's value.
+	*m.Country = "France"
+	if got, _ := r.GetByID(ctx, created[0]); str(got.Country) != "United Kingdom" {
+		t.Errorf("stored row changed through the caller's pointer: Country = %q", str(got.Country))
+	}
+}
+
+func testProfileFields(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+
+	m := New("Grace", "Hopper", 3_000_000)
+	m.Biography = ptr("Rear admiral and *compiler* pioneer.")
+	m.Education = ptr("Yale University")
+	m.Citizenships = []string{"United States", "Canada"}
+	m.MaritalStatus = ptr("divorced")
+	m.ChildrenCount = ptr(0)
+	m.ResidenceCity = ptr("Arlington")
+	m.Website = ptr("https://example.com/hopper")
+	m.SocialHandles = map[string]string{"x": "@grace", "github": "ghopper"}
+	m.BiographyHTML = "<p>not stored</p>"
+	id := create(t, r, m)[0]
+
+	got, err := r.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	for name, pair := range map[string][2]*string{
+		"Biography":     {got.Biography, m.Biography},
+		"Education":     {got.Education, m.Education},
+		"MaritalStatus": {got.MaritalStatus, m.MaritalStatus},
+		"ResidenceCity": {got.ResidenceCity, m.ResidenceCity},
+		"Website":       {got.Website, m.Website},
+	} {
+		if str(pair[0]) != str(pair[1]) {
+			t.Errorf("GetByID: %s = %q, want %q", name, str(pair[0]), str(pair[1]))
+		}
+	}
+	if got.ChildrenCount == nil || *got.ChildrenCount != 0 {
+		t.Errorf("GetByID: ChildrenCount = %v, want 0", got.ChildrenCount)
+	}
+	if fmt.Sprint(got.Citizenships) != fmt.Sprint(m.Citizenships) {
+		t.Errorf("GetByID: Citizenships = %v, want %v", got.Citizenships, m.Citizenships)
+	}
+	if fmt.Sprint(got.SocialHandles) != fmt.Sprint(m.SocialHandles) {
+		t.Errorf("GetByID: SocialHandles = %v, want %v", got.SocialHandles, m.SocialHandles)
+	}
+	if got.BiographyHTML != "" {
+		t.Errorf("GetByID: BiographyHTML = %q, want it left to the service", got.BiographyHTML)
+	}
+
+	all, err := r.GetAll(ctx, 1, 10)
+	if err != nil || len(all.Millionaires) != 1 {
+		t.Fatalf("GetAll: %+v, %v", all, err)
+	}
+	found, err := r.Search(ctx, repo.MillionaireFilter{LastName: "Hopper"}, 1, 10)
+	if err != nil || len(found.Millionaires) != 1 {
+		t.Fatalf("Search: %+v, %v", found, err)
+	}
+	for name, listed := range map[string]models.Millionaire{"GetAll": all.Millionaires[0], "Search": found.Millionaires[0]} {
+		if listed.Biography != nil || listed.Education != nil {
+			t.Errorf("%s: Biography %q and Education %q, want them left out", name, str(listed.Biography), str(listed.Education))
+		}
+		if str(listed.Website) != str(m.Website) || len(listed.Citizenships) != 2 {
+			t.Errorf("%s: Website %q and Citizenships %v, want the rest of the profile", name, str(listed.Website), listed.Citizenships)
+		}
+	}
+
+	got.Citizenships, got.SocialHandles, got.Biography = nil, nil, nil
+	if err := r.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, _ = r.GetByID(ctx, id)
+	if len(got.Citizenships) != 0 || len(got.SocialHandles) != 0 || got.Biography != nil {
+		t.Errorf("Update did not clear profile fields: %v %v %q", got.Citizenships, got.SocialHandles, str(got.Biography))
+	}
+
+	m.ChildrenCount = ptr(-1)
+	if err := r.Create(ctx, &m); err == nil {
+		t.Errorf("Create with a negative children count: no error")
+	}
+}
+
+func testCreateRequiresNetWorth(t *testing.T, r repo.MillionaireRepository) {
+	m := New("No", "Worth", 0)
+	m.NetWorth = nil
+	if err := r.Create(context.Background(), &m); err == nil {
+		t.Error("Create without net worth: want an error")
+	}
+}
+
+func testNetWorthIsExact(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+	for _, v := range []string{"1234.50", "-0.05", "0.000", "123456789012345678901234567890.12"} {
+		m := New("Exact", "Al", 0)
+		m.NetWorth = ptr(money.MustParse(v))
+		if err := r.Create(ctx, &m); err != nil {
+			t.Fatalf("Create(%s): %v", v, err)
+		}
+		got, err := r.GetByID(ctx, m.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.NetWorth.String() != v {
+			t.Errorf("net worth %s read back as %s", v, got.NetWorth)
+		}
+	}
+}
+
+func testValuation(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+	m := New("Tenge", "Holder", 5_000_000_000)
+	m.NetWorthCurrency, m.NetWorthAsOf = "KZT", ptr("2024-03-01")
+	id := create(t, r, m)[0]
+
+	got, err := r.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.NetWorthCurrency != "KZT" || str(got.NetWorthAsOf) != "2024-03-01" {
+		t.Errorf("GetByID: got valuation in %q on %q, want KZT on 2024-03-01", got.NetWorthCurrency, str(got.NetWorthAsOf))
+	}
+
+	for name, change := range map[string]func(*models.Millionaire){
+		"lower-case currency": func(m *models.Millionaire) { m.NetWorthCurrency = "kzt" },
+		"no currency":         func(m *models.Millionaire) { m.NetWorthCurrency = "" },
+		"no day":              func(m *models.Millionaire) { m.NetWorthAsOf = nil },
+		"invalid day":         func(m *models.Millionaire) { m.NetWorthAsOf = ptr("2024-02-30") },
+	} {
+		m := New("Bad", "Valuation", 1)
+		change(&m)
+		if err := r.Create(ctx, &m); err == nil {
+			t.Errorf("Create with %s: want an error", name)
+		}
+	}
+}
+
+func testGetByIDUnknown(t *testing.T, r repo.MillionaireRepository) {
+	_, err := r.GetByID(context.Background(), 424242)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetByID(unknown): got %v, want sql.ErrNoRows", err)
+	}
+}
+
+func testUpdate(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+	id := create(t, r, New("Grace", "Hopper", 10))[0]
+
+	before, err := r.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	changed := New("Grace", "Hopper", 20)
+	changed.ID = id
+	changed.Country = ptr("USA")
+	if err := r.Update(ctx, &changed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	after, err := r.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if after.NetWorth.String() != "20" || str(after.Country) != "USA" {
+		t.Errorf("Update: got net worth %v, country %q", *after.NetWorth, str(after.Country))
+	}
+	if !after.CreatedAt.Equal(before.CreatedAt) {
+		t.Errorf("Update changed CreatedAt from %v to %v", before.CreatedAt, after.CreatedAt)
+	}
+	if after.UpdatedAt.Before(before.UpdatedAt) {
+		t.Errorf("Update moved UpdatedAt back from %v to %v", before.UpdatedAt, after.UpdatedAt)
+	}
+
+	unknown := New("Nobody", "Here", 1)
+	unknown.ID = id + 1000
+	if err := r.Update(ctx, &unknown); err != nil {
+		t.Errorf("Update(unknown): %v, want no error", err)
+	}
+	if _, err := r.GetByID(ctx, unknown.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Update(unknown) created a row: %v", err)
+	}
+}
+
+func testDelete(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+	created := create(t, r, New("A", "One", 1), New("B", "Two", 2))
+
+	if err := r.Delete(ctx, created[0]); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := r.GetByID(ctx, created[0]); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetByID after Delete: got %v, want sql.ErrNoRows", err)
+	}
+	if err := r.Delete(ctx, created[0]); err != nil {
+		t.Errorf("Delete twice: %v, want no error", err)
+	}
+
+	all, err := r.GetAll(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	equalIDs(t, "GetAll after Delete", ids(all.Millionaires), created[1:])
+}
+
+func testGetAllPages(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+
+	ms := make([]models.Millionaire, 25)
+	for i := range ms {
+		ms[i] = New(fmt.Sprintf("First%02d", i), fmt.Sprintf("Last%02d", i), int64(i))
+	}
+	created := create(t, r, ms...)
+
+	for _, c := range []struct {
+		page, size int
+		want       []int
+	}{
+		{1, 10, created[0:10]},
+		{2, 10, created[10:20]},
+		{3, 10, created[20:25]},
+		{4, 10, nil},
+		{1, 0, nil},
+		{1, 100, created},
+	} {
+		result, err := r.GetAll(ctx, c.page, c.size)
+		if err != nil {
+			t.Fatalf("GetAll(%d, %d): %v", c.page, c.size, err)
+		}
+		what := fmt.Sprintf("GetAll(%d, %d)", c.page, c.size)
+		equalIDs(t, what, ids(result.Millionaires), c.want)
+		if result.Total != 25 || result.Page != c.page || result.PageSize != c.size {
+			t.Errorf("%s: got total %d, page %d, size %d", what, result.Total, result.Page, result.PageSize)
+		}
+		if len(c.want) == 0 && result.Millionaires != nil {
+			t.Errorf("%s: empty page should be nil, got %v", what, result.Millionaires)
+		}
+	}
+}
+
+func testNegativePage(t *testing.T, r repo.MillionaireRepository) {
+	create(t, r, New("A", "One", 1))
+	if _, err := r.GetAll(context.Background(), 0, 10); err == nil {
+		t.Error("GetAll(page 0): want an error for the negative offset")
+	}
+}
+
+func testSearch(t *testing.T, r repo.MillionaireRepository) {
+	ctx := context.Background()
+
+	smith := New("John", "Smith", 1)
+	smith.Country, smith.CountryCode = ptr("United States"), ptr("US")
+	smith.Industry, smith.IndustryCode = ptr("Software"), ptr("technology.software")
+	smithson := New("Joan", "Smithson", 2)
+	smithson.MiddleName = ptr("Q")
+	smithson.Country, smithson.CountryCode = ptr("united kingdom"), ptr("GB")
+	smithson.Industry, smithson.IndustryCode = ptr("Technology"), ptr("technology")
+	blacksmith := New("Jo", "Blacksmith", 3)
+	blacksmith.Industry = ptr("Tech-adjacent")
+	doe := New("Jane", "Doe", 4)
+	doe.Country, doe.CountryCode = ptr("United States"), ptr("US")
+	created := create(t, r, smith, smithson, blacksmith, doe)
+
+	for _, c := range []struct {
+		filter repo.MillionaireFilter
+		want   []int
+	}{
+		{repo.MillionaireFilter{}, created},
+		{repo.MillionaireFilter{LastName: "smith"}, created[0:3]},
+		{repo.MillionaireFilter{LastName: "SMITH", FirstName: "jo"}, created[0:3]},
+		{repo.MillionaireFilter{LastName: "smith", Country: "united"}, created[0:2]},
+		{repo.MillionaireFilter{Country: "States"}, []int{created[0], created[3]}},
+		{repo.MillionaireFilter{MiddleName: "q"}, created[1:2]},
+		{repo.MillionaireFilter{FirstName: "Jo_n"}, created[0:2]},
+		{repo.MillionaireFilter{Industry: "tech"}, created[1:3]},
+		{repo.MillionaireFilter{CountryCode: "US"}, []int{created[0], created[3]}},
+		{repo.MillionaireFilter{IndustryCode: "technology"}, created[0:2]},
+		{repo.MillionaireFilter{IndustryCode: "technology.software"}, created[0:1]},
+		{repo.MillionaireFilter{IndustryCode: "technology", CountryCode: "GB"}, created[1:2]},
+		{repo.MillionaireFilter{CountryCodes: []string{"GB", "US"}}, []int{created[0], created[1], created[3]}},
+		{repo.MillionaireFilter{IndustryCodes: []string{"finance", "technology.software"}}, created[0:1]},
+		{repo.MillionaireFilter{LastName: "nobody"}, nil},
+	} {
+		result, err := r.Search(ctx, c.filter, 1, 10)
+		if err != nil {
+			t.Fatalf("Search(%+v): %v", c.filter, err)
+		}
+		what := fmt.Sprintf("Search(%+v)", c.filter)
+		equalIDs(t, what, ids(result.Millionaires), c.want)
+		if result.Total != len(c.want) {
+			t.Errorf("%s: total %d, want %d", what, result.Total, len(c.want))
+		}
+
+		all, err := r.SearchAll(ctx, c.filter)
+		if err != nil {
+			t.Fatalf("SearchAll(%+v): %v", c.filter, err)
+		}
+		equalIDs(t, fmt.Sprintf("SearchAll(%+v)", c.filter), ids(all), c.want)
+	}
+
+	result, err := r.Search(ctx, repo.MillionaireFilter{LastName: "smith"}, 2, 2)
+	if err != nil {
+		t.Fatalf("Search page 2: %v", err)
+	}
+	equalIDs(t, "Search page 2", ids(result.Millionaires), created[2:3])
+	if result.Total != 3 {
+		t.Errorf("Search page 2: total %d, want 3", result.Total)
+	}
+}
+
+func testTopMillionaires(t *testing.T, r repo.MillionaireRepository) {
+	ms := make([]models.Millionaire, 12)
+	for i := range ms {
+		ms[i] = New(fmt.Sprintf("Rich%02d", i), "Person", int64(100*(i%6)))
+	}
+	ms[4].CountryCode = ptr("KZ")
+	ms[9].CountryCode = ptr("KZ")
+	created := create(t, r, ms...)
+
+	top, err := r.Top(context.Background(), repo.MillionaireFilter{}, 10)
+	if err != nil {
+		t.Fatalf("Top: %v", err)
+	}
+	// Net worths repeat every six rows; ties are broken by ID.
+	want := []int{created[5], created[11], created[4], created[10], created[3], created[9], created[2], created[8], created[1], created[7]}
+	equalIDs(t, "Top", ids(top), want)
+
+	top, err = r.Top(context.Background(), repo.MillionaireFilter{CountryCodes: []string{"KZ", "GB"}}, 1)
+	if err != nil {
+		t.Fatalf("Top in KZ: %v", err)
+	}
+	equalIDs(t, "Top in KZ", ids(top), created[4:5])
+}
+
+func testTopMillionairesCurrencies(t *testing.T, r repo.MillionaireRepository) {
+	tenge := New("Tenge", "Holder", 5_000_000_000)
+	tenge.NetWorthCurrency = "KZT"
+	created := create(t, r, New("Small", "Dollar", 100), tenge, New("Big", "Dollar", 200))
+
+	top, err := r.Top(context.Background(), repo.MillionaireFilter{}, 10)
+	if err != nil {
+		t.Fatalf("Top: %v", err)
+	}
+
+	// Without exchange rates the tenge valuation cannot be ranked.
+	equalIDs(t, "Top", ids(top), []int{created[2], created[0], created[1]})
+}
+
+func testNewest(t *testing.T, r repo.MillionaireRepository) {
+	first := New("First", "Added", 1)
+	first.IndustryCode = ptr("technology.software")
+	created := create(t, r, first, New("Second", "Added", 2), New("Third", "Added", 3))
+
+	newest, err := r.Newest(context.Background(), repo.MillionaireFilter{}, 2)
+	if err != nil {
+		t.Fatalf("Newest: %v", err)
+	}
+	equalIDs(t, "Newest", ids(newest), []int{created[2], created[1]})
+
+	newest, err = r.Newest(context.Background(), repo.MillionaireFilter{IndustryCodes: []string{"finance", "technology"}}, 2)
+	if err != nil {
+		t.Fatalf("Newest in technology: %v", err)
+	}
+	equalIDs(t, "Newest in technology", ids(newest), created[0:1])
+}
+
+func testGetByIDs(t *testing.T, r repo.MillionaireRepository) {
+	created := create(t, r, New("A", "Featured", 1), New("B", "Featured", 2), New("C", "Featured", 3))
+
+	got, err := r.GetByIDs(context.Background(), []int{created[2], 424242, created[0]})
+	if err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	equalIDs(t, "GetByIDs", ids(got), []int{created[2], created[0]})
+}
+
+func testMoversWithoutHistory(t *testing.T, r repo.MillionaireRepository) {
+	create(t, r, New("No", "History", 1))
+
+	for _, gainers := range []bool{true, false} {
+		movers, err := r.Movers(context.Background(), repo.MillionaireFilter{}, "2025-01-01", 10, gainers)
+		if err != nil {
+			t.Fatalf("Movers(gainers=%v): %v", gainers, err)
+		}
+		if len(movers) != 0 {
+			t.Errorf("Movers(gainers=%v): %d entries without history", gainers, len(movers))
+		}
+	}
+}
+
+func testConcurrentCreate(t *testing.T, r repo.MillionaireRepository) {
+	const n = 20
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := New(fmt.Sprintf("C%02d", i), "Concurrent", int64(i))
+			if err := r.Create(ctx, &m); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := r.GetAll(ctx, 1, n); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent Create: %v", err)
+	}
+
+	all, err := r.GetAll(ctx, 1, 2*n)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	seen := make(map[int]bool)
+	for _, m := range all.Millionaires {
+		if seen[m.ID] {
+			t.Errorf("ID %d assigned twice", m.ID)
+		}
+		seen[m.ID] = true
+	}
+	if all.Total != n || len(seen) != n {
+		t.Errorf("got %d millionaires (%d distinct IDs), want %d", all.Total, len(seen), n)
+	}
+}
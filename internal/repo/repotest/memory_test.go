@@ -0,0 +1,8 @@
+package repotest_test
+
+import (
+	"testing"
+	"wealthlist/internal/repo/repotest"
+)
+
+func TestMemory(t *testing.T) { repotest.MillionaireContract(t, repotest.Memory) }
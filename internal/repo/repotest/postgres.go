@@ -0,0 +1,70 @@
+package repotest
+
+import (
+	"database/sql"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"wealthlist/internal/repo"
+	"wealthlist/migrations"
+
+	_ "github.com/lib/pq"
+)
+
+// DatabaseURLEnv names the variable that points the suites at a Postgres
+// database. Its tables are emptied by every test, so never use a database
+// holding data you care about.
+const DatabaseURLEnv = "TEST_DATABASE_URL"
+
+var (
+	dbOnce sync.Once
+	testDB *sql.DB
+	dbErr  error
+)
+
+// DB returns the migrated test database, skipping t when DatabaseURLEnv is
+// not set.
+func DB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	url := os.Getenv(DatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", DatabaseURLEnv)
+	}
+
+	dbOnce.Do(func() {
+		if testDB, dbErr = sql.Open("postgres", url); dbErr != nil {
+			return
+		}
+		if dbErr = testDB.Ping(); dbErr != nil {
+			return
+		}
+		dbErr = migrations.RunMigrationUp(testDB)
+	})
+	if dbErr != nil {
+		t.Fatalf("test database: %v", dbErr)
+	}
+	return testDB
+}
+
+// Postgres returns the Postgres repository on an emptied test database. Like
+// the memory repository, it has no exchange rates.
+func Postgres(t *testing.T) repo.MillionaireRepository {
+	db := DB(t)
+	if _, err := db.Exec(`TRUNCATE millionaires, exchange_rates RESTART IDENTITY CASCADE`); err != nil {
+		t.Fatalf("truncate millionaires: %v", err)
+	}
+	return repo.NewMillionaireRepo(repo.Direct(db), Logger())
+}
+
+// Memory returns an empty in-memory repository.
+func Memory(*testing.T) repo.MillionaireRepository {
+	return repo.NewMemoryMillionaireRepo()
+}
+
+// Logger discards everything; test output stays readable.
+func Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
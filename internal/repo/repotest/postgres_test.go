@@ -0,0 +1,54 @@
+package repotest_test
+
+import (
+	"context"
+	"testing"
+	"wealthlist/internal/models"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/repo/repotest"
+)
+
+// TestPostgres is skipped unless TEST_DATABASE_URL points at a scratch
+// database.
+func TestPostgres(t *testing.T) { repotest.MillionaireContract(t, repotest.Postgres) }
+
+// TestPostgresSeedReset checks that a reset does not leave featured sections
+// pointing at IDs the next millionaires are given.
+func TestPostgresSeedReset(t *testing.T) {
+	millionaires := repotest.Postgres(t)
+	db := repotest.DB(t)
+	ctx := context.Background()
+
+	first := repotest.New("Featured", "Before", 1000)
+	if err := millionaires.Create(ctx, &first); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	sections := repo.NewHomeSectionRepo(repo.Direct(db), repotest.Logger())
+	featured := models.HomeSection{Kind: "featured", Title: "Featured", Size: 10, MillionaireIDs: []int{first.ID}}
+	if err := sections.Create(ctx, &featured); err != nil {
+		t.Fatalf("create section: %v", err)
+	}
+	t.Cleanup(func() { sections.Delete(ctx, featured.ID) })
+
+	err := repo.NewUnitOfWork(db, repotest.Logger()).WithTx(ctx, func(tx repo.Repos) error {
+		return tx.Seed.DeleteAll(ctx)
+	})
+	if err != nil {
+		t.Fatalf("delete all: %v", err)
+	}
+
+	second := repotest.New("Someone", "Else", 1000)
+	if err := millionaires.Create(ctx, &second); err != nil {
+		t.Fatalf("create after reset: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Fatalf("ID after reset: %d, want the restarted %d", second.ID, first.ID)
+	}
+	got, err := sections.GetByID(ctx, featured.ID)
+	if err != nil {
+		t.Fatalf("get section: %v", err)
+	}
+	if len(got.MillionaireIDs) != 0 {
+		t.Errorf("featured after reset: %v, want none", got.MillionaireIDs)
+	}
+}
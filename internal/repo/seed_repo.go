@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/tracing"
+)
+
+// MillionaireKey identifies a person independently of the generated ID.
+type MillionaireKey struct {
+	LastName  string
+	FirstName string
+	BirthDate string
+}
+
+// Seed holds the bulk operations of `wealthlist seed`.
+type Seed interface {
+	// Keys returns the key of every stored millionaire; birth dates are
+	// formatted as YYYY-MM-DD and empty when unknown.
+	Keys(ctx context.Context) (map[MillionaireKey]bool, error)
+	// DeleteAll removes every millionaire. Run it in a transaction.
+	DeleteAll(ctx context.Context) error
+}
+
+// SeedRepo is the Postgres Seed.
+type SeedRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+var _ Seed = (*SeedRepo)(nil)
+
+func NewSeedRepo(conn Conn, log *slog.Logger) *SeedRepo {
+	return &SeedRepo{conn: conn, log: log}
+}
+
+// Keys returns the key of every stored millionaire; birth dates are
+// formatted as YYYY-MM-DD and empty when unknown.
+func (r *SeedRepo) Keys(ctx context.Context) (map[MillionaireKey]bool, error) {
+	defer metrics.ObserveQuery("seed", "Keys", time.Now())
+	query := `SELECT last_name, first_name, COALESCE(to_char(birth_date, 'YYYY-MM-DD'), '') FROM millionaires`
+
+	ctx, span := tracing.StartQuery(ctx, "SeedRepo.Keys", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Writer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list millionaire keys", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make(map[MillionaireKey]bool)
+	for rows.Next() {
+		var k MillionaireKey
+		if err := rows.Scan(&k.LastName, &k.FirstName, &k.BirthDate); err != nil {
+			return nil, err
+		}
+		keys[k] = true
+	}
+	return keys, rows.Err()
+}
+
+// DeleteAll removes every millionaire with their history, holdings and
+// redirects and restarts the ID sequences. Companies and homepage sections
+// are kept, but featured sections are emptied so they do not list whoever
+// gets the reused IDs. Run it in a transaction.
+func (r *SeedRepo) DeleteAll(ctx context.Context) error {
+	defer metrics.ObserveQuery("seed", "DeleteAll", time.Now())
+	query := `TRUNCATE millionaires, net_worth_history, holdings, millionaire_redirects RESTART IDENTITY`
+
+	ctx, span := tracing.StartQuery(ctx, "SeedRepo.DeleteAll", "TRUNCATE", query)
+	defer span.End()
+
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to delete millionaires", logger.Err(err))
+		return err
+	}
+
+	query = `UPDATE home_sections SET millionaire_ids = '{}', updated_at = NOW() WHERE millionaire_ids <> '{}'`
+	if _, err := r.conn.Writer(ctx).ExecContext(ctx, query); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to clear featured millionaires", logger.Err(err))
+		return err
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package repo
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+)
 
 func BuildWhereClause(filter MillionaireFilter) (string, []interface{}) {
 	var where string
@@ -23,6 +27,32 @@ func BuildWhereClause(filter MillionaireFilter) (string, []interface{}) {
 		conditions = append(conditions, fmt.Sprintf("country ILIKE $%d", len(args)+1))
 		args = append(args, "%"+filter.Country+"%")
 	}
+	if filter.Industry != "" {
+		conditions = append(conditions, fmt.Sprintf("industry ILIKE $%d", len(args)+1))
+		args = append(args, "%"+filter.Industry+"%")
+	}
+	if filter.CountryCode != "" {
+		conditions = append(conditions, fmt.Sprintf("country_code = $%d", len(args)+1))
+		args = append(args, filter.CountryCode)
+	}
+	if filter.IndustryCode != "" {
+		n := len(args) + 1
+		conditions = append(conditions, fmt.Sprintf("(industry_code = $%d OR industry_code LIKE $%d)", n, n+1))
+		args = append(args, filter.IndustryCode, filter.IndustryCode+".%")
+	}
+	if len(filter.CountryCodes) > 0 {
+		conditions = append(conditions, fmt.Sprintf("country_code = ANY($%d)", len(args)+1))
+		args = append(args, pq.Array(filter.CountryCodes))
+	}
+	if len(filter.IndustryCodes) > 0 {
+		prefixes := make([]string, len(filter.IndustryCodes))
+		for i, code := range filter.IndustryCodes {
+			prefixes[i] = code + ".%"
+		}
+		n := len(args) + 1
+		conditions = append(conditions, fmt.Sprintf("(industry_code = ANY($%d) OR industry_code LIKE ANY($%d))", n, n+1))
+		args = append(args, pq.Array(filter.IndustryCodes), pq.Array(prefixes))
+	}
 
 	if len(conditions) > 0 {
 		where = " WHERE " + JoinConditions(conditions, " AND ")
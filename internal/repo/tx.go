@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/tracing"
+)
+
+// Transactor runs fn atomically: every statement issued through the Repos
+// passed to fn is committed together or not at all.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(tx Repos) error) error
+}
+
+// Repos are repository instances bound to one transaction. Calling WithTx
+// on them nests fn in a savepoint, so a failing inner step can be rolled
+// back without aborting the outer transaction.
+type Repos struct {
+	Millionaires MillionaireRepository
+	Photos       *PhotoRepo
+	Users        *UserRepo
+	Companies    Companies
+	Holdings     Holdings
+	History      History
+	HomeSections *HomeSectionRepo
+	Redirects    *RedirectRepo
+	Audit        *AuditRepo
+	Seed         Seed
+
+	tx  *txConn
+	log *slog.Logger
+}
+
+// txConn sends reads and writes to the transaction; replicas would not see
+// its uncommitted changes.
+type txConn struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+func (c *txConn) Writer(context.Context) Querier { return c.tx }
+func (c *txConn) Reader(context.Context) Querier { return c.tx }
+
+// UnitOfWork starts transactions on the primary.
+type UnitOfWork struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+var (
+	_ Transactor = (*UnitOfWork)(nil)
+	_ Transactor = Repos{}
+)
+
+func NewUnitOfWork(db *sql.DB, log *slog.Logger) *UnitOfWork {
+	return &UnitOfWork{db: db, log: log}
+}
+
+// WithTx begins a transaction, passes repositories bound to it to fn and
+// commits when fn returns nil. It rolls back on an error or a panic, which
+// is re-raised.
+func (u *UnitOfWork) WithTx(ctx context.Context, fn func(tx Repos) error) (err error) {
+	defer metrics.ObserveQuery("tx", "WithTx", time.Now())
+	ctx, span := tracing.Start(ctx, "UnitOfWork.WithTx")
+	defer span.End()
+
+	sqlTx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		tracing.RecordError(span, err)
+		u.log.ErrorContext(ctx, "Error beginning transaction", logger.Err(err))
+		return err
+	}
+
+	conn := &txConn{tx: sqlTx}
+	defer func() {
+		if p := recover(); p != nil {
+			sqlTx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tracing.RecordError(span, err)
+			if rbErr := sqlTx.Rollback(); rbErr != nil {
+				u.log.ErrorContext(ctx, "Error rolling back transaction", logger.Err(rbErr))
+			}
+			return
+		}
+		if err = sqlTx.Commit(); err != nil {
+			tracing.RecordError(span, err)
+			u.log.ErrorContext(ctx, "Error committing transaction", logger.Err(err))
+		}
+	}()
+
+	return fn(newRepos(conn, u.log))
+}
+
+// WithTx runs fn inside a savepoint of the current transaction. An error
+// from fn rolls back to the savepoint and is returned; the outer
+// transaction stays usable.
+func (r Repos) WithTx(ctx context.Context, fn func(tx Repos) error) (err error) {
+	if r.tx == nil {
+		return errors.New("repo: Repos used outside a transaction")
+	}
+
+	r.tx.savepoints++
+	name := fmt.Sprintf("sp_%d", r.tx.savepoints)
+
+	ctx, span := tracing.Start(ctx, "Repos.WithTx")
+	defer span.End()
+
+	if _, err := r.tx.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Error creating savepoint", logger.Err(err))
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			r.tx.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+		if err != nil {
+			tracing.RecordError(span, err)
+			if _, rbErr := r.tx.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+				r.log.ErrorContext(ctx, "Error rolling back to savepoint", logger.Err(rbErr))
+			}
+			return
+		}
+		if _, err = r.tx.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+			tracing.RecordError(span, err)
+			r.log.ErrorContext(ctx, "Error releasing savepoint", logger.Err(err))
+		}
+	}()
+
+	return fn(r)
+}
+
+func newRepos(conn *txConn, log *slog.Logger) Repos {
+	return Repos{
+		Millionaires: NewMillionaireRepo(conn, log),
+		Photos:       NewPhotoRepo(conn, log),
+		Users:        NewUserRepo(conn, log),
+		Companies:    NewCompanyRepo(conn, log),
+		Holdings:     NewHoldingRepo(conn, log),
+		History:      NewHistoryRepo(conn, log),
+		HomeSections: NewHomeSectionRepo(conn, log),
+		Redirects:    NewRedirectRepo(conn, log),
+		Audit:        NewAuditRepo(conn, log),
+		Seed:         NewSeedRepo(conn, log),
+		tx:           conn,
+		log:          log,
+	}
+}
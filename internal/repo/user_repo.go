@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("user already exists")
+)
+
+// uniqueViolation is the PostgreSQL error code for a duplicate key.
+const uniqueViolation = "23505"
+
+type UserRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+// NewUserRepo always uses conn's writer so that password changes apply
+// immediately.
+func NewUserRepo(conn Conn, log *slog.Logger) *UserRepo {
+	return &UserRepo{conn: conn, log: log}
+}
+
+func (r *UserRepo) Create(ctx context.Context, u *models.User) error {
+	defer metrics.ObserveQuery("user", "Create", time.Now())
+	query := `
+		INSERT INTO users (username, password_hash, role, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	ctx, span := tracing.StartQuery(ctx, "UserRepo.Create", "INSERT", query)
+	defer span.End()
+
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query, u.Username, u.PasswordHash, u.Role).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrUserExists
+		}
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to create user", logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	defer metrics.ObserveQuery("user", "GetByUsername", time.Now())
+	query := `SELECT id, username, password_hash, role, created_at, updated_at FROM users WHERE username = $1`
+
+	ctx, span := tracing.StartQuery(ctx, "UserRepo.GetByUsername", "SELECT", query)
+	defer span.End()
+
+	u := &models.User{}
+	err := r.conn.Writer(ctx).QueryRowContext(ctx, query, username).Scan(
+		&u.ID, &u.Username, &u.PasswordHash, &u.Role, &u.CreatedAt, &u.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to fetch user", logger.Err(err))
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *UserRepo) UpdatePassword(ctx context.Context, username, passwordHash string) error {
+	defer metrics.ObserveQuery("user", "UpdatePassword", time.Now())
+	query := `UPDATE users SET password_hash = $1, updated_at = NOW() WHERE username = $2`
+
+	ctx, span := tracing.StartQuery(ctx, "UserRepo.UpdatePassword", "UPDATE", query)
+	defer span.End()
+
+	res, err := r.conn.Writer(ctx).ExecContext(ctx, query, passwordHash, username)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to update password", logger.Err(err))
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
+)
+
+// Vocabulary finds the code of a country or an industry from a code or a
+// name in any known language, ignoring case. Values that match nothing, or
+// more than one entry, resolve to "".
+type Vocabulary interface {
+	ResolveCountry(ctx context.Context, value string) (string, error)
+	ResolveIndustry(ctx context.Context, value string) (string, error)
+}
+
+type VocabularyRepo struct {
+	conn Conn
+	log  *slog.Logger
+}
+
+var _ Vocabulary = (*VocabularyRepo)(nil)
+
+// NewVocabularyRepo only reads, so every query goes to conn's reader.
+func NewVocabularyRepo(conn Conn, log *slog.Logger) *VocabularyRepo {
+	return &VocabularyRepo{conn: conn, log: log}
+}
+
+func (r *VocabularyRepo) ResolveCountry(ctx context.Context, value string) (string, error) {
+	defer metrics.ObserveQuery("vocabulary", "ResolveCountry", time.Now())
+	return r.resolve(ctx, "VocabularyRepo.ResolveCountry", `SELECT code FROM country_lookup WHERE key = $1`, value)
+}
+
+func (r *VocabularyRepo) ResolveIndustry(ctx context.Context, value string) (string, error) {
+	defer metrics.ObserveQuery("vocabulary", "ResolveIndustry", time.Now())
+	return r.resolve(ctx, "VocabularyRepo.ResolveIndustry", `SELECT code FROM industry_lookup WHERE key = $1`, value)
+}
+
+func (r *VocabularyRepo) resolve(ctx context.Context, name, query, value string) (string, error) {
+	ctx, span := tracing.StartQuery(ctx, name, "SELECT", query)
+	defer span.End()
+
+	var code string
+	err := r.conn.Reader(ctx).QueryRowContext(ctx, query, strings.ToLower(strings.TrimSpace(value))).Scan(&code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to resolve vocabulary value", slog.String("value", value), logger.Err(err))
+		return "", err
+	}
+	return code, nil
+}
+
+// Countries lists all countries ordered by name, named in lang where a
+// translation exists and in English otherwise.
+func (r *VocabularyRepo) Countries(ctx context.Context, lang string) ([]models.Country, error) {
+	defer metrics.ObserveQuery("vocabulary", "Countries", time.Now())
+	query := `
+		SELECT c.code, c.alpha3, COALESCE(n.name, c.name) AS name
+		FROM countries c
+		LEFT JOIN country_names n ON n.country_code = c.code AND n.lang = $1
+		ORDER BY name, c.code`
+
+	ctx, span := tracing.StartQuery(ctx, "VocabularyRepo.Countries", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, lang)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list countries", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var countries []models.Country
+	for rows.Next() {
+		var c models.Country
+		if err := rows.Scan(&c.Code, &c.Alpha3, &c.Name); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		countries = append(countries, c)
+	}
+	return countries, rows.Err()
+}
+
+// Country returns a country with all its translations, or sql.ErrNoRows.
+func (r *VocabularyRepo) Country(ctx context.Context, code string) (*models.Country, error) {
+	defer metrics.ObserveQuery("vocabulary", "Country", time.Now())
+	query := `
+		SELECT c.code, c.alpha3, c.name, n.lang, n.name
+		FROM countries c
+		LEFT JOIN country_names n ON n.country_code = c.code
+		WHERE c.code = $1`
+
+	ctx, span := tracing.StartQuery(ctx, "VocabularyRepo.Country", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query, strings.ToUpper(code))
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to fetch country", slog.String("code", code), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var c *models.Country
+	for rows.Next() {
+		var (
+			country    models.Country
+			lang, name sql.NullString
+		)
+		if err := rows.Scan(&country.Code, &country.Alpha3, &country.Name, &lang, &name); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if c == nil {
+			country.Names = make(map[string]string)
+			c = &country
+		}
+		if lang.Valid {
+			c.Names[lang.String] = name.String
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// Industries lists the whole taxonomy ordered by code, so every parent
+// comes before its children.
+func (r *VocabularyRepo) Industries(ctx context.Context) ([]models.Industry, error) {
+	defer metrics.ObserveQuery("vocabulary", "Industries", time.Now())
+	query := `SELECT code, parent_code, name FROM industries ORDER BY code`
+
+	ctx, span := tracing.StartQuery(ctx, "VocabularyRepo.Industries", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list industries", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var industries []models.Industry
+	for rows.Next() {
+		var i models.Industry
+		if err := rows.Scan(&i.Code, &i.ParentCode, &i.Name); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		industries = append(industries, i)
+	}
+	return industries, rows.Err()
+}
+
+// Unmatched lists the free-text countries and industries of millionaires
+// that have no code, most used first.
+func (r *VocabularyRepo) Unmatched(ctx context.Context) ([]models.UnmatchedValue, error) {
+	defer metrics.ObserveQuery("vocabulary", "Unmatched", time.Now())
+	query := `SELECT kind, value, millionaires FROM unmatched_vocabulary ORDER BY kind, millionaires DESC, value`
+
+	ctx, span := tracing.StartQuery(ctx, "VocabularyRepo.Unmatched", "SELECT", query)
+	defer span.End()
+
+	rows, err := r.conn.Reader(ctx).QueryContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		r.log.ErrorContext(ctx, "Failed to list unmatched values", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []models.UnmatchedValue
+	for rows.Next() {
+		var v models.UnmatchedValue
+		if err := rows.Scan(&v.Kind, &v.Value, &v.Millionaires); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,166 @@
+package router
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"wealthlist/internal/cache"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/publicurl"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	RequestIDHeader   = "X-Request-ID"
+	ConsistencyHeader = "X-Consistency"
+)
+
+// validRequestID bounds what we accept from clients so the value is safe to
+// log and echo back.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestContext assigns a request ID (reusing a valid incoming X-Request-ID),
+// echoes it in the response and stores the request-scoped logging attributes
+// and logger in the request context.
+func RequestContext(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		user := "anonymous"
+		if name, _, ok := c.Request.BasicAuth(); ok && name != "" {
+			user = name
+		}
+
+		ctx := logger.WithAttrs(c.Request.Context(),
+			slog.String("request_id", requestID),
+			slog.String("route", route),
+			slog.String("user", user),
+			slog.String("client_ip", c.ClientIP()),
+		)
+		ctx = logger.WithLogger(ctx, log)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.id", requestID))
+
+		c.Set("requestID", requestID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// ReadYourWrites sends all reads of a request to the primary when the
+// request changes data or the client asks for it with "X-Consistency: strong",
+// e.g. right after a write.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if !strings.EqualFold(c.GetHeader(ConsistencyHeader), "strong") {
+				c.Next()
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(repo.WithPrimary(c.Request.Context()))
+		c.Next()
+	}
+}
+
+// CacheStatus tells clients in the X-Cache header whether a cached read
+// was served from the cache (HIT), loaded (MISS) or skipped (BYPASS).
+func CacheStatus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := cache.WithReporter(c.Request.Context(), func(r cache.Result) {
+			c.Header(cache.Header, string(r))
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// PublicURL stores the base URL the client used and the photo URL signer
+// in the request context, for handlers that return absolute URLs.
+func PublicURL(urls *publicurl.Builder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := publicurl.WithBase(c.Request.Context(), urls.Base(c.Request))
+		ctx = publicurl.WithSigner(ctx, urls.Signer())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// AccessLog writes one line per request once the response is written.
+func AccessLog(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		duration := time.Since(start)
+
+		log.InfoContext(c.Request.Context(), "HTTP-request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration", duration,
+		)
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102T150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// Authenticator verifies credentials of users created with
+// `wealthlist user create`.
+type Authenticator interface {
+	Authenticate(ctx context.Context, username, password string) (*models.User, error)
+}
+
+// AdminAuth accepts "Authorization: Bearer <token>" when token is set, or
+// HTTP basic auth of a user with the admin role.
+func AdminAuth(token string, users Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := ""
+		if provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			if token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+				user = "admin"
+			}
+		} else if name, password, ok := c.Request.BasicAuth(); ok {
+			u, err := users.Authenticate(c.Request.Context(), name, password)
+			if err == nil && u.Role == service.RoleAdmin {
+				user = u.Username
+			}
+		}
+
+		if user == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		ctx := logger.WithAttrs(c.Request.Context(), slog.String("user", user))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -1,35 +1,42 @@
 package router
 
 import (
-	"time"
+	"log/slog"
 	_ "wealthlist/docs"
 	"wealthlist/internal/handler"
 	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/publicurl"
+	"wealthlist/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRouter(millionaireHandler *handler.MillionaireHandler, photoHandler *handler.PhotoHandler, homeHandler *handler.HomeHandler, feedbackHandler *handler.FeedbackHandler) *gin.Engine {
+func SetupRouter(millionaireHandler *handler.MillionaireHandler, companyHandler *handler.CompanyHandler, vocabularyHandler *handler.VocabularyHandler, photoHandler *handler.PhotoHandler, homeHandler *handler.HomeHandler, statsHandler *handler.StatsHandler, mergeHandler *handler.MergeHandler, feedbackHandler *handler.FeedbackHandler, healthHandler *handler.HealthHandler, adminHandler *handler.AdminHandler, adminToken string, users Authenticator, urls *publicurl.Builder, log *slog.Logger) *gin.Engine {
 	router := gin.Default()
+	// Client addresses in logs and traces honour X-Forwarded-For only from
+	// the proxies trusted for absolute URLs.
+	if err := router.SetTrustedProxies(urls.TrustedProxies()); err != nil {
+		log.Error("Invalid trusted proxies", logger.Err(err))
+	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	log := logger.SetupLogger("dev")
-
-	router.Use(func(c *gin.Context) {
-		start := time.Now()
-		c.Next()
-		duration := time.Since(start)
+	// Probes and /metrics are registered before the middleware to keep them out of the request log and metrics.
+	router.GET("/healthz", healthHandler.Liveness)
+	router.GET("/readyz", healthHandler.Readiness)
+	router.GET("/version", healthHandler.Version)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
-		log.Info("HTTP-request",
-			"method", c.Request.Method,
-			"path", c.Request.URL.Path,
-			"status", c.Writer.Status(),
-			"duration", duration,
-		)
-	})
+	router.Use(tracing.Middleware())
+	router.Use(RequestContext(log))
+	router.Use(ReadYourWrites())
+	router.Use(CacheStatus())
+	router.Use(PublicURL(urls))
+	router.Use(metrics.Middleware())
+	router.Use(AccessLog(log))
 
 	millionaireGroup := router.Group("/api/millionaires")
 	{
@@ -39,6 +46,36 @@ func SetupRouter(millionaireHandler *handler.MillionaireHandler, photoHandler *h
 		millionaireGroup.PUT("/:id", millionaireHandler.Update)
 		millionaireGroup.DELETE("/:id", millionaireHandler.Delete)
 		millionaireGroup.GET("/search", millionaireHandler.Search)
+		millionaireGroup.POST("/merge", AdminAuth(adminToken, users), mergeHandler.Merge)
+		millionaireGroup.GET("/:id/holdings", companyHandler.ListHoldings)
+		millionaireGroup.POST("/:id/holdings", companyHandler.AddHolding)
+		millionaireGroup.PUT("/:id/holdings/:holdingId", companyHandler.UpdateHolding)
+		millionaireGroup.DELETE("/:id/holdings/:holdingId", companyHandler.DeleteHolding)
+		millionaireGroup.GET("/:id/net-worth-breakdown", companyHandler.Breakdown)
+	}
+
+	companyGroup := router.Group("/api/companies")
+	{
+		companyGroup.GET("/", companyHandler.List)
+		companyGroup.GET("/:id", companyHandler.GetByID)
+		companyGroup.POST("/", companyHandler.Create)
+		companyGroup.PUT("/:id", companyHandler.Update)
+		companyGroup.DELETE("/:id", companyHandler.Delete)
+		companyGroup.GET("/:id/people", companyHandler.People)
+	}
+
+	router.GET("/api/duplicates", mergeHandler.Duplicates)
+
+	router.GET("/api/countries", vocabularyHandler.Countries)
+	router.GET("/api/countries/:code", vocabularyHandler.Country)
+	router.GET("/api/industries", vocabularyHandler.Industries)
+	router.GET("/api/industries/:code", vocabularyHandler.Industry)
+
+	statsGroup := router.Group("/api/stats")
+	{
+		statsGroup.GET("/", statsHandler.Summary)
+		statsGroup.GET("/by/:dimension", statsHandler.Groups)
+		statsGroup.GET("/histogram", statsHandler.Histogram)
 	}
 
 	photoGroup := router.Group("/api/photo")
@@ -58,5 +95,16 @@ func SetupRouter(millionaireHandler *handler.MillionaireHandler, photoHandler *h
 		feedbackGroup.POST("/", feedbackHandler.SendFeedback)
 	}
 
+	adminGroup := router.Group("/admin", AdminAuth(adminToken, users))
+	{
+		adminGroup.GET("/log-level", adminHandler.GetLogLevel)
+		adminGroup.PUT("/log-level", adminHandler.SetLogLevel)
+		adminGroup.GET("/home/sections", homeHandler.ListSections)
+		adminGroup.POST("/home/sections", homeHandler.CreateSection)
+		adminGroup.GET("/home/sections/:id", homeHandler.GetSection)
+		adminGroup.PUT("/home/sections/:id", homeHandler.UpdateSection)
+		adminGroup.DELETE("/home/sections/:id", homeHandler.DeleteSection)
+	}
+
 	return router
 }
@@ -0,0 +1,1140 @@
+package routertest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"wealthlist/internal/cache"
+	"wealthlist/internal/cache/cachetest"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/repo/repotest"
+)
+
+// MillionaireAPI checks the millionaire, company, duplicate, stats, home, probe
+// and admin endpoints through HTTP against repositories made by newRepo,
+// without and with the response cache:
+//
+//	func TestAPI(t *testing.T) { routertest.MillionaireAPI(t, repotest.Memory) }
+func MillionaireAPI(t *testing.T, newRepo repotest.Factory) {
+	for _, c := range []struct {
+		name string
+		run  func(t *testing.T, s *Server)
+	}{
+		{"CRUD", testCRUD},
+		{"InvalidRequests", testInvalidRequests},
+		{"ListAndSearch", testListAndSearch},
+		{"ProfileAndFields", testProfileAndFields},
+		{"Vocabulary", testVocabulary},
+		{"Currency", testCurrency},
+		{"ExactMoney", testExactMoney},
+		{"Stats", testStats},
+		{"Home", testHome},
+		{"HomeSections", testHomeSections},
+		{"PhotoURLs", testPhotoURLs},
+		{"History", testHistory},
+		{"Companies", testCompanies},
+		{"Duplicates", testDuplicates},
+		{"MergeRequests", testMergeRequests},
+		{"Probes", testProbes},
+		{"AdminAuth", testAdminAuth},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, NewServer(t, newRepo(t)))
+		})
+		// Every write must invalidate what the reads before it cached.
+		t.Run("Cached"+c.name, func(t *testing.T) {
+			c.run(t, NewCachedServer(t, newRepo(t), cachetest.Memory(t)))
+		})
+	}
+	for _, c := range []struct {
+		name     string
+		newStore cachetest.Factory
+	}{
+		{"MemoryCache", cachetest.Memory},
+		{"RedisCache", cachetest.Redis},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			testCache(t, NewCachedServer(t, newRepo(t), c.newStore(t)))
+		})
+	}
+	t.Run("SignedPhotoURLs", func(t *testing.T) {
+		testSignedPhotoURLs(t, NewSigningServer(t, newRepo(t)))
+	})
+}
+
+func expectStatus(t *testing.T, what string, got, want int, body []byte) {
+	t.Helper()
+	if got != want {
+		t.Fatalf("%s: status %d, want %d (body %s)", what, got, want, body)
+	}
+}
+
+func testCRUD(t *testing.T, s *Server) {
+	country := "Norway"
+	m := repotest.New("Ingrid", "Olsen", 5_000_000)
+	m.Country = &country
+
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+	expectStatus(t, "create", status, http.StatusCreated, body)
+
+	var list models.PaginationMillionaireDto
+	status, body = s.Do(t, http.MethodGet, "/api/millionaires/", nil)
+	expectStatus(t, "list", status, http.StatusOK, body)
+	Decode(t, body, &list)
+	if list.Total != 1 || len(list.Millionaires) != 1 {
+		t.Fatalf("list after create: %s", body)
+	}
+	id := list.Millionaires[0].ID
+	path := fmt.Sprintf("/api/millionaires/%d", id)
+
+	var got models.Millionaire
+	status, body = s.Do(t, http.MethodGet, path, nil)
+	expectStatus(t, "get", status, http.StatusOK, body)
+	Decode(t, body, &got)
+	if got.FirstName != "Ingrid" || got.Country == nil || *got.Country != "Norway" {
+		t.Errorf("get: %s", body)
+	}
+
+	m.LastName = "Olsen-Berg"
+	status, body = s.Do(t, http.MethodPut, path, m)
+	expectStatus(t, "update", status, http.StatusOK, body)
+
+	status, body = s.Do(t, http.MethodGet, path, nil)
+	expectStatus(t, "get after update", status, http.StatusOK, body)
+	Decode(t, body, &got)
+	if got.LastName != "Olsen-Berg" {
+		t.Errorf("get after update: last name %q", got.LastName)
+	}
+
+	status, body = s.Do(t, http.MethodDelete, path, nil)
+	expectStatus(t, "delete", status, http.StatusOK, body)
+
+	status, body = s.Do(t, http.MethodGet, path, nil)
+	expectStatus(t, "get after delete", status, http.StatusNotFound, body)
+}
+
+func testInvalidRequests(t *testing.T, s *Server) {
+	for _, c := range []struct {
+		method, path string
+		body         interface{}
+		want         int
+	}{
+		{http.MethodGet, "/api/millionaires/abc", nil, http.StatusBadRequest},
+		{http.MethodGet, "/api/millionaires/424242", nil, http.StatusNotFound},
+		{http.MethodPut, "/api/millionaires/abc", repotest.New("A", "B", 1), http.StatusBadRequest},
+		{http.MethodPut, "/api/millionaires/1", "not an object", http.StatusBadRequest},
+		{http.MethodPost, "/api/millionaires/", []int{1, 2}, http.StatusBadRequest},
+		{http.MethodDelete, "/api/millionaires/abc", nil, http.StatusBadRequest},
+	} {
+		status, body := s.Do(t, c.method, c.path, c.body)
+		if status != c.want {
+			t.Errorf("%s %s: status %d, want %d (body %s)", c.method, c.path, status, c.want, body)
+		}
+	}
+}
+
+func testListAndSearch(t *testing.T, s *Server) {
+	for i := 0; i < 12; i++ {
+		m := repotest.New(fmt.Sprintf("First%02d", i), fmt.Sprintf("Family%02d", i%3), int64(i))
+		status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+		expectStatus(t, "create", status, http.StatusCreated, body)
+	}
+
+	var page models.PaginationMillionaireDto
+	status, body := s.Do(t, http.MethodGet, "/api/millionaires/?pageNum=2&pageSize=5", nil)
+	expectStatus(t, "list page 2", status, http.StatusOK, body)
+	Decode(t, body, &page)
+	if page.Total != 12 || page.Page != 2 || page.PageSize != 5 || len(page.Millionaires) != 5 {
+		t.Errorf("list page 2: %s", body)
+	} else if page.Millionaires[0].FirstName != "First05" {
+		t.Errorf("list page 2 starts with %s, want First05", page.Millionaires[0].FirstName)
+	}
+
+	// Invalid paging falls back to the defaults.
+	status, body = s.Do(t, http.MethodGet, "/api/millionaires/?pageNum=0&pageSize=x", nil)
+	expectStatus(t, "list with invalid paging", status, http.StatusOK, body)
+	Decode(t, body, &page)
+	if page.Page != 1 || page.PageSize != 10 || len(page.Millionaires) != 10 {
+		t.Errorf("list with invalid paging: page %d, size %d, %d items", page.Page, page.PageSize, len(page.Millionaires))
+	}
+
+	status, body = s.Do(t, http.MethodGet, "/api/millionaires/search?lastName=family01&pageSize=2&page=2", nil)
+	expectStatus(t, "search", status, http.StatusOK, body)
+	Decode(t, body, &page)
+	if page.Total != 4 || len(page.Millionaires) != 2 {
+		t.Errorf("search: %s", body)
+	}
+}
+
+func testProfileAndFields(t *testing.T, s *Server) {
+	m := repotest.New("Grace", "Hopper", 3_000_000)
+	bio := "**Admiral**<script>alert(1)</script> [site](javascript:alert(1))"
+	status := "married"
+	m.Biography = &bio
+	m.MaritalStatus = &status
+	m.Citizenships = []string{"United States"}
+	m.SocialHandles = map[string]string{"linkedin": "grace-hopper"}
+
+	code, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+	expectStatus(t, "create", code, http.StatusCreated, body)
+
+	var list models.PaginationMillionaireDto
+	_, body = s.Do(t, http.MethodGet, "/api/millionaires/", nil)
+	Decode(t, body, &list)
+	path := fmt.Sprintf("/api/millionaires/%d", list.Millionaires[0].ID)
+	if list.Millionaires[0].Biography != nil || list.Millionaires[0].MaritalStatus == nil {
+		t.Errorf("list: want the biography left out and the rest of the profile kept: %s", body)
+	}
+
+	var got models.Millionaire
+	code, body = s.Do(t, http.MethodGet, path, nil)
+	expectStatus(t, "get", code, http.StatusOK, body)
+	Decode(t, body, &got)
+	if !strings.Contains(got.BiographyHTML, "<strong>Admiral</strong>") {
+		t.Errorf("biographyHtml not rendered: %q", got.BiographyHTML)
+	}
+	if strings.Contains(got.BiographyHTML, "<script") || strings.Contains(got.BiographyHTML, "javascript:") {
+		t.Errorf("biographyHtml not sanitized: %q", got.BiographyHTML)
+	}
+	if len(got.Citizenships) != 1 || got.SocialHandles["linkedin"] != "grace-hopper" {
+		t.Errorf("get: %s", body)
+	}
+
+	var selected map[string]interface{}
+	code, body = s.Do(t, http.MethodGet, path+"?fields=lastName,netWorth", nil)
+	expectStatus(t, "get with fields", code, http.StatusOK, body)
+	Decode(t, body, &selected)
+	if len(selected) != 3 || selected["id"] == nil || selected["lastName"] != "Hopper" || selected["netWorth"] == nil {
+		t.Errorf("get with fields: %s", body)
+	}
+
+	var page struct {
+		Millionaires []map[string]interface{} `json:"millionaires"`
+		Total        int                      `json:"total"`
+	}
+	code, body = s.Do(t, http.MethodGet, "/api/millionaires/?fields=firstName", nil)
+	expectStatus(t, "list with fields", code, http.StatusOK, body)
+	Decode(t, body, &page)
+	if page.Total != 1 || len(page.Millionaires) != 1 || len(page.Millionaires[0]) != 2 {
+		t.Errorf("list with fields: %s", body)
+	}
+
+	code, body = s.Do(t, http.MethodGet, path+"?fields=biography,biographyHtml", nil)
+	expectStatus(t, "get with the biography", code, http.StatusOK, body)
+	Decode(t, body, &selected)
+	if selected["biography"] != bio || !strings.Contains(fmt.Sprint(selected["biographyHtml"]), "<strong>Admiral</strong>") {
+		t.Errorf("get with the biography: %s", body)
+	}
+
+	code, body = s.Do(t, http.MethodGet, "/api/millionaires/?fields=firstName&pageNum=2", nil)
+	expectStatus(t, "empty page with fields", code, http.StatusOK, body)
+	if !strings.Contains(string(body), `"millionaires":[]`) {
+		t.Errorf("empty page with fields: %s, want an empty list", body)
+	}
+
+	code, body = s.Do(t, http.MethodGet, path+"?fields=lastName,password", nil)
+	expectStatus(t, "get with an unknown field", code, http.StatusBadRequest, body)
+
+	invalid := repotest.New("In", "Valid", 1)
+	status = "complicated"
+	invalid.MaritalStatus = &status
+	code, body = s.Do(t, http.MethodPost, "/api/millionaires/", invalid)
+	expectStatus(t, "create with an invalid marital status", code, http.StatusBadRequest, body)
+
+	invalid = repotest.New("In", "Valid", 1)
+	invalid.SocialHandles = map[string]string{"myspace": "tom"}
+	code, body = s.Do(t, http.MethodPost, "/api/millionaires/", invalid)
+	expectStatus(t, "create with an unknown social network", code, http.StatusBadRequest, body)
+}
+
+func testVocabulary(t *testing.T, s *Server) {
+	for _, c := range []struct {
+		first, country, industry string
+	}{
+		{"Aigerim", "Казахстан", "Software"},
+		{"Boris", "rus", "technology.telecom"},
+		{"Carl", "United States", "Energy"},
+		{"Dana", "Atlantis", "Underwater Basket Weaving"},
+	} {
+		m := repotest.New(c.first, "Vocab", 1_000_000)
+		m.Country, m.Industry = &c.country, &c.industry
+		status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+		expectStatus(t, "create "+c.first, status, http.StatusCreated, body)
+	}
+
+	var page models.PaginationMillionaireDto
+	_, body := s.Do(t, http.MethodGet, "/api/millionaires/", nil)
+	Decode(t, body, &page)
+	codes := map[string]string{}
+	for _, m := range page.Millionaires {
+		codes[m.FirstName] = fmt.Sprint(str(m.CountryCode), "/", str(m.IndustryCode))
+	}
+	for first, want := range map[string]string{
+		"Aigerim": "KZ/technology.software",
+		"Boris":   "RU/technology.telecom",
+		"Carl":    "US/energy",
+		"Dana":    "/",
+	} {
+		if codes[first] != want {
+			t.Errorf("codes of %s: %s, want %s", first, codes[first], want)
+		}
+	}
+
+	for _, c := range []struct {
+		query string
+		want  int
+	}{
+		{"country=KZ", 1},
+		{"country=kaz", 1},
+		{"country=Қазақстан", 1},
+		{"country=atlan", 1},
+		{"industry=technology", 2},
+		{"industry=Software", 1},
+		{"industry=basket", 1},
+		{"industry=technology&country=US", 0},
+	} {
+		status, body := s.Do(t, http.MethodGet, "/api/millionaires/search?"+c.query, nil)
+		expectStatus(t, "search "+c.query, status, http.StatusOK, body)
+		Decode(t, body, &page)
+		if page.Total != c.want {
+			t.Errorf("search %s: %d results, want %d", c.query, page.Total, c.want)
+		}
+	}
+
+	unknown := repotest.New("Eve", "Vocab", 1)
+	code := "XX"
+	unknown.CountryCode = &code
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", unknown)
+	expectStatus(t, "create with an unknown country code", status, http.StatusBadRequest, body)
+}
+
+func testCurrency(t *testing.T, s *Server) {
+	for _, c := range []struct {
+		first, currency, asOf string
+		netWorth              int64
+	}{
+		{"Dollar", "USD", "2024-06-01", 1_100_000},
+		{"Tenge", "KZT", "2025-03-01", 550_000_000},
+		{"Early", "USD", "2023-01-01", 1_000},
+		{"Default", "", "", 2_080},
+	} {
+		m := repotest.New(c.first, "Currency", c.netWorth)
+		m.NetWorthCurrency, m.NetWorthAsOf = c.currency, nil
+		if c.asOf != "" {
+			m.NetWorthAsOf = &c.asOf
+		}
+		status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+		expectStatus(t, "create "+c.first, status, http.StatusCreated, body)
+	}
+
+	valuations := func(query string) map[string]string {
+		t.Helper()
+		var page models.PaginationMillionaireDto
+		status, body := s.Do(t, http.MethodGet, "/api/millionaires/"+query, nil)
+		expectStatus(t, "list "+query, status, http.StatusOK, body)
+		Decode(t, body, &page)
+		got := map[string]string{}
+		for _, m := range page.Millionaires {
+			got[m.FirstName] = fmt.Sprintf("%s %s", m.NetWorth, m.NetWorthCurrency)
+		}
+		return got
+	}
+
+	// Rates per euro: USD 1.1 and KZT 500 from 2024-01-02, USD 1.04 and KZT
+	// 550 from 2025-01-02. Nothing converts before 2024-01-02.
+	for query, want := range map[string]map[string]string{
+		"":              {"Dollar": "1100000 USD", "Tenge": "550000000 KZT", "Early": "1000 USD", "Default": "2080 USD"},
+		"?currency=EUR": {"Dollar": "1000000.00 EUR", "Tenge": "1000000.00 EUR", "Early": "1000 USD", "Default": "2000.00 EUR"},
+		"?currency=kzt": {"Dollar": "500000000.00 KZT", "Tenge": "550000000 KZT", "Early": "1000 USD", "Default": "1100000.00 KZT"},
+	} {
+		got := valuations(query)
+		for first, w := range want {
+			if got[first] != w {
+				t.Errorf("list%s: %s has %s, want %s", query, first, got[first], w)
+			}
+		}
+	}
+
+	for _, query := range []string{"?currency=XYZ", "?currency=dollars"} {
+		status, body := s.Do(t, http.MethodGet, "/api/millionaires/"+query, nil)
+		expectStatus(t, "list "+query, status, http.StatusBadRequest, body)
+	}
+
+	var home models.HomePageDto
+	status, body := s.Do(t, http.MethodGet, "/home?currency=EUR", nil)
+	expectStatus(t, "home in euros", status, http.StatusOK, body)
+	Decode(t, body, &home)
+	if len(home.Sections) != 1 || len(home.Sections[0].Entries) != 4 ||
+		home.Sections[0].Entries[0].Millionaire.FirstName != "Dollar" || home.Sections[0].Entries[0].Millionaire.NetWorthCurrency != "EUR" {
+		t.Errorf("home in euros: %s", body)
+	}
+
+	// Without a day, an unchanged net worth keeps its day and a new one is
+	// valued today.
+	all, err := s.Millionaires.Search(context.Background(), repo.MillionaireFilter{LastName: "Currency", FirstName: "Tenge"}, 1, 1)
+	if err != nil || len(all.Millionaires) != 1 {
+		t.Fatalf("search: %+v, %v", all, err)
+	}
+	m := all.Millionaires[0]
+	path := fmt.Sprintf("/api/millionaires/%d", m.ID)
+	m.MiddleName, m.NetWorthAsOf = ptr("Renamed"), nil
+	status, body = s.Do(t, http.MethodPut, path, m)
+	expectStatus(t, "rename", status, http.StatusOK, body)
+	got, err := s.Millionaires.GetByID(context.Background(), m.ID)
+	if err != nil || str(got.NetWorthAsOf) != "2025-03-01" {
+		t.Errorf("net worth day after rename: %q, %v", str(got.NetWorthAsOf), err)
+	}
+
+	netWorth := money.FromInt(600_000_000)
+	m.NetWorth = &netWorth
+	status, body = s.Do(t, http.MethodPut, path, m)
+	expectStatus(t, "revalue", status, http.StatusOK, body)
+	got, err = s.Millionaires.GetByID(context.Background(), m.ID)
+	if today := time.Now().Format(time.DateOnly); err != nil || str(got.NetWorthAsOf) != today {
+		t.Errorf("net worth day after revaluation: %q, %v, want %s", str(got.NetWorthAsOf), err, today)
+	}
+}
+
+func testExactMoney(t *testing.T, s *Server) {
+	const netWorth = `1234567890123456789.10`
+	body := json.RawMessage(`{"firstName": "Exact", "lastName": "Money", "netWorth": ` + netWorth + `, "netWorthCurrency": "EUR"}`)
+	status, out := s.Do(t, http.MethodPost, "/api/millionaires/", body)
+	expectStatus(t, "create", status, http.StatusCreated, out)
+
+	var page models.PaginationMillionaireDto
+	_, out = s.Do(t, http.MethodGet, "/api/millionaires/", nil)
+	Decode(t, out, &page)
+	if len(page.Millionaires) != 1 {
+		t.Fatalf("list: %s", out)
+	}
+	_, out = s.Do(t, http.MethodGet, fmt.Sprintf("/api/millionaires/%d?fields=netWorth", page.Millionaires[0].ID), nil)
+	if !strings.Contains(string(out), `"netWorth":`+netWorth) {
+		t.Errorf("net worth %s came back as %s", netWorth, out)
+	}
+
+	for _, c := range []struct{ currency, netWorth string }{{"JPY", "100.5"}, {"USD", "0.001"}} {
+		body := json.RawMessage(`{"firstName": "Too", "lastName": "Precise", "netWorth": ` + c.netWorth + `, "netWorthCurrency": "` + c.currency + `"}`)
+		status, out := s.Do(t, http.MethodPost, "/api/millionaires/", body)
+		expectStatus(t, "create with "+c.netWorth+" "+c.currency, status, http.StatusBadRequest, out)
+	}
+}
+
+func testStats(t *testing.T, s *Server) {
+	today := time.Now()
+	born := func(years int) *string {
+		d := today.AddDate(-years, 0, -10).Format(time.DateOnly)
+		return &d
+	}
+	decade := func(years int) string {
+		return fmt.Sprintf("%ds", today.AddDate(-years, 0, -10).Year()/10*10)
+	}
+	for _, c := range []struct {
+		first, country string
+		netWorth       int64
+		age            int
+	}{
+		{"Old", "US", 1_000_000, 75},
+		{"Middle", "US", 2_000_000, 45},
+		{"Ageless", "GB", 3_000_000, 0},
+		{"Young", "Atlantis", 4_000_000, 25},
+	} {
+		m := repotest.New(c.first, "Stats", c.netWorth)
+		m.Country = &c.country
+		if c.age > 0 {
+			m.BirthDate = born(c.age)
+		}
+		status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+		expectStatus(t, "create "+c.first, status, http.StatusCreated, body)
+	}
+	franc := repotest.New("Franc", "Stats", 1_000)
+	franc.NetWorthCurrency = "CHF"
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", franc)
+	expectStatus(t, "create Franc", status, http.StatusCreated, body)
+
+	var summary models.StatsSummary
+	status, body = s.Do(t, http.MethodGet, "/api/stats/", nil)
+	expectStatus(t, "summary", status, http.StatusOK, body)
+	Decode(t, body, &summary)
+	if summary.Currency != "USD" || summary.Count != 4 || summary.Unconverted != 1 {
+		t.Errorf("summary: %s", body)
+	}
+	for what, got := range map[string]string{
+		"total":  summary.Total.String(),
+		"mean":   fmt.Sprint(summary.Mean),
+		"median": fmt.Sprint(summary.Median),
+		"p10":    fmt.Sprint(summary.Percentiles["p10"]),
+		"max":    fmt.Sprint(summary.Max),
+		"gini":   fmt.Sprint(*summary.Gini),
+		"top1":   fmt.Sprint(*summary.Top1Share),
+	} {
+		want := map[string]string{
+			"total": "10000000", "mean": "2500000.00", "median": "2500000.00", "p10": "1300000.00",
+			"max": "4000000", "gini": "0.25", "top1": "0.4",
+		}[what]
+		if got != want {
+			t.Errorf("summary %s: %s, want %s", what, got, want)
+		}
+	}
+
+	status, body = s.Do(t, http.MethodGet, "/api/stats/?country=us", nil)
+	expectStatus(t, "summary of US", status, http.StatusOK, body)
+	Decode(t, body, &summary)
+	if summary.Count != 2 || summary.Total.String() != "3000000" {
+		t.Errorf("summary of US: %s", body)
+	}
+
+	status, body = s.Do(t, http.MethodGet, "/api/stats/?currency=EUR", nil)
+	expectStatus(t, "summary in euros", status, http.StatusOK, body)
+	Decode(t, body, &summary)
+	if summary.Currency != "EUR" || summary.Count != 4 || summary.Total.String() != "9615384.61" {
+		t.Errorf("summary in euros: %s", body)
+	}
+
+	for path, want := range map[string][]string{
+		"/api/stats/by/country": {"Atlantis", "GB", "US"},
+		"/api/stats/by/age":     {"<30", "40-49", "70-79", "unknown"},
+		"/api/stats/by/decade":  {decade(75), decade(45), decade(25), "unknown"},
+	} {
+		var groups models.StatsGroups
+		status, body := s.Do(t, http.MethodGet, path, nil)
+		expectStatus(t, path, status, http.StatusOK, body)
+		Decode(t, body, &groups)
+		var keys []string
+		for _, g := range groups.Groups {
+			keys = append(keys, g.Key)
+		}
+		if fmt.Sprint(keys) != fmt.Sprint(want) {
+			t.Errorf("%s: groups %v, want %v", path, keys, want)
+		}
+	}
+
+	status, body = s.Do(t, http.MethodGet, "/api/stats/by/country?format=csv", nil)
+	expectStatus(t, "groups as CSV", status, http.StatusOK, body)
+	if !strings.HasPrefix(string(body), "country,currency,count,total,") || !strings.Contains(string(body), "\nUS,USD,2,3000000,1500000.00,") {
+		t.Errorf("groups as CSV: %s", body)
+	}
+
+	var histogram models.Histogram
+	status, body = s.Do(t, http.MethodGet, "/api/stats/histogram?buckets=1500000,3500000", nil)
+	expectStatus(t, "histogram", status, http.StatusOK, body)
+	Decode(t, body, &histogram)
+	var counts []string
+	for _, b := range histogram.Buckets {
+		counts = append(counts, fmt.Sprintf("%s-%s:%d", amount(b.From), amount(b.To), b.Count))
+	}
+	if want := "[-1500000:1 1500000-3500000:2 3500000-:1]"; fmt.Sprint(counts) != want {
+		t.Errorf("histogram: %v, want %s", counts, want)
+	}
+
+	for _, path := range []string{
+		"/api/stats/?currency=XYZ",
+		"/api/stats/by/planet",
+		"/api/stats/by/country?format=xml",
+		"/api/stats/histogram?buckets=5,1",
+		"/api/stats/histogram?buckets=lots",
+	} {
+		status, body := s.Do(t, http.MethodGet, path, nil)
+		expectStatus(t, path, status, http.StatusBadRequest, body)
+	}
+}
+
+func amount(a *money.Amount) string {
+	if a == nil {
+		return ""
+	}
+	return a.String()
+}
+
+func str(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func testHome(t *testing.T, s *Server) {
+	for i := 1; i <= 11; i++ {
+		m := repotest.New("Top", fmt.Sprint(i), int64(i*1000))
+		if i == 11 {
+			m.PathToPhoto = ptr("uploads/photos/11_abcdef.jpg")
+		}
+		status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+		expectStatus(t, "create", status, http.StatusCreated, body)
+	}
+
+	// A migrated database starts with the global top 10.
+	var home models.HomePageDto
+	status, body := s.Do(t, http.MethodGet, "/home/", nil)
+	expectStatus(t, "home", status, http.StatusOK, body)
+	Decode(t, body, &home)
+	if len(home.Sections) != 1 || home.Sections[0].Kind != models.SectionTop || len(home.Sections[0].Entries) != 10 {
+		t.Fatalf("home: %s", body)
+	}
+	first := home.Sections[0].Entries[0].Millionaire
+	if first.LastName != "11" || str(first.PathToPhoto) != s.URL+"/api/photo/11_abcdef.jpg" {
+		t.Errorf("home: first is %s with photo %q", first.LastName, str(first.PathToPhoto))
+	}
+}
+
+func testHomeSections(t *testing.T, s *Server) {
+	auth := []string{"Authorization", "Bearer " + AdminToken}
+	var ids []int
+	for i, country := range []string{"KZ", "US", "KZ"} {
+		m := repotest.New(fmt.Sprint("Section", i), "Person", int64((i+1)*1000))
+		m.CountryCode = &country
+		status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+		expectStatus(t, "create", status, http.StatusCreated, body)
+	}
+	var list models.PaginationMillionaireDto
+	_, body := s.Do(t, http.MethodGet, "/api/millionaires/", nil)
+	Decode(t, body, &list)
+	for _, m := range list.Millionaires {
+		ids = append(ids, m.ID)
+	}
+
+	status, body := s.Do(t, http.MethodGet, "/admin/home/sections", nil)
+	expectStatus(t, "sections without credentials", status, http.StatusUnauthorized, body)
+
+	var sections []models.HomeSection
+	status, body = s.Do(t, http.MethodGet, "/admin/home/sections", nil, auth...)
+	expectStatus(t, "sections", status, http.StatusOK, body)
+	Decode(t, body, &sections)
+	if len(sections) != 1 {
+		t.Fatalf("sections: %s", body)
+	}
+	top := sections[0]
+
+	window := 30
+	for _, section := range []models.HomeSection{
+		{Kind: models.SectionFeatured, Title: "Editors' picks", MillionaireIDs: []int{ids[2], ids[0]}},
+		{Kind: models.SectionTop, Title: "Richest in Kazakhstan", Size: 5, Countries: []string{"Kazakhstan"}},
+		{Kind: models.SectionNewcomers, Title: "New on the list", Size: 2},
+		{Kind: models.SectionGainers, Title: "Gainers", WindowDays: &window},
+	} {
+		status, body := s.Do(t, http.MethodPost, "/admin/home/sections", section, auth...)
+		expectStatus(t, "create "+section.Title, status, http.StatusCreated, body)
+	}
+
+	top.Hidden = true
+	status, body = s.Do(t, http.MethodPut, fmt.Sprintf("/admin/home/sections/%d", top.ID), top, auth...)
+	expectStatus(t, "hide top", status, http.StatusOK, body)
+
+	var home models.HomePageDto
+	status, body = s.Do(t, http.MethodGet, "/home/", nil)
+	expectStatus(t, "home", status, http.StatusOK, body)
+	Decode(t, body, &home)
+	got := map[string][]string{}
+	var titles []string
+	for _, section := range home.Sections {
+		titles = append(titles, section.Title)
+		for _, e := range section.Entries {
+			got[section.Title] = append(got[section.Title], e.Millionaire.FirstName)
+		}
+	}
+	if want := "[Editors' picks Richest in Kazakhstan New on the list Gainers]"; fmt.Sprint(titles) != want {
+		t.Errorf("home sections: %v, want %s", titles, want)
+	}
+	for title, want := range map[string]string{
+		"Editors' picks":        "[Section2 Section0]",
+		"Richest in Kazakhstan": "[Section2 Section0]",
+		"New on the list":       "[Section2 Section1]",
+		"Gainers":               "[]",
+	} {
+		if fmt.Sprint(got[title]) != want {
+			t.Errorf("%s: %v, want %s", title, got[title], want)
+		}
+	}
+
+	for _, c := range []struct {
+		what    string
+		section models.HomeSection
+	}{
+		{"unknown kind", models.HomeSection{Kind: "random", Title: "Random"}},
+		{"movers without window", models.HomeSection{Kind: models.SectionLosers, Title: "Losers"}},
+		{"window on top", models.HomeSection{Kind: models.SectionTop, Title: "Top", WindowDays: &window}},
+		{"featured without IDs", models.HomeSection{Kind: models.SectionFeatured, Title: "Picks"}},
+		{"featured unknown ID", models.HomeSection{Kind: models.SectionFeatured, Title: "Picks", MillionaireIDs: []int{424242}}},
+		{"unknown country", models.HomeSection{Kind: models.SectionTop, Title: "Top", Countries: []string{"Atlantis"}}},
+		{"size too big", models.HomeSection{Kind: models.SectionTop, Title: "Top", Size: 1000}},
+	} {
+		status, body := s.Do(t, http.MethodPost, "/admin/home/sections", c.section, auth...)
+		expectStatus(t, c.what, status, http.StatusBadRequest, body)
+	}
+
+	path := fmt.Sprintf("/admin/home/sections/%d", top.ID)
+	status, body = s.Do(t, http.MethodDelete, path, nil, auth...)
+	expectStatus(t, "delete", status, http.StatusOK, body)
+	status, body = s.Do(t, http.MethodGet, path, nil, auth...)
+	expectStatus(t, "get after delete", status, http.StatusNotFound, body)
+}
+
+func testProbes(t *testing.T, s *Server) {
+	status, body := s.Do(t, http.MethodGet, "/healthz", nil)
+	expectStatus(t, "healthz", status, http.StatusOK, body)
+
+	status, body = s.Do(t, http.MethodGet, "/api/millionaires/", nil, "X-Request-ID", "routertest-1")
+	expectStatus(t, "list", status, http.StatusOK, body)
+}
+
+func testAdminAuth(t *testing.T, s *Server) {
+	status, body := s.Do(t, http.MethodGet, "/admin/log-level", nil)
+	expectStatus(t, "admin without credentials", status, http.StatusUnauthorized, body)
+
+	status, body = s.Do(t, http.MethodGet, "/admin/log-level", nil, "Authorization", "Bearer wrong")
+	expectStatus(t, "admin with a wrong token", status, http.StatusUnauthorized, body)
+
+	status, body = s.Do(t, http.MethodGet, "/admin/log-level", nil, "Authorization", "Bearer "+AdminToken)
+	expectStatus(t, "admin with the token", status, http.StatusOK, body)
+}
+
+func testCache(t *testing.T, s *Server) {
+	expectCache := func(what, method, path string, body interface{}, want cache.Result, headers ...string) []byte {
+		t.Helper()
+		status, header, out := s.Send(t, method, path, body, headers...)
+		if status >= 300 {
+			t.Fatalf("%s: status %d (body %s)", what, status, out)
+		}
+		if got := cache.Result(header.Get(cache.Header)); got != want {
+			t.Errorf("%s: %s %q, want %q", what, cache.Header, got, want)
+		}
+		return out
+	}
+
+	m := repotest.New("Cached", "Person", 1000)
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+	expectStatus(t, "create", status, http.StatusCreated, body)
+	all, err := s.Millionaires.GetAll(context.Background(), 1, 1)
+	if err != nil || len(all.Millionaires) != 1 {
+		t.Fatalf("get all: %+v, %v", all, err)
+	}
+	path := fmt.Sprintf("/api/millionaires/%d", all.Millionaires[0].ID)
+
+	for _, p := range []string{"/home/", "/api/millionaires/", "/api/millionaires/search?lastName=Person", path} {
+		expectCache("first "+p, http.MethodGet, p, nil, cache.Miss)
+		expectCache("second "+p, http.MethodGet, p, nil, cache.Hit)
+	}
+	expectCache("strong read", http.MethodGet, path, nil, cache.Bypass, "X-Consistency", "strong")
+	expectCache("other page", http.MethodGet, "/api/millionaires/?pageSize=5", nil, cache.Miss)
+
+	m.LastName = "Renamed"
+	expectCache("update", http.MethodPut, path, m, "")
+	var got models.Millionaire
+	Decode(t, expectCache("get after update", http.MethodGet, path, nil, cache.Miss), &got)
+	if got.LastName != "Renamed" {
+		t.Errorf("get after update: last name %q", got.LastName)
+	}
+
+	var home models.HomePageDto
+	Decode(t, expectCache("home after update", http.MethodGet, "/home/", nil, cache.Miss), &home)
+	if len(home.Sections) == 0 || len(home.Sections[0].Entries) != 1 || home.Sections[0].Entries[0].Millionaire.LastName != "Renamed" {
+		t.Errorf("home after update: %+v", home)
+	}
+
+	section := models.HomeSection{Kind: models.SectionNewcomers, Title: "New"}
+	expectCache("create section", http.MethodPost, "/admin/home/sections", section, "", "Authorization", "Bearer "+AdminToken)
+	Decode(t, expectCache("home after section change", http.MethodGet, "/home/", nil, cache.Miss), &home)
+	if len(home.Sections) != 2 {
+		t.Errorf("home after section change: %d sections", len(home.Sections))
+	}
+
+	expectCache("delete", http.MethodDelete, path, nil, "")
+	status, header, body := s.Send(t, http.MethodGet, path, nil)
+	expectStatus(t, "get after delete", status, http.StatusNotFound, body)
+	if got := header.Get(cache.Header); got != string(cache.Miss) {
+		t.Errorf("get after delete: %s %q", cache.Header, got)
+	}
+	var list models.PaginationMillionaireDto
+	Decode(t, expectCache("list after delete", http.MethodGet, "/api/millionaires/", nil, cache.Miss), &list)
+	if list.Total != 0 {
+		t.Errorf("list after delete: %d millionaires", list.Total)
+	}
+}
+
+func testPhotoURLs(t *testing.T, s *Server) {
+	m := repotest.New("Photo", "Person", 1000)
+	m.PathToPhoto = ptr("uploads/photos/1_0123456789abcdef.jpg")
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+	expectStatus(t, "create", status, http.StatusCreated, body)
+	all, err := s.Millionaires.GetAll(context.Background(), 1, 1)
+	if err != nil || len(all.Millionaires) != 1 {
+		t.Fatalf("get all: %+v, %v", all, err)
+	}
+	id := all.Millionaires[0].ID
+	path := fmt.Sprintf("/api/millionaires/%d", id)
+
+	photo := func(what, p string, headers ...string) string {
+		t.Helper()
+		status, body := s.Do(t, http.MethodGet, p, nil, headers...)
+		expectStatus(t, what, status, http.StatusOK, body)
+		var page models.PaginationMillionaireDto
+		var one models.Millionaire
+		var home models.HomePageDto
+		switch {
+		case strings.HasPrefix(p, "/home"):
+			Decode(t, body, &home)
+			one = home.Sections[0].Entries[0].Millionaire
+		case p == path:
+			Decode(t, body, &one)
+		default:
+			Decode(t, body, &page)
+			one = page.Millionaires[0]
+		}
+		return str(one.PathToPhoto)
+	}
+
+	want := s.URL + "/api/photo/1_0123456789abcdef.jpg"
+	for _, p := range []string{"/api/millionaires/", "/api/millionaires/search?lastName=Person", path, "/home/"} {
+		if got := photo(p, p); got != want {
+			t.Errorf("%s: photo %q, want %q", p, got, want)
+		}
+	}
+
+	var got models.Millionaire
+	status, body = s.Do(t, http.MethodGet, path, nil)
+	expectStatus(t, "get", status, http.StatusOK, body)
+	Decode(t, body, &got)
+	if got.ThumbnailURL != want+"?size=thumb" {
+		t.Errorf("thumbnail %q, want %q", got.ThumbnailURL, want+"?size=thumb")
+	}
+
+	for _, c := range []struct {
+		name    string
+		headers []string
+		want    string
+	}{
+		{"X-Forwarded", []string{"X-Forwarded-Proto", "https", "X-Forwarded-Host", "wealth.example.com, proxy.internal"}, "https://wealth.example.com"},
+		{"Forwarded", []string{"Forwarded", `for=203.0.113.7;proto=https;host="wealth.example.com:8443", for=10.0.0.1`}, "https://wealth.example.com:8443"},
+		{"invalid host", []string{"X-Forwarded-Host", "evil.example.com/path"}, s.URL},
+	} {
+		if got := photo(c.name, path, c.headers...); got != c.want+"/api/photo/1_0123456789abcdef.jpg" {
+			t.Errorf("%s: photo %q", c.name, got)
+		}
+	}
+
+	// Sending the profile back keeps the stored path.
+	m.PathToPhoto = ptr("https://wealth.example.com/api/photo/1_0123456789abcdef.jpg")
+	status, body = s.Do(t, http.MethodPut, path, m)
+	expectStatus(t, "update", status, http.StatusOK, body)
+	stored, err := s.Millionaires.GetByID(context.Background(), id)
+	if err != nil || str(stored.PathToPhoto) != "uploads/photos/1_0123456789abcdef.jpg" {
+		t.Errorf("stored photo after update: %q, %v", str(stored.PathToPhoto), err)
+	}
+}
+
+func testDuplicates(t *testing.T, s *Server) {
+	ctx := context.Background()
+	create := func(first, last, birthDate, company string) models.Millionaire {
+		t.Helper()
+		m := repotest.New(first, last, 1000)
+		m.BirthDate, m.Company = &birthDate, &company
+		if err := s.Millionaires.Create(ctx, &m); err != nil {
+			t.Fatalf("create %s %s: %v", first, last, err)
+		}
+		return m
+	}
+	latin := create("Nursultan", "Nazarbayev", "1940-07-06", "Kazakhmys")
+	cyrillic := create("Нурсултан", "Назарбаев", "1940-07-06", "Казахмыс")
+	create("Ivan", "Ivanov", "1970-01-01", "Alpha")
+	create("Pyotr", "Petrov", "1980-01-01", "Beta")
+
+	status, body := s.Do(t, http.MethodGet, "/api/duplicates", nil)
+	expectStatus(t, "duplicates", status, http.StatusOK, body)
+	var pairs []models.DuplicatePair
+	Decode(t, body, &pairs)
+	if len(pairs) != 1 {
+		t.Fatalf("duplicates: %s, want one pair", body)
+	}
+	p := pairs[0]
+	if p.Millionaires[0].ID != latin.ID || p.Millionaires[1].ID != cyrillic.ID {
+		t.Errorf("pair: %d and %d, want %d and %d", p.Millionaires[0].ID, p.Millionaires[1].ID, latin.ID, cyrillic.ID)
+	}
+	if p.Score != 1 || p.Name != 1 || p.BirthDate == nil || *p.BirthDate != 1 {
+		t.Errorf("transliterated spelling scored %s", body)
+	}
+
+	status, body = s.Do(t, http.MethodGet, "/api/duplicates?minScore=0.1&limit=1", nil)
+	expectStatus(t, "low minScore", status, http.StatusOK, body)
+	Decode(t, body, &pairs)
+	if len(pairs) != 1 || pairs[0].Score != 1 {
+		t.Errorf("limit 1: %s, want the best pair only", body)
+	}
+
+	for _, q := range []string{"minScore=0", "minScore=1.5", "minScore=high", "limit=0", "limit=many"} {
+		status, body = s.Do(t, http.MethodGet, "/api/duplicates?"+q, nil)
+		expectStatus(t, q, status, http.StatusBadRequest, body)
+	}
+}
+
+func testMergeRequests(t *testing.T, s *Server) {
+	ctx := context.Background()
+	m := repotest.New("Merged", "Into", 1000)
+	if err := s.Millionaires.Create(ctx, &m); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	auth := []string{"Authorization", "Bearer " + AdminToken}
+
+	merge := models.MergeRequest{TargetID: m.ID, SourceID: m.ID + 1}
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/merge", merge)
+	expectStatus(t, "merge without credentials", status, http.StatusUnauthorized, body)
+
+	for what, req := range map[string]interface{}{
+		"itself":        models.MergeRequest{TargetID: m.ID, SourceID: m.ID},
+		"unknown field": models.MergeRequest{TargetID: m.ID, SourceID: m.ID + 1, Fields: map[string]string{"id": "source"}},
+		"follower":      models.MergeRequest{TargetID: m.ID, SourceID: m.ID + 1, Fields: map[string]string{"countryCode": "source"}},
+		"unknown side":  models.MergeRequest{TargetID: m.ID, SourceID: m.ID + 1, Fields: map[string]string{"netWorth": "both"}},
+		"no source":     map[string]int{"targetId": m.ID},
+	} {
+		status, body = s.Do(t, http.MethodPost, "/api/millionaires/merge", req, auth...)
+		expectStatus(t, "merge "+what, status, http.StatusBadRequest, body)
+	}
+
+	// A merge left a redirect from an ID that no longer exists.
+	gone := m.ID + 100
+	if err := s.Redirects.Add(ctx, gone, m.ID); err != nil {
+		t.Fatalf("add redirect: %v", err)
+	}
+	client := *s.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(fmt.Sprintf("%s/api/millionaires/%d?currency=USD", s.URL, gone))
+	if err != nil {
+		t.Fatalf("get merged: %v", err)
+	}
+	resp.Body.Close()
+	want := fmt.Sprintf("/api/millionaires/%d?currency=USD", m.ID)
+	if resp.StatusCode != http.StatusMovedPermanently || resp.Header.Get("Location") != want {
+		t.Errorf("get merged: %d to %q, want 301 to %q", resp.StatusCode, resp.Header.Get("Location"), want)
+	}
+
+	status, body = s.Do(t, http.MethodGet, fmt.Sprintf("/api/millionaires/%d", gone), nil)
+	expectStatus(t, "follow redirect", status, http.StatusOK, body)
+	var got models.Millionaire
+	Decode(t, body, &got)
+	if got.ID != m.ID {
+		t.Errorf("redirect led to %d, want %d", got.ID, m.ID)
+	}
+
+	status, body = s.Do(t, http.MethodGet, fmt.Sprintf("/api/millionaires/%d", gone+1), nil)
+	expectStatus(t, "unknown ID", status, http.StatusNotFound, body)
+}
+
+// created returns the ID of the only millionaire in the repository.
+func created(t *testing.T, s *Server) int {
+	t.Helper()
+	all, err := s.Millionaires.GetAll(context.Background(), 1, 2)
+	if err != nil || len(all.Millionaires) != 1 {
+		t.Fatalf("get all: %+v, %v", all, err)
+	}
+	return all.Millionaires[0].ID
+}
+
+func testHistory(t *testing.T, s *Server) {
+	history := func(id int) string {
+		t.Helper()
+		points, err := s.History.List(context.Background(), id)
+		if err != nil {
+			t.Fatalf("list history: %v", err)
+		}
+		var days []string
+		for _, p := range points {
+			days = append(days, fmt.Sprintf("%s %s %s", p.Date, p.NetWorth, p.Currency))
+		}
+		return strings.Join(days, ", ")
+	}
+
+	m := repotest.New("Dated", "Person", 1000)
+	m.NetWorthAsOf = ptr("2024-01-02")
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+	expectStatus(t, "create", status, http.StatusCreated, body)
+	id := created(t, s)
+	if got, want := history(id), "2024-01-02 1000 USD"; got != want {
+		t.Errorf("history after create: %q, want %q", got, want)
+	}
+
+	netWorth := money.FromInt(2000)
+	m.NetWorth, m.NetWorthCurrency, m.NetWorthAsOf = &netWorth, "KZT", ptr("2025-01-02")
+	status, body = s.Do(t, http.MethodPut, fmt.Sprintf("/api/millionaires/%d", id), m)
+	expectStatus(t, "update", status, http.StatusOK, body)
+	if got, want := history(id), "2024-01-02 1000 USD, 2025-01-02 2000 KZT"; got != want {
+		t.Errorf("history after update: %q, want %q", got, want)
+	}
+
+	// A new net worth without a day is valued today, after the one it
+	// replaces.
+	m.NetWorthCurrency, m.NetWorthAsOf = "", nil
+	today := time.Now().Format(time.DateOnly)
+	status, body = s.Do(t, http.MethodPut, fmt.Sprintf("/api/millionaires/%d", id), m)
+	expectStatus(t, "revalue", status, http.StatusOK, body)
+	if got, want := history(id), "2024-01-02 1000 USD, 2025-01-02 2000 KZT, "+today+" 2000 USD"; got != want {
+		t.Errorf("history after revaluation: %q, want %q", got, want)
+	}
+
+	status, body = s.Do(t, http.MethodPut, fmt.Sprintf("/api/millionaires/%d", id+1), m)
+	expectStatus(t, "update unknown", status, http.StatusOK, body)
+	if got := history(id + 1); got != "" {
+		t.Errorf("history of an unknown millionaire: %q", got)
+	}
+}
+
+func testCompanies(t *testing.T, s *Server) {
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", repotest.New("Aliya", "Serik", 1000))
+	expectStatus(t, "create millionaire", status, http.StatusCreated, body)
+	id := created(t, s)
+	millionaire := fmt.Sprintf("/api/millionaires/%d", id)
+
+	var company models.Company
+	status, body = s.Do(t, http.MethodPost, "/api/companies/", models.Company{Name: "Steppe Mining", LegalID: ptr("KZ-1")})
+	expectStatus(t, "create company", status, http.StatusCreated, body)
+	Decode(t, body, &company)
+	path := fmt.Sprintf("/api/companies/%d", company.ID)
+
+	status, body = s.Do(t, http.MethodPost, "/api/companies/", models.Company{Name: "Other Mining", LegalID: ptr("KZ-1")})
+	expectStatus(t, "duplicate legal ID", status, http.StatusConflict, body)
+
+	company.Name, company.Ticker = "Steppe Mining Group", ptr("STM")
+	status, body = s.Do(t, http.MethodPut, path, company)
+	expectStatus(t, "update company", status, http.StatusOK, body)
+
+	var list models.PaginationCompanyDto
+	status, body = s.Do(t, http.MethodGet, "/api/companies/?name=steppe", nil)
+	expectStatus(t, "list companies", status, http.StatusOK, body)
+	Decode(t, body, &list)
+	if list.Total != 1 || list.Companies[0].Name != "Steppe Mining Group" || str(list.Companies[0].Ticker) != "STM" {
+		t.Errorf("list companies: %s", body)
+	}
+
+	// Holdings worth more than the net worth leave a negative remainder.
+	value := money.FromInt(1500)
+	holding := models.Holding{CompanyID: company.ID, Role: "founder", Value: &value}
+	var added models.Holding
+	status, body = s.Do(t, http.MethodPost, millionaire+"/holdings", holding)
+	expectStatus(t, "add holding", status, http.StatusCreated, body)
+	Decode(t, body, &added)
+
+	var holdings []models.Holding
+	status, body = s.Do(t, http.MethodGet, millionaire+"/holdings", nil)
+	expectStatus(t, "list holdings", status, http.StatusOK, body)
+	Decode(t, body, &holdings)
+	if len(holdings) != 1 || holdings[0].CompanyName != "Steppe Mining Group" || amount(holdings[0].Value) != "1500" {
+		t.Errorf("list holdings: %s", body)
+	}
+
+	var breakdown models.NetWorthBreakdown
+	status, body = s.Do(t, http.MethodGet, millionaire+"/net-worth-breakdown", nil)
+	expectStatus(t, "breakdown", status, http.StatusOK, body)
+	Decode(t, body, &breakdown)
+	if breakdown.NetWorth.String() != "1000" || breakdown.Other.String() != "-500" || len(breakdown.Holdings) != 1 {
+		t.Errorf("breakdown: %s", body)
+	}
+
+	var people []models.CompanyPerson
+	status, body = s.Do(t, http.MethodGet, path+"/people", nil)
+	expectStatus(t, "people", status, http.StatusOK, body)
+	Decode(t, body, &people)
+	if len(people) != 1 || people[0].FirstName != "Aliya" || people[0].MillionaireID != id {
+		t.Errorf("people: %s", body)
+	}
+
+	status, body = s.Do(t, http.MethodDelete, path, nil)
+	expectStatus(t, "delete company with holdings", status, http.StatusConflict, body)
+
+	value = money.FromInt(400)
+	holding.Role = "ceo"
+	status, body = s.Do(t, http.MethodPut, fmt.Sprintf("%s/holdings/%d", millionaire, added.ID), holding)
+	expectStatus(t, "update holding", status, http.StatusOK, body)
+
+	status, body = s.Do(t, http.MethodGet, millionaire+"/net-worth-breakdown", nil)
+	expectStatus(t, "breakdown after update", status, http.StatusOK, body)
+	Decode(t, body, &breakdown)
+	if breakdown.Other.String() != "600" || len(breakdown.Holdings) != 1 || breakdown.Holdings[0].Role != "ceo" {
+		t.Errorf("breakdown after update: %s", body)
+	}
+
+	negative := money.FromInt(-1)
+	for _, c := range []struct {
+		what, method, path string
+		body               interface{}
+		want               int
+	}{
+		{"negative value", http.MethodPost, millionaire + "/holdings", models.Holding{CompanyID: company.ID, Role: "ceo", Value: &negative}, http.StatusBadRequest},
+		{"unknown company", http.MethodPost, millionaire + "/holdings", models.Holding{CompanyID: company.ID + 1, Role: "ceo"}, http.StatusNotFound},
+		{"unknown millionaire", http.MethodPost, fmt.Sprintf("/api/millionaires/%d/holdings", id+1), holding, http.StatusNotFound},
+		{"unknown holding", http.MethodPut, fmt.Sprintf("%s/holdings/%d", millionaire, added.ID+1), holding, http.StatusNotFound},
+		{"get unknown company", http.MethodGet, fmt.Sprintf("/api/companies/%d", company.ID+1), nil, http.StatusNotFound},
+		{"update unknown company", http.MethodPut, fmt.Sprintf("/api/companies/%d", company.ID+1), company, http.StatusNotFound},
+		{"breakdown of unknown millionaire", http.MethodGet, fmt.Sprintf("/api/millionaires/%d/net-worth-breakdown", id+1), nil, http.StatusNotFound},
+	} {
+		status, body = s.Do(t, c.method, c.path, c.body)
+		if status != c.want {
+			t.Errorf("%s: status %d, want %d (body %s)", c.what, status, c.want, body)
+		}
+	}
+
+	status, body = s.Do(t, http.MethodDelete, fmt.Sprintf("%s/holdings/%d", millionaire, added.ID), nil)
+	expectStatus(t, "delete holding", status, http.StatusOK, body)
+
+	status, body = s.Do(t, http.MethodGet, path+"/people", nil)
+	expectStatus(t, "people after delete", status, http.StatusOK, body)
+	if strings.TrimSpace(string(body)) != "[]" {
+		t.Errorf("people after delete: %s, want []", body)
+	}
+
+	status, body = s.Do(t, http.MethodDelete, path, nil)
+	expectStatus(t, "delete company", status, http.StatusOK, body)
+
+	status, body = s.Do(t, http.MethodGet, path, nil)
+	expectStatus(t, "get after delete", status, http.StatusNotFound, body)
+}
+
+func testSignedPhotoURLs(t *testing.T, s *Server) {
+	m := repotest.New("Signed", "Person", 1000)
+	m.PathToPhoto = ptr("uploads/photos/1_0123456789abcdef.jpg")
+	status, body := s.Do(t, http.MethodPost, "/api/millionaires/", m)
+	expectStatus(t, "create", status, http.StatusCreated, body)
+
+	var got models.Millionaire
+	status, body = s.Do(t, http.MethodGet, fmt.Sprintf("/api/millionaires/%d", created(t, s)), nil)
+	expectStatus(t, "get", status, http.StatusOK, body)
+	Decode(t, body, &got)
+
+	photo := strings.TrimPrefix(str(got.PathToPhoto), s.URL)
+	thumbnail := strings.TrimPrefix(got.ThumbnailURL, s.URL)
+	if !strings.HasPrefix(photo, "/api/photo/1_0123456789abcdef.jpg?expires=") || !strings.Contains(photo, "&signature=") {
+		t.Fatalf("photo %q, want a signed URL", photo)
+	}
+	if !strings.HasPrefix(thumbnail, "/api/photo/1_0123456789abcdef.jpg?") || !strings.Contains(thumbnail, "size=thumb") || !strings.Contains(thumbnail, "signature=") {
+		t.Fatalf("thumbnail %q, want a signed URL", thumbnail)
+	}
+
+	// The file does not exist, so a URL that passes the check is not found.
+	for _, c := range []struct {
+		what, path string
+		want       int
+	}{
+		{"signed photo", photo, http.StatusNotFound},
+		{"signed thumbnail", thumbnail, http.StatusNotFound},
+		{"unsigned", "/api/photo/1_0123456789abcdef.jpg", http.StatusForbidden},
+		{"another photo", strings.Replace(photo, "1_0123456789abcdef", "2_0123456789abcdef", 1), http.StatusForbidden},
+		{"later expiry", strings.Replace(photo, "expires=", "expires=9", 1), http.StatusForbidden},
+		{"wrong signature", strings.Replace(photo, "signature=", "signature=x", 1), http.StatusForbidden},
+	} {
+		status, body := s.Do(t, http.MethodGet, c.path, nil)
+		if status != c.want {
+			t.Errorf("%s: status %d, want %d (body %s)", c.what, status, c.want, body)
+		}
+	}
+
+	// Sending the profile back keeps the stored path.
+	m.PathToPhoto = got.PathToPhoto
+	status, body = s.Do(t, http.MethodPut, fmt.Sprintf("/api/millionaires/%d", got.ID), m)
+	expectStatus(t, "update", status, http.StatusOK, body)
+	stored, err := s.Millionaires.GetByID(context.Background(), got.ID)
+	if err != nil || str(stored.PathToPhoto) != "uploads/photos/1_0123456789abcdef.jpg" {
+		t.Errorf("stored photo after update: %q, %v", str(stored.PathToPhoto), err)
+	}
+}
@@ -0,0 +1,9 @@
+package routertest_test
+
+import (
+	"testing"
+	"wealthlist/internal/repo/repotest"
+	"wealthlist/internal/router/routertest"
+)
+
+func TestAPI(t *testing.T) { routertest.MillionaireAPI(t, repotest.Memory) }
@@ -0,0 +1,215 @@
+// Package routertest serves the real router over httptest with the
+// in-memory millionaire repository, so handlers can be exercised end to end
+// without Postgres. Countries and industries are resolved against a small
+// sample vocabulary and net worths converted at sample exchange rates.
+// Vocabulary lookup, photo upload, merge, user and readiness endpoints still
+// need a database and are not wired up; redirects left by merges can be
+// added to Server.Redirects. Writes go through an in-memory unit of work
+// that records net worth history in Server.History.
+package routertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wealthlist/config"
+	"wealthlist/internal/cache"
+	"wealthlist/internal/handler"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/publicurl"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/repo/repotest"
+	"wealthlist/internal/router"
+	"wealthlist/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminToken is accepted by the server's /admin routes.
+const AdminToken = "routertest-admin-token"
+
+// PhotoURLSecret signs the photo URLs of servers made by NewSigningServer.
+const PhotoURLSecret = "routertest-photo-url-secret-0123456789"
+
+// TrustedProxy is the proxy whose forwarding headers the server believes:
+// the test client itself.
+const TrustedProxy = "127.0.0.1"
+
+// Countries and Industries are the sample vocabulary of the server, Rates
+// its exchange rates.
+var (
+	Countries = []models.Country{
+		{Code: "KZ", Alpha3: "KAZ", Name: "Kazakhstan", Names: map[string]string{"ru": "Казахстан", "kk": "Қазақстан"}},
+		{Code: "RU", Alpha3: "RUS", Name: "Russia", Names: map[string]string{"ru": "Россия", "kk": "Ресей"}},
+		{Code: "US", Alpha3: "USA", Name: "United States", Names: map[string]string{"ru": "Соединенные Штаты"}},
+		{Code: "NO", Alpha3: "NOR", Name: "Norway", Names: map[string]string{"ru": "Норвегия"}},
+	}
+	Industries = []models.Industry{
+		{Code: "technology", Name: "Technology"},
+		{Code: "technology.software", ParentCode: ptr("technology"), Name: "Software"},
+		{Code: "technology.telecom", ParentCode: ptr("technology"), Name: "Telecom"},
+		{Code: "energy", Name: "Energy"},
+	}
+	Rates = []models.ExchangeRate{
+		{Currency: "USD", Date: "2024-01-02", Rate: money.MustParse("1.1")},
+		{Currency: "USD", Date: "2025-01-02", Rate: money.MustParse("1.04")},
+		{Currency: "KZT", Date: "2024-01-02", Rate: money.FromInt(500)},
+		{Currency: "KZT", Date: "2025-01-02", Rate: money.FromInt(550)},
+	}
+)
+
+func ptr(s string) *string { return &s }
+
+// Server is a running test server and the repositories behind it.
+type Server struct {
+	*httptest.Server
+	Millionaires repo.MillionaireRepository
+	History      repo.History
+	Redirects    repo.Redirects
+}
+
+// NewServer starts a server backed by millionaires, or by an empty in-memory
+// repository when it is nil. It is closed when the test ends.
+func NewServer(t testing.TB, millionaires repo.MillionaireRepository) *Server {
+	t.Helper()
+	return NewCachedServer(t, millionaires, nil)
+}
+
+// NewCachedServer is NewServer with responses cached in store; a nil store
+// disables caching.
+func NewCachedServer(t testing.TB, millionaires repo.MillionaireRepository, store cache.Store) *Server {
+	t.Helper()
+	return newServer(t, millionaires, store, "")
+}
+
+// NewSigningServer is NewServer with photo URLs signed with PhotoURLSecret.
+func NewSigningServer(t testing.TB, millionaires repo.MillionaireRepository) *Server {
+	t.Helper()
+	return newServer(t, millionaires, nil, PhotoURLSecret)
+}
+
+func newServer(t testing.TB, millionaires repo.MillionaireRepository, store cache.Store, photoURLSecret string) *Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+
+	if millionaires == nil {
+		millionaires = repo.NewMemoryMillionaireRepo()
+	}
+
+	log := repotest.Logger()
+	cfg := &config.Config{}
+
+	vocabulary := repo.NewMemoryVocabulary(Countries, Industries)
+	history := repo.NewMemoryHistory()
+	companies := repo.NewMemoryCompanies(millionaires)
+	uow := repo.NewMemoryUnitOfWork(repo.Repos{
+		Millionaires: millionaires,
+		Companies:    companies,
+		Holdings:     companies.Holdings(),
+		History:      history,
+	})
+	var millionaireService service.MillionaireServiceInterface = service.NewMillionaireService(millionaires, uow, vocabulary, log)
+	companyService := service.NewCompanyService(companies, companies.Holdings(), millionaires, uow, log)
+	currencyService := service.NewCurrencyService(repo.NewMemoryRates(Rates...), log)
+	var homeService service.HomeServiceInterface = service.NewHomeService(millionaires, repo.NewMemoryHomeSections(), vocabulary, log)
+	statsService := service.NewStatsService(millionaires, vocabulary, currencyService, log)
+	photoService := service.NewPhotoService(nil, nil, log)
+	feedbackService := service.NewFeedbackService(cfg, log)
+	healthService := service.NewHealthService(nil, cfg, log)
+	redirects := repo.NewMemoryRedirects()
+	mergeService := service.NewMergeService(millionaires, redirects, photoService, nil, log)
+
+	if store != nil {
+		c := cache.New(store, time.Minute, log)
+		millionaireService = service.NewCachedMillionaireService(millionaireService, c)
+		homeService = service.NewCachedHomeService(homeService, c)
+		photoService.OnChange(c.Invalidate)
+		mergeService.OnChange(c.Invalidate)
+	}
+
+	urls, err := publicurl.New("", []string{TrustedProxy})
+	if err != nil {
+		t.Fatalf("public URLs: %v", err)
+	}
+	if photoURLSecret != "" {
+		urls.SignPhotos(photoURLSecret, time.Hour)
+	}
+
+	r := router.SetupRouter(
+		handler.NewMillionaireHandler(millionaireService, currencyService, mergeService, log),
+		handler.NewCompanyHandler(companyService, log),
+		handler.NewVocabularyHandler(service.NewVocabularyService(nil, log), log),
+		handler.NewPhotoHandler(photoService, log),
+		handler.NewHomeHandler(homeService, currencyService, log),
+		handler.NewStatsHandler(statsService, log),
+		handler.NewMergeHandler(mergeService, log),
+		handler.NewFeedbackHandler(feedbackService, log),
+		handler.NewHealthHandler(healthService, log),
+		handler.NewAdminHandler(log),
+		AdminToken, nil, urls, log,
+	)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return &Server{Server: srv, Millionaires: millionaires, History: history, Redirects: redirects}
+}
+
+// Do sends body, if not nil, as JSON and returns the status and response
+// body. Headers are given as name, value pairs.
+func (s *Server) Do(t testing.TB, method, path string, body interface{}, headers ...string) (int, []byte) {
+	t.Helper()
+	status, _, out := s.Send(t, method, path, body, headers...)
+	return status, out
+}
+
+// Send is Do that also returns the response headers.
+func (s *Server) Send(t testing.TB, method, path string, body interface{}, headers ...string) (int, http.Header, []byte) {
+	t.Helper()
+
+	var in io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encode request body: %v", err)
+		}
+		in = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, s.URL+path, in)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s %s: read body: %v", method, path, err)
+	}
+	return resp.StatusCode, resp.Header, out
+}
+
+// Decode unmarshals a JSON response body into v.
+func Decode(t testing.TB, body []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("decode response %q: %v", body, err)
+	}
+}
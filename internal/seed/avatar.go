@@ -0,0 +1,71 @@
+package seed
+
+import (
+	"bytes"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+)
+
+const avatarSize = 256
+
+// Avatar draws a placeholder portrait, a light silhouette on a background
+// whose colour is derived from the person's name.
+func Avatar(p Person) ([]byte, error) {
+	h := fnv.New32a()
+	h.Write([]byte(p.Millionaire.FirstName + " " + p.Millionaire.LastName))
+	sum := h.Sum32()
+
+	bg := hsl(float64(sum%360), 0.45, 0.55)
+	fg := hsl(float64(sum%360), 0.35, 0.88)
+
+	img := image.NewNRGBA(image.Rect(0, 0, avatarSize, avatarSize))
+	for y := 0; y < avatarSize; y++ {
+		for x := 0; x < avatarSize; x++ {
+			fx, fy := float64(x)/avatarSize, float64(y)/avatarSize
+			head := sq(fx-0.5)+sq(fy-0.38) < sq(0.17)
+			shoulders := fy > 0.62 && sq((fx-0.5)/0.36)+sq((fy-1.0)/0.38) < 1
+			if head || shoulders {
+				img.Set(x, y, fg)
+			} else {
+				img.Set(x, y, bg)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func sq(v float64) float64 { return v * v }
+
+// hsl converts a hue in degrees and saturation and lightness in [0, 1].
+func hsl(h, s, l float64) color.NRGBA {
+	c := (1 - math.Abs(2*l-1)) * s
+	hp := h / 60
+	x := c * (1 - math.Abs(math.Mod(hp, 2)-1))
+
+	var r, g, b float64
+	switch {
+	case hp < 1:
+		r, g = c, x
+	case hp < 2:
+		r, g = x, c
+	case hp < 3:
+		g, b = c, x
+	case hp < 4:
+		g, b = x, c
+	case hp < 5:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+
+	m := l - c/2
+	return color.NRGBA{R: uint8((r + m) * 255), G: uint8((g + m) * 255), B: uint8((b + m) * 255), A: 255}
+}
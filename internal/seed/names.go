@@ -0,0 +1,169 @@
+package seed
+
+import "math/rand/v2"
+
+type country struct {
+	name   string
+	code   string
+	cities []string
+}
+
+type industry struct {
+	name  string
+	code  string
+	nouns []string
+}
+
+// givenName pairs a man's name with the stem of the patronymic derived
+// from it, e.g. Sergey -> Sergeev-ich / Sergeev-na.
+type givenName struct {
+	name, patronymic string
+}
+
+type culture struct {
+	male, female []givenName
+	surnames     []string
+	// feminine turns a surname into its feminine form; nil when surnames
+	// do not change.
+	feminine func(string) string
+	// patronymic builds the middle name from the father's name; nil when
+	// the culture uses optional given middle names instead.
+	patronymic     func(father givenName, female bool) string
+	countries      []country
+	countryWeights []int
+	brands         []string
+	legalForms     []string
+}
+
+func (c culture) name(rng *rand.Rand, female bool) (first, last string, middle *string) {
+	given := c.male
+	if female {
+		given = c.female
+	}
+	first = pick(rng, given, nil).name
+
+	last = pick(rng, c.surnames, nil)
+	if female && c.feminine != nil {
+		last = c.feminine(last)
+	}
+
+	switch {
+	case c.patronymic != nil:
+		middle = ptr(c.patronymic(pick(rng, c.male, nil), female))
+	case rng.IntN(5) < 3:
+		if m := pick(rng, given, nil).name; m != first {
+			middle = &m
+		}
+	}
+	return first, last, middle
+}
+
+func slavicFeminine(surname string) string { return surname + "a" }
+
+var cultures = []culture{
+	{
+		male: []givenName{
+			{"Nurlan", "Nurlan"}, {"Askar", "Askar"}, {"Yerlan", "Yerlan"}, {"Daniyar", "Daniyar"}, {"Serik", "Serik"},
+			{"Bolat", "Bolat"}, {"Timur", "Timur"}, {"Arman", "Arman"}, {"Kairat", "Kairat"}, {"Marat", "Marat"},
+			{"Azamat", "Azamat"}, {"Yerzhan", "Yerzhan"}, {"Dauren", "Dauren"}, {"Aidos", "Aidos"}, {"Baurzhan", "Baurzhan"},
+			{"Nurzhan", "Nurzhan"}, {"Sanzhar", "Sanzhar"}, {"Almas", "Almas"}, {"Talgat", "Talgat"}, {"Murat", "Murat"},
+		},
+		female: []givenName{
+			{"Aigerim", ""}, {"Dana", ""}, {"Aliya", ""}, {"Gulnara", ""}, {"Saule", ""}, {"Madina", ""}, {"Ainur", ""},
+			{"Zhanar", ""}, {"Dinara", ""}, {"Assel", ""}, {"Kamila", ""}, {"Aruzhan", ""}, {"Meruert", ""}, {"Gaukhar", ""},
+		},
+		surnames: []string{
+			"Nurpeisov", "Abenov", "Kassymov", "Zhakupov", "Utemuratov", "Suleimenov", "Ibraimov", "Akhmetov",
+			"Bekmukhambetov", "Dzhaksybekov", "Omarov", "Sarsenov", "Zhumabayev", "Karimov", "Baimukhanov",
+			"Yessenov", "Kenzhebekov", "Tuleshov", "Mukhametzhanov", "Amanzholov",
+		},
+		feminine: slavicFeminine,
+		patronymic: func(father givenName, female bool) string {
+			if female {
+				return father.patronymic + "kyzy"
+			}
+			return father.patronymic + "uly"
+		},
+		countries: []country{
+			{"Kazakhstan", "KZ", []string{"Almaty", "Astana", "Shymkent", "Karaganda", "Aktobe", "Pavlodar", "Oskemen", "Atyrau", "Kostanay", "Taraz", "Petropavl", "Aktau"}},
+			{"United Arab Emirates", "AE", []string{"Dubai", "Abu Dhabi"}},
+		},
+		countryWeights: []int{4, 1},
+		brands:         []string{"Altyn", "Steppe", "Nomad", "Baiterek", "Caspian", "Kazyna", "Saryarka", "Tulpar", "Zhetisu", "Alatau", "Kokzhiek", "Sunkar"},
+		legalForms:     []string{"JSC", "LLP", "Group", "Holding"},
+	},
+	{
+		male: []givenName{
+			{"Aleksandr", "Aleksandrov"}, {"Dmitry", "Dmitriev"}, {"Sergey", "Sergeev"}, {"Andrey", "Andreev"},
+			{"Alexey", "Alexeev"}, {"Mikhail", "Mikhailov"}, {"Ivan", "Ivanov"}, {"Vladimir", "Vladimirov"},
+			{"Nikolai", "Nikolaev"}, {"Pavel", "Pavlov"}, {"Roman", "Romanov"}, {"Oleg", "Olegov"},
+			{"Konstantin", "Konstantinov"}, {"Viktor", "Viktorov"}, {"Yuri", "Yuriev"}, {"Maxim", "Maximov"},
+			{"Igor", "Igorev"}, {"Boris", "Borisov"}, {"Grigory", "Grigoriev"}, {"Artem", "Artemov"},
+		},
+		female: []givenName{
+			{"Anna", ""}, {"Elena", ""}, {"Olga", ""}, {"Natalia", ""}, {"Tatiana", ""}, {"Irina", ""}, {"Svetlana", ""},
+			{"Ekaterina", ""}, {"Maria", ""}, {"Yulia", ""}, {"Anastasia", ""}, {"Marina", ""}, {"Ksenia", ""}, {"Daria", ""},
+		},
+		surnames: []string{
+			"Ivanov", "Smirnov", "Kuznetsov", "Popov", "Sokolov", "Lebedev", "Kozlov", "Novikov", "Morozov", "Volkov",
+			"Solovyov", "Vasiliev", "Zaitsev", "Pavlov", "Semenov", "Golubev", "Vinogradov", "Bogdanov", "Vorobyov", "Fedorov",
+		},
+		feminine: slavicFeminine,
+		patronymic: func(father givenName, female bool) string {
+			if female {
+				return father.patronymic + "na"
+			}
+			return father.patronymic + "ich"
+		},
+		countries: []country{
+			{"Russia", "RU", []string{"Moscow", "Saint Petersburg", "Novosibirsk", "Yekaterinburg", "Kazan", "Nizhny Novgorod", "Samara", "Omsk", "Perm", "Krasnoyarsk"}},
+			{"Kazakhstan", "KZ", []string{"Petropavl", "Kostanay", "Pavlodar", "Oskemen", "Karaganda"}},
+		},
+		countryWeights: []int{3, 1},
+		brands:         []string{"Ural", "Volga", "Sibir", "Baltic", "Polar", "Neva", "Rus", "Severny", "Amur", "Taiga"},
+		legalForms:     []string{"Group", "Holding", "PJSC", "JSC"},
+	},
+	{
+		male: []givenName{
+			{"James", ""}, {"John", ""}, {"Robert", ""}, {"Michael", ""}, {"William", ""}, {"David", ""}, {"Richard", ""},
+			{"Thomas", ""}, {"Charles", ""}, {"Daniel", ""}, {"Matthew", ""}, {"Andrew", ""}, {"George", ""}, {"Edward", ""}, {"Henry", ""},
+		},
+		female: []givenName{
+			{"Mary", ""}, {"Elizabeth", ""}, {"Sarah", ""}, {"Emily", ""}, {"Jennifer", ""}, {"Laura", ""}, {"Rachel", ""},
+			{"Catherine", ""}, {"Olivia", ""}, {"Charlotte", ""}, {"Grace", ""}, {"Alice", ""}, {"Susan", ""}, {"Helen", ""}, {"Victoria", ""},
+		},
+		surnames: []string{
+			"Smith", "Johnson", "Williams", "Brown", "Jones", "Miller", "Davis", "Wilson", "Anderson", "Taylor",
+			"Thomas", "Moore", "Clark", "Walker", "Hall", "Wright", "Harris", "Cooper", "Bennett", "Hughes",
+		},
+		countries: []country{
+			{"USA", "US", []string{"New York", "Chicago", "Boston", "San Francisco", "Seattle", "Houston", "Los Angeles", "Austin"}},
+			{"United Kingdom", "GB", []string{"London", "Manchester", "Edinburgh", "Birmingham", "Bristol"}},
+			{"Canada", "CA", []string{"Toronto", "Vancouver", "Montreal", "Calgary"}},
+			{"Australia", "AU", []string{"Sydney", "Melbourne", "Perth", "Brisbane"}},
+		},
+		countryWeights: []int{2, 1, 1, 1},
+		brands:         []string{"Summit", "Northstar", "Atlas", "Meridian", "Silverline", "Blue Harbor", "Redwood", "Keystone", "Evergreen", "Pioneer"},
+		legalForms:     []string{"Inc.", "Ltd", "Group", "Partners", "Holdings"},
+	},
+}
+
+// cultureWeights: Kazakh, Russian, English.
+var cultureWeights = []int{40, 30, 30}
+
+var industries = []industry{
+	{"Technology", "technology", []string{"Tech", "Soft", "Digital", "Systems"}},
+	{"Finance & Investments", "finance", []string{"Capital", "Invest", "Bank", "Finance"}},
+	{"Energy", "energy", []string{"Energy", "Oil", "Gas", "Power"}},
+	{"Metals & Mining", "materials.mining", []string{"Mining", "Metals", "Gold", "Steel"}},
+	{"Real Estate", "real-estate", []string{"Development", "Estates", "Properties"}},
+	{"Retail", "consumer.retail", []string{"Trade", "Retail", "Market"}},
+	{"Telecom", "technology.telecom", []string{"Telecom", "Mobile", "Connect"}},
+	{"Food & Beverage", "consumer.food-beverage", []string{"Foods", "Beverages", "Dairy"}},
+	{"Logistics", "industrials.logistics", []string{"Logistics", "Trans", "Cargo"}},
+	{"Healthcare", "healthcare", []string{"Pharm", "Health", "Medical"}},
+	{"Media & Entertainment", "media", []string{"Media", "Studios", "Broadcasting"}},
+	{"Construction", "industrials.construction", []string{"Construction", "Build", "Engineering"}},
+	{"Agriculture", "agriculture", []string{"Agro", "Grain", "Farms"}},
+	{"Automotive", "consumer.automotive", []string{"Motors", "Auto"}},
+}
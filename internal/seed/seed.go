@@ -0,0 +1,163 @@
+// Package seed generates believable sample millionaires for development
+// databases, demos and load tests. The same seed value always yields the
+// same people.
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+)
+
+const (
+	// minNetWorth and paretoAlpha shape the net worth distribution: a
+	// Pareto tail where roughly a fifth of the people hold four fifths of
+	// the wealth.
+	minNetWorth = 1_000_000
+	maxNetWorth = 250_000_000_000
+	paretoAlpha = 1.16
+
+	historyYears = 10
+
+	// currency is the currency of generated net worths.
+	currency = "USD"
+)
+
+// ReferenceDate is "today" for generated data, so ages and histories do
+// not depend on when the seed runs.
+var ReferenceDate = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Person is a generated millionaire with a yearly net worth history ending
+// at ReferenceDate.
+type Person struct {
+	Millionaire models.Millionaire
+	History     []models.NetWorthPoint
+}
+
+// Key identifies a person the way `wealthlist seed` recognises existing
+// rows: by last name, first name and birth date.
+func (p Person) Key() (lastName, firstName, birthDate string) {
+	return p.Millionaire.LastName, p.Millionaire.FirstName, *p.Millionaire.BirthDate
+}
+
+// Generate returns n distinct people. Generate(s, n) is a prefix of
+// Generate(s, m) for m > n, so a larger run extends a smaller one.
+func Generate(seed uint64, n int) []Person {
+	rng := rand.New(rand.NewPCG(seed, 0x9e3779b97f4a7c15))
+	seen := make(map[string]bool, n)
+
+	people := make([]Person, 0, n)
+	for len(people) < n {
+		p := generatePerson(rng)
+		last, first, birth := p.Key()
+		key := last + "|" + first + "|" + birth
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		people = append(people, p)
+	}
+	return people
+}
+
+func generatePerson(rng *rand.Rand) Person {
+	c := pick(rng, cultures, cultureWeights)
+	female := rng.IntN(4) == 0
+
+	first, last, middle := c.name(rng, female)
+	country := pick(rng, c.countries, c.countryWeights)
+	industry := pick(rng, industries, nil)
+	company := companyName(rng, c, industry, last)
+	birthDate := birthDate(rng)
+	netWorth := netWorth(rng)
+	asOf := ReferenceDate.Format(time.DateOnly)
+
+	m := models.Millionaire{
+		LastName:         last,
+		FirstName:        first,
+		MiddleName:       middle,
+		BirthDate:        &birthDate,
+		BirthPlace:       ptr(pick(rng, country.cities, nil)),
+		Company:          &company,
+		NetWorth:         ptr(money.FromInt(int64(netWorth))),
+		NetWorthCurrency: currency,
+		NetWorthAsOf:     &asOf,
+		Industry:         ptr(industry.name),
+		Country:          ptr(country.name),
+		CountryCode:      ptr(country.code),
+		IndustryCode:     ptr(industry.code),
+	}
+	return Person{Millionaire: m, History: history(rng, netWorth)}
+}
+
+// netWorth draws from the Pareto distribution and keeps three significant
+// digits, the precision rich lists publish.
+func netWorth(rng *rand.Rand) float64 {
+	v := minNetWorth / math.Pow(1-rng.Float64(), 1/paretoAlpha)
+	v = math.Min(v, maxNetWorth)
+	return roundSignificant(v, 3)
+}
+
+func roundSignificant(v float64, digits int) float64 {
+	scale := math.Pow(10, math.Floor(math.Log10(v))-float64(digits-1))
+	return math.Round(v/scale) * scale
+}
+
+// birthDate puts most people between 40 and 70.
+func birthDate(rng *rand.Rand) string {
+	age := int(math.Round(55 + 12*rng.NormFloat64()))
+	age = min(max(age, 25), 92)
+	d := ReferenceDate.AddDate(-age, 0, -rng.IntN(365)-1)
+	return d.Format(time.DateOnly)
+}
+
+// history walks back from the current net worth one year at a time with a
+// noisy yearly growth of about 8%.
+func history(rng *rand.Rand, current float64) []models.NetWorthPoint {
+	points := make([]models.NetWorthPoint, historyYears)
+	v := current
+	for i := historyYears - 1; i >= 0; i-- {
+		points[i] = models.NetWorthPoint{
+			Date:     ReferenceDate.AddDate(i-historyYears+1, 0, 0).Format(time.DateOnly),
+			NetWorth: money.FromInt(int64(roundSignificant(v, 3))),
+			Currency: currency,
+		}
+		growth := math.Exp(0.08 + 0.25*rng.NormFloat64())
+		v = math.Max(v/growth, 1000)
+	}
+	return points
+}
+
+func companyName(rng *rand.Rand, c culture, ind industry, lastName string) string {
+	var base string
+	if rng.IntN(3) == 0 {
+		base = lastName
+	} else {
+		base = pick(rng, c.brands, nil)
+	}
+	return fmt.Sprintf("%s %s %s", base, pick(rng, ind.nouns, nil), pick(rng, c.legalForms, nil))
+}
+
+// pick returns a random element, weighted when weights is not nil.
+func pick[T any](rng *rand.Rand, items []T, weights []int) T {
+	if weights == nil {
+		return items[rng.IntN(len(items))]
+	}
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	n := rng.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return items[i]
+		}
+		n -= w
+	}
+	return items[len(items)-1]
+}
+
+func ptr[T any](v T) *T { return &v }
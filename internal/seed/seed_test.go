@@ -0,0 +1,48 @@
+package seed
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	people := Generate(42, 50)
+	if again := Generate(42, 50); !reflect.DeepEqual(people, again) {
+		t.Fatal("Generate(42, 50) differs between runs")
+	}
+	if fewer := Generate(42, 20); !reflect.DeepEqual(fewer, people[:20]) {
+		t.Error("Generate(42, 20) is not a prefix of Generate(42, 50)")
+	}
+
+	// Seeded databases must get the same people after code changes too.
+	var first []string
+	for _, p := range people[:3] {
+		last, firstName, birth := p.Key()
+		first = append(first, fmt.Sprintf("%s %s %s %s", last, firstName, birth, p.Millionaire.NetWorth))
+	}
+	want := []string{
+		"Abenov Askar 1968-08-08 1040000",
+		"Suleimenov Kairat 1965-05-02 1260000",
+		"Akhmetov Askar 1962-03-26 1290000",
+	}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("Generate(42, 3) = %q, want %q", first, want)
+	}
+
+	keys := make(map[string]bool)
+	for _, p := range people {
+		last, firstName, birth := p.Key()
+		keys[last+"|"+firstName+"|"+birth] = true
+		if len(p.History) != historyYears {
+			t.Errorf("%s %s: %d history points, want %d", firstName, last, len(p.History), historyYears)
+		}
+	}
+	if len(keys) != len(people) {
+		t.Errorf("%d distinct people of %d", len(keys), len(people))
+	}
+
+	if other := Generate(43, 3); reflect.DeepEqual(other, people[:3]) {
+		t.Error("Generate(43, 3) equals Generate(42, 3)")
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+	"wealthlist/config"
+	"wealthlist/internal/logger"
+)
+
+type Server struct {
+	httpServer *http.Server
+	cfg        config.ServerConfig
+	log        *slog.Logger
+	onShutdown []func()
+}
+
+func New(cfg config.ServerConfig, handler http.Handler, log *slog.Logger) (*Server, error) {
+	srv := &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(log.Handler(), slog.LevelError),
+	}
+
+	if cfg.TLS.ClientCAFile != "" {
+		if cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" {
+			return nil, errors.New("client CA configured without a server certificate and key")
+		}
+
+		tlsConfig, err := clientAuthTLSConfig(cfg.TLS.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		srv.TLSConfig = tlsConfig
+	}
+
+	return &Server{httpServer: srv, cfg: cfg, log: log}, nil
+}
+
+func clientAuthTLSConfig(caFile string) (*tls.Config, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", caFile)
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+func (s *Server) tlsEnabled() bool {
+	return s.cfg.TLS.CertFile != "" && s.cfg.TLS.KeyFile != ""
+}
+
+// OnShutdown registers f to run as soon as shutdown begins, before the
+// listener is closed.
+func (s *Server) OnShutdown(f func()) {
+	s.onShutdown = append(s.onShutdown, f)
+}
+
+// Run serves until ctx is cancelled, then stops accepting connections and
+// waits up to ShutdownTimeout for in-flight requests to finish.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		s.log.Info("Starting server",
+			slog.String("addr", s.httpServer.Addr),
+			slog.Bool("tls", s.tlsEnabled()),
+			slog.Bool("mtls", s.cfg.TLS.ClientCAFile != ""),
+		)
+
+		var err error
+		if s.tlsEnabled() {
+			err = s.httpServer.ListenAndServeTLS(s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile)
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err, ok := <-errCh:
+		if ok {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.log.Info("Shutting down server", slog.Duration("timeout", s.cfg.ShutdownTimeout))
+
+	for _, f := range s.onShutdown {
+		f()
+	}
+
+	// Keep serving while load balancers observe the failing readiness probe.
+	if s.cfg.ShutdownDelay > 0 {
+		time.Sleep(s.cfg.ShutdownDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		s.log.Error("Graceful shutdown did not complete", logger.Err(err))
+		s.httpServer.Close()
+		return err
+	}
+
+	s.log.Info("Server stopped")
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"wealthlist/internal/cache"
+	"wealthlist/internal/models"
+	"wealthlist/internal/repo"
+)
+
+// cached serves a read from c, unless the request reads from the primary
+// (see repo.WithPrimary): a client that asked for fresh data gets it.
+func cached[T any](ctx context.Context, c *cache.Cache, name, key string, load func(context.Context) (T, error)) (T, error) {
+	if repo.UsesPrimary(ctx) {
+		return cache.Skip(ctx, name, load)
+	}
+	return cache.Do(ctx, c, name, key, load)
+}
+
+// cachedMillionaireService serves lists, searches and profiles from a cache
+// and invalidates it after every write.
+type cachedMillionaireService struct {
+	next  MillionaireServiceInterface
+	cache *cache.Cache
+}
+
+var _ MillionaireServiceInterface = (*cachedMillionaireService)(nil)
+
+func NewCachedMillionaireService(next MillionaireServiceInterface, c *cache.Cache) MillionaireServiceInterface {
+	return &cachedMillionaireService{next: next, cache: c}
+}
+
+func (s *cachedMillionaireService) CreateMillionaire(ctx context.Context, m *models.Millionaire) error {
+	err := s.next.CreateMillionaire(ctx, m)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+func (s *cachedMillionaireService) CreateMillionaires(ctx context.Context, ms []models.Millionaire) error {
+	err := s.next.CreateMillionaires(ctx, ms)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+func (s *cachedMillionaireService) SearchMillionaire(ctx context.Context, lastName, firstName, middleName, country, industry string, pageNum, pageSize int) (models.PaginationMillionaireDto, error) {
+	key := cache.Key(lastName, firstName, middleName, country, industry, pageNum, pageSize)
+	return cached(ctx, s.cache, "search", key, func(ctx context.Context) (models.PaginationMillionaireDto, error) {
+		return s.next.SearchMillionaire(ctx, lastName, firstName, middleName, country, industry, pageNum, pageSize)
+	})
+}
+
+func (s *cachedMillionaireService) GetAllMillionaires(ctx context.Context, pageNum, pageSize int) (models.PaginationMillionaireDto, error) {
+	return cached(ctx, s.cache, "list", cache.Key(pageNum, pageSize), func(ctx context.Context) (models.PaginationMillionaireDto, error) {
+		return s.next.GetAllMillionaires(ctx, pageNum, pageSize)
+	})
+}
+
+func (s *cachedMillionaireService) GetMillionaireByID(ctx context.Context, id int, withHTML bool) (*models.Millionaire, error) {
+	return cached(ctx, s.cache, "profile", cache.Key(id, withHTML), func(ctx context.Context) (*models.Millionaire, error) {
+		return s.next.GetMillionaireByID(ctx, id, withHTML)
+	})
+}
+
+func (s *cachedMillionaireService) UpdateMillionaire(ctx context.Context, m *models.Millionaire) error {
+	err := s.next.UpdateMillionaire(ctx, m)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+func (s *cachedMillionaireService) DeleteMillionaire(ctx context.Context, id int) error {
+	err := s.next.DeleteMillionaire(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+// cachedHomeService serves the homepage from a cache and invalidates it when
+// a section changes. Section definitions themselves are not cached.
+type cachedHomeService struct {
+	next  HomeServiceInterface
+	cache *cache.Cache
+}
+
+var _ HomeServiceInterface = (*cachedHomeService)(nil)
+
+func NewCachedHomeService(next HomeServiceInterface, c *cache.Cache) HomeServiceInterface {
+	return &cachedHomeService{next: next, cache: c}
+}
+
+func (s *cachedHomeService) GetHomePageData(ctx context.Context) (*models.HomePageDto, error) {
+	return cached(ctx, s.cache, "home", cache.Key(), s.next.GetHomePageData)
+}
+
+func (s *cachedHomeService) Sections(ctx context.Context) ([]models.HomeSection, error) {
+	return s.next.Sections(ctx)
+}
+
+func (s *cachedHomeService) Section(ctx context.Context, id int) (*models.HomeSection, error) {
+	return s.next.Section(ctx, id)
+}
+
+func (s *cachedHomeService) CreateSection(ctx context.Context, section *models.HomeSection) error {
+	err := s.next.CreateSection(ctx, section)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+func (s *cachedHomeService) UpdateSection(ctx context.Context, section *models.HomeSection) error {
+	err := s.next.UpdateSection(ctx, section)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+func (s *cachedHomeService) DeleteSection(ctx context.Context, id int) error {
+	err := s.next.DeleteSection(ctx, id)
+	if err == nil {
+		s.cache.Invalidate(ctx)
+	}
+	return err
+}
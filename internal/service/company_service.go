@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
+)
+
+var (
+	ErrCompanyNotFound    = errors.New("company not found")
+	ErrCompanyExists      = errors.New("company with this legal ID already exists")
+	ErrCompanyHasHoldings = errors.New("company still has holdings")
+	ErrHoldingNotFound    = errors.New("holding not found")
+)
+
+type CompanyService struct {
+	companies    repo.Companies
+	holdings     repo.Holdings
+	millionaires repo.MillionaireRepository
+	uow          repo.Transactor
+	log          *slog.Logger
+}
+
+func NewCompanyService(companies repo.Companies, holdings repo.Holdings, millionaires repo.MillionaireRepository, uow repo.Transactor, log *slog.Logger) *CompanyService {
+	return &CompanyService{
+		companies:    companies,
+		holdings:     holdings,
+		millionaires: millionaires,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+// companyError maps repository errors to the service's.
+func companyError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrCompanyNotFound
+	case errors.Is(err, repo.ErrCompanyExists):
+		return ErrCompanyExists
+	case errors.Is(err, repo.ErrCompanyHasHoldings):
+		return ErrCompanyHasHoldings
+	}
+	return err
+}
+
+func (s *CompanyService) CreateCompany(ctx context.Context, c *models.Company) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.CreateCompany")
+	defer span.End()
+
+	if err := s.companies.Create(ctx, c); err != nil {
+		tracing.RecordError(span, err)
+		return companyError(err)
+	}
+	s.log.InfoContext(ctx, "Company created", slog.Int("id", c.ID))
+	return nil
+}
+
+func (s *CompanyService) GetCompany(ctx context.Context, id int) (*models.Company, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.GetCompany")
+	defer span.End()
+
+	c, err := s.companies.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, companyError(err)
+	}
+	return c, nil
+}
+
+// ListCompanies pages through companies whose name contains name; invalid
+// paging falls back to the first page of 10.
+func (s *CompanyService) ListCompanies(ctx context.Context, name string, pageNum, pageSize int) (models.PaginationCompanyDto, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.ListCompanies")
+	defer span.End()
+
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	result, err := s.companies.List(ctx, name, pageNum, pageSize)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return models.PaginationCompanyDto{}, err
+	}
+	return result, nil
+}
+
+func (s *CompanyService) UpdateCompany(ctx context.Context, c *models.Company) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.UpdateCompany")
+	defer span.End()
+
+	if err := s.companies.Update(ctx, c); err != nil {
+		tracing.RecordError(span, err)
+		return companyError(err)
+	}
+	s.log.InfoContext(ctx, "Company updated", slog.Int("id", c.ID))
+	return nil
+}
+
+// DeleteCompany refuses to delete a company that still has holdings, so
+// that no one's ownership history is lost by accident.
+func (s *CompanyService) DeleteCompany(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.DeleteCompany")
+	defer span.End()
+
+	if err := s.companies.Delete(ctx, id); err != nil {
+		tracing.RecordError(span, err)
+		return companyError(err)
+	}
+	s.log.InfoContext(ctx, "Company deleted", slog.Int("id", id))
+	return nil
+}
+
+// CompanyPeople lists everyone who holds or held a part in the company.
+func (s *CompanyService) CompanyPeople(ctx context.Context, companyID int) ([]models.CompanyPerson, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.CompanyPeople")
+	defer span.End()
+
+	if _, err := s.companies.GetByID(ctx, companyID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, companyError(err)
+	}
+
+	people, err := s.companies.People(ctx, companyID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return people, nil
+}
+
+func (s *CompanyService) ListHoldings(ctx context.Context, millionaireID int) ([]models.Holding, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.ListHoldings")
+	defer span.End()
+
+	if _, err := s.millionaires.GetByID(ctx, millionaireID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, millionaireError(err)
+	}
+
+	holdings, err := s.holdings.List(ctx, millionaireID, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return holdings, nil
+}
+
+// AddHolding checks that the millionaire and the company exist and stores
+// the holding in the same transaction.
+func (s *CompanyService) AddHolding(ctx context.Context, h *models.Holding) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.AddHolding")
+	defer span.End()
+
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		if err := s.checkHolding(ctx, tx, h); err != nil {
+			return err
+		}
+		return tx.Holdings.Add(ctx, h)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	s.log.InfoContext(ctx, "Holding added", slog.Int("id", h.ID), slog.Int("millionaireId", h.MillionaireID), slog.Int("companyId", h.CompanyID))
+	return nil
+}
+
+func (s *CompanyService) UpdateHolding(ctx context.Context, h *models.Holding) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.UpdateHolding")
+	defer span.End()
+
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		if err := s.checkHolding(ctx, tx, h); err != nil {
+			return err
+		}
+		err := tx.Holdings.Update(ctx, h)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrHoldingNotFound
+		}
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	s.log.InfoContext(ctx, "Holding updated", slog.Int("id", h.ID))
+	return nil
+}
+
+func (s *CompanyService) DeleteHolding(ctx context.Context, millionaireID, id int) error {
+	ctx, span := tracing.Start(ctx, "CompanyService.DeleteHolding")
+	defer span.End()
+
+	err := s.holdings.Delete(ctx, millionaireID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrHoldingNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	s.log.InfoContext(ctx, "Holding deleted", slog.Int("id", id))
+	return nil
+}
+
+// NetWorthBreakdown attributes the millionaire's net worth to current
+// holdings by their value. Holdings without a value count as zero; Other
+// is negative if the holdings are worth more than the recorded net worth.
+func (s *CompanyService) NetWorthBreakdown(ctx context.Context, millionaireID int) (*models.NetWorthBreakdown, error) {
+	ctx, span := tracing.Start(ctx, "CompanyService.NetWorthBreakdown")
+	defer span.End()
+
+	m, err := s.millionaires.GetByID(ctx, millionaireID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, millionaireError(err)
+	}
+
+	holdings, err := s.holdings.List(ctx, millionaireID, true)
+	if err != nil {
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to list holdings", logger.Err(err))
+		return nil, err
+	}
+
+	b := &models.NetWorthBreakdown{MillionaireID: millionaireID, Currency: m.NetWorthCurrency, Holdings: holdings}
+	if m.NetWorth != nil {
+		b.NetWorth = *m.NetWorth
+	}
+	b.Other = b.NetWorth
+	for _, h := range holdings {
+		if h.Value != nil {
+			b.Other = b.Other.Sub(*h.Value)
+		}
+	}
+	return b, nil
+}
+
+// checkHolding checks that the millionaire and the company exist and that
+// the value is a valid amount in the millionaire's currency.
+func (s *CompanyService) checkHolding(ctx context.Context, tx repo.Repos, h *models.Holding) error {
+	m, err := tx.Millionaires.GetByID(ctx, h.MillionaireID)
+	if err != nil {
+		return millionaireError(err)
+	}
+	if _, err := tx.Companies.GetByID(ctx, h.CompanyID); err != nil {
+		return companyError(err)
+	}
+	if h.Value != nil {
+		if h.Value.Sign() < 0 {
+			return fmt.Errorf("%w: value must not be negative", ErrInvalidAmount)
+		}
+		return checkScale("value", *h.Value, m.NetWorthCurrency)
+	}
+	return nil
+}
+
+// millionaireError maps a missing row to ErrMillionaireNotFound.
+func millionaireError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrMillionaireNotFound
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
+)
+
+var (
+	ErrUnknownCurrency = errors.New("no exchange rates for currency")
+	ErrInvalidAmount   = errors.New("invalid amount")
+)
+
+const (
+	// baseCurrency is the currency exchange rates are quoted against.
+	baseCurrency = "EUR"
+	// DefaultCurrency is assumed for valuations given without a currency.
+	DefaultCurrency = "USD"
+	// lastDay finds the latest rate of a currency.
+	lastDay = "9999-12-31"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// checkScale rejects an amount with more decimal places than currency has
+// minor units.
+func checkScale(what string, a money.Amount, currency string) error {
+	if units := money.MinorUnits(currency); a.Scale() > units {
+		return fmt.Errorf("%w: %s in %s has at most %d decimal places, got %s", ErrInvalidAmount, what, currency, units, a)
+	}
+	return nil
+}
+
+type CurrencyService struct {
+	rates repo.Rates
+	log   *slog.Logger
+}
+
+func NewCurrencyService(rates repo.Rates, log *slog.Logger) *CurrencyService {
+	return &CurrencyService{rates: rates, log: log}
+}
+
+// Convert restates net worths in currency at the rates in effect on each
+// valuation's day, rounded to the currency's minor units. A valuation
+// without rates for its day keeps its own currency. An empty currency
+// converts nothing; one that has never had a rate is ErrUnknownCurrency.
+func (s *CurrencyService) Convert(ctx context.Context, currency string, ms []models.Millionaire) error {
+	if currency == "" {
+		return nil
+	}
+	ctx, span := tracing.Start(ctx, "CurrencyService.Convert")
+	defer span.End()
+
+	currency = strings.ToUpper(currency)
+	// A zero rate stands for a missing one.
+	rates := make(map[[2]string]money.Amount)
+	rate := func(currency, day string) (money.Amount, error) {
+		if currency == baseCurrency {
+			return money.FromInt(1), nil
+		}
+		key := [2]string{currency, day}
+		if r, ok := rates[key]; ok {
+			return r, nil
+		}
+		r, err := s.rates.Rate(ctx, currency, day)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+		rates[key] = r
+		return r, err
+	}
+
+	if !currencyCode.MatchString(currency) {
+		return fmt.Errorf("%w %q", ErrUnknownCurrency, currency)
+	}
+	latest, err := rate(currency, lastDay)
+	if err != nil {
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to fetch exchange rate", logger.Err(err))
+		return err
+	}
+	if latest.IsZero() {
+		return fmt.Errorf("%w %q", ErrUnknownCurrency, currency)
+	}
+
+	for i := range ms {
+		m := &ms[i]
+		if m.NetWorth == nil || m.NetWorthAsOf == nil || m.NetWorthCurrency == currency {
+			continue
+		}
+		from, err := rate(m.NetWorthCurrency, *m.NetWorthAsOf)
+		if err != nil {
+			tracing.RecordError(span, err)
+			s.log.ErrorContext(ctx, "Failed to fetch exchange rate", logger.Err(err))
+			return err
+		}
+		to, err := rate(currency, *m.NetWorthAsOf)
+		if err != nil {
+			tracing.RecordError(span, err)
+			s.log.ErrorContext(ctx, "Failed to fetch exchange rate", logger.Err(err))
+			return err
+		}
+		if from.IsZero() || to.IsZero() {
+			s.log.DebugContext(ctx, "No exchange rate for valuation",
+				slog.Int("id", m.ID), slog.String("currency", m.NetWorthCurrency), slog.String("asOf", *m.NetWorthAsOf))
+			continue
+		}
+
+		v := m.NetWorth.Mul(to).Quo(from, money.MinorUnits(currency))
+		m.NetWorth = &v
+		m.NetWorthCurrency = currency
+	}
+	return nil
+}
+
+// Import saves exchange rates, replacing any stored for the same currency
+// and day.
+func (s *CurrencyService) Import(ctx context.Context, rates []models.ExchangeRate) error {
+	ctx, span := tracing.Start(ctx, "CurrencyService.Import")
+	defer span.End()
+
+	if err := s.rates.Save(ctx, rates); err != nil {
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to import exchange rates", logger.Err(err))
+		return err
+	}
+	s.log.InfoContext(ctx, "Exchange rates imported", slog.Int("count", len(rates)))
+	return nil
+}
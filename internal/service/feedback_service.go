@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/smtp"
 	"strings"
 	"wealthlist/config"
+	"wealthlist/internal/metrics"
 	"wealthlist/internal/models"
+	"wealthlist/internal/tracing"
 )
 
 type FeedbackService struct {
@@ -21,8 +24,11 @@ func NewFeedbackService(cfg *config.Config, log *slog.Logger) *FeedbackService {
 	}
 }
 
-func (s *FeedbackService) SendFeedbackEmail(feedback models.FeedbackDto) error {
-	s.log.Info("Sending feedback email", slog.String("from", feedback.Email))
+func (s *FeedbackService) SendFeedbackEmail(ctx context.Context, feedback models.FeedbackDto) error {
+	ctx, span := tracing.Start(ctx, "FeedbackService.SendFeedbackEmail")
+	defer span.End()
+
+	s.log.InfoContext(ctx, "Sending feedback email", slog.String("from", feedback.Email))
 
 	messageBody := s.createEmailBody(feedback)
 
@@ -42,11 +48,14 @@ func (s *FeedbackService) SendFeedbackEmail(feedback models.FeedbackDto) error {
 	)
 
 	if err != nil {
-		s.log.Error("Failed to send email", slog.String("error", err.Error()))
+		metrics.FeedbackFailed.Inc()
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to send email", slog.String("error", err.Error()))
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
-	s.log.Info("Email successfully sent",
+	metrics.FeedbackSent.Inc()
+	s.log.InfoContext(ctx, "Email successfully sent",
 		slog.String("to", s.smtpConfig.To),
 		slog.String("subject", "New feedback from the website"))
 
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+	"wealthlist/config"
+	"wealthlist/internal/buildinfo"
+	"wealthlist/internal/models"
+	"wealthlist/migrations"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+type HealthService struct {
+	db           *sql.DB
+	timeout      time.Duration
+	checks       []healthCheck
+	shuttingDown atomic.Bool
+	log          *slog.Logger
+}
+
+func NewHealthService(db *sql.DB, cfg *config.Config, log *slog.Logger) *HealthService {
+	s := &HealthService{
+		db:      db,
+		timeout: cfg.Health.CheckTimeout,
+		log:     log,
+	}
+
+	s.checks = []healthCheck{
+		{name: "database", check: s.checkDatabase},
+		{name: "migrations", check: s.checkMigrations},
+		{name: "storage", check: s.checkStorage},
+	}
+	if cfg.Health.CheckSMTP {
+		addr := net.JoinHostPort(cfg.SMTP.Host, strconv.Itoa(cfg.SMTP.Port))
+		s.checks = append(s.checks, healthCheck{
+			name:  "smtp",
+			check: func(ctx context.Context) error { return checkDial(ctx, addr) },
+		})
+	}
+
+	return s
+}
+
+// MarkShuttingDown makes every following readiness check fail so traffic is
+// routed away while in-flight requests drain.
+func (s *HealthService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// Readiness runs all dependency checks concurrently, each bounded by the
+// configured timeout.
+func (s *HealthService) Readiness(ctx context.Context) models.ReadinessDto {
+	result := models.ReadinessDto{
+		Status: StatusOK,
+		Checks: make([]models.HealthCheckResult, len(s.checks)),
+	}
+
+	if s.shuttingDown.Load() {
+		result.Status = StatusFail
+		result.Checks = []models.HealthCheckResult{{
+			Name:     "shutdown",
+			Status:   StatusFail,
+			Error:    ErrShuttingDown.Error(),
+			Duration: "0s",
+		}}
+		return result
+	}
+
+	var wg sync.WaitGroup
+	for i, hc := range s.checks {
+		wg.Add(1)
+		go func(i int, hc healthCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := hc.check(checkCtx)
+			r := models.HealthCheckResult{
+				Name:     hc.name,
+				Status:   StatusOK,
+				Duration: time.Since(start).String(),
+			}
+			if err != nil {
+				r.Status = StatusFail
+				r.Error = err.Error()
+			}
+			result.Checks[i] = r
+		}(i, hc)
+	}
+	wg.Wait()
+
+	for _, r := range result.Checks {
+		if r.Status != StatusOK {
+			result.Status = StatusFail
+			s.log.WarnContext(ctx, "Readiness check failed", slog.String("check", r.Name), slog.String("error", r.Error))
+		}
+	}
+
+	return result
+}
+
+func (s *HealthService) Version(ctx context.Context) models.VersionDto {
+	dto := models.VersionDto{Info: buildinfo.Get()}
+
+	if expected, err := migrations.LatestVersion(); err == nil {
+		dto.ExpectedSchemaVersion = expected
+	}
+
+	if current, err := migrations.CurrentVersion(s.db); err == nil {
+		dto.SchemaVersion = current
+	} else {
+		s.log.WarnContext(ctx, "Could not read schema version", slog.String("error", err.Error()))
+	}
+
+	return dto
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) error {
+	expected, err := migrations.LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	current, err := migrations.CurrentVersion(s.db)
+	if err != nil {
+		return err
+	}
+	if current > expected {
+		return fmt.Errorf("schema version %d, expected %d", current, expected)
+	}
+
+	pending, err := migrations.Pending(s.db)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d migration(s) not applied, first %d_%s", len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+func (s *HealthService) checkStorage(_ context.Context) error {
+	if err := os.MkdirAll(PhotoDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(PhotoDir, ".healthcheck-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+
+	return os.Remove(name)
+}
+
+func checkDial(ctx context.Context, addr string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -1,29 +1,278 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 	"wealthlist/internal/logger"
 	"wealthlist/internal/models"
 	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
 )
 
+var (
+	ErrSectionNotFound = errors.New("home section not found")
+	ErrInvalidSection  = errors.New("invalid home section")
+)
+
+// defaultSectionSize is the size of a section created without one.
+const defaultSectionSize = 10
+
+type HomeServiceInterface interface {
+	GetHomePageData(ctx context.Context) (*models.HomePageDto, error)
+	Sections(ctx context.Context) ([]models.HomeSection, error)
+	Section(ctx context.Context, id int) (*models.HomeSection, error)
+	CreateSection(ctx context.Context, section *models.HomeSection) error
+	UpdateSection(ctx context.Context, section *models.HomeSection) error
+	DeleteSection(ctx context.Context, id int) error
+}
+
 type HomeService struct {
-	repo repo.MillionaireRepository
-	log  *slog.Logger
+	millionaires repo.MillionaireRepository
+	sections     repo.HomeSections
+	vocab        repo.Vocabulary
+	log          *slog.Logger
+}
+
+var _ HomeServiceInterface = (*HomeService)(nil)
+
+func NewHomeService(millionaires repo.MillionaireRepository, sections repo.HomeSections, vocab repo.Vocabulary, log *slog.Logger) *HomeService {
+	return &HomeService{millionaires: millionaires, sections: sections, vocab: vocab, log: log}
+}
+
+// GetHomePageData fills the sections that are not hidden, in order.
+func (s *HomeService) GetHomePageData(ctx context.Context) (*models.HomePageDto, error) {
+	ctx, span := tracing.Start(ctx, "HomeService.GetHomePageData")
+	defer span.End()
+
+	sections, err := s.sections.List(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Error fetching home sections", logger.Err(err))
+		return nil, err
+	}
+
+	page := &models.HomePageDto{Sections: []models.HomeSectionDto{}}
+	for _, section := range sections {
+		if section.Hidden {
+			continue
+		}
+		entries, err := s.entries(ctx, section)
+		if err != nil {
+			tracing.RecordError(span, err)
+			s.log.ErrorContext(ctx, "Error filling home section", slog.Int("id", section.ID), logger.Err(err))
+			return nil, err
+		}
+		page.Sections = append(page.Sections, models.HomeSectionDto{
+			ID:      section.ID,
+			Kind:    section.Kind,
+			Title:   section.Title,
+			Entries: entries,
+		})
+	}
+	return page, nil
+}
+
+func (s *HomeService) entries(ctx context.Context, section models.HomeSection) ([]models.HomeEntry, error) {
+	filter := repo.MillionaireFilter{CountryCodes: section.Countries, IndustryCodes: section.Industries}
+
+	var millionaires []models.Millionaire
+	var err error
+	switch section.Kind {
+	case models.SectionTop:
+		millionaires, err = s.millionaires.Top(ctx, filter, section.Size)
+	case models.SectionNewcomers:
+		millionaires, err = s.millionaires.Newest(ctx, filter, section.Size)
+	case models.SectionFeatured:
+		millionaires, err = s.millionaires.GetByIDs(ctx, section.MillionaireIDs)
+	case models.SectionGainers, models.SectionLosers:
+		since := time.Now().AddDate(0, 0, -*section.WindowDays).Format(time.DateOnly)
+		entries, err := s.millionaires.Movers(ctx, filter, since, section.Size, section.Kind == models.SectionGainers)
+		if entries == nil {
+			entries = []models.HomeEntry{}
+		}
+		return entries, err
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidSection, section.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.HomeEntry, len(millionaires))
+	for i, m := range millionaires {
+		entries[i].Millionaire = m
+	}
+	return entries, nil
+}
+
+// checkSection fills in the default size, resolves country and industry
+// names to codes, and rejects settings that do not apply to the kind and
+// featured millionaires that do not exist.
+func (s *HomeService) checkSection(ctx context.Context, section *models.HomeSection) error {
+	if section.Size == 0 {
+		section.Size = defaultSectionSize
+	}
+
+	movers := section.Kind == models.SectionGainers || section.Kind == models.SectionLosers
+	switch {
+	case movers && section.WindowDays == nil:
+		return fmt.Errorf("%w: %s needs windowDays", ErrInvalidSection, section.Kind)
+	case !movers && section.WindowDays != nil:
+		return fmt.Errorf("%w: windowDays only applies to gainers and losers", ErrInvalidSection)
+	}
+
+	if section.Kind != models.SectionFeatured {
+		if len(section.MillionaireIDs) > 0 {
+			return fmt.Errorf("%w: millionaireIds only apply to featured", ErrInvalidSection)
+		}
+		var err error
+		if section.Countries, err = s.codes(ctx, s.vocab.ResolveCountry, section.Countries, ErrUnknownCountry); err != nil {
+			return err
+		}
+		section.Industries, err = s.codes(ctx, s.vocab.ResolveIndustry, section.Industries, ErrUnknownIndustry)
+		return err
+	}
+
+	if len(section.Countries) > 0 || len(section.Industries) > 0 {
+		return fmt.Errorf("%w: featured takes millionaireIds, not countries or industries", ErrInvalidSection)
+	}
+	if len(section.MillionaireIDs) == 0 {
+		return fmt.Errorf("%w: featured needs millionaireIds", ErrInvalidSection)
+	}
+	found, err := s.millionaires.GetByIDs(ctx, section.MillionaireIDs)
+	if err != nil {
+		return err
+	}
+	for i, id := range section.MillionaireIDs {
+		if slices.Contains(section.MillionaireIDs[:i], id) {
+			return fmt.Errorf("%w: millionaire %d is listed twice", ErrInvalidSection, id)
+		}
+		if !slices.ContainsFunc(found, func(m models.Millionaire) bool { return m.ID == id }) {
+			return fmt.Errorf("%w: millionaire %d does not exist", ErrInvalidSection, id)
+		}
+	}
+	return nil
 }
 
-func NewHomeService(repo repo.MillionaireRepository, log *slog.Logger) *HomeService {
-	return &HomeService{repo: repo, log: log}
+// codes resolves each of values to a code, dropping repeats.
+func (s *HomeService) codes(ctx context.Context, lookup func(context.Context, string) (string, error), values []string, unknown error) ([]string, error) {
+	var codes []string
+	for _, v := range values {
+		code, err := lookup(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		if code == "" {
+			return nil, fmt.Errorf("%w %q", unknown, v)
+		}
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// sectionError maps repository errors to the service's.
+func sectionError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSectionNotFound
+	}
+	return err
 }
 
-func (s *HomeService) GetHomePageData(baseURL string) (*models.HomePageDto, error) {
-	topMillionaires, err := s.repo.GetTopMillionaires(baseURL)
+// Sections lists every section, hidden ones included.
+func (s *HomeService) Sections(ctx context.Context) ([]models.HomeSection, error) {
+	ctx, span := tracing.Start(ctx, "HomeService.Sections")
+	defer span.End()
+
+	sections, err := s.sections.List(ctx)
 	if err != nil {
-		s.log.Error("Error fetching top millionaires", logger.Err(err))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
+	if sections == nil {
+		sections = []models.HomeSection{}
+	}
+	return sections, nil
+}
+
+func (s *HomeService) Section(ctx context.Context, id int) (*models.HomeSection, error) {
+	ctx, span := tracing.Start(ctx, "HomeService.Section")
+	defer span.End()
+
+	section, err := s.sections.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, sectionError(err)
+	}
+	return section, nil
+}
 
-	return &models.HomePageDto{
-		TopMillionaires: topMillionaires,
-	}, nil
+// CreateSection adds a section; without a position it goes last.
+func (s *HomeService) CreateSection(ctx context.Context, section *models.HomeSection) error {
+	ctx, span := tracing.Start(ctx, "HomeService.CreateSection")
+	defer span.End()
+
+	if err := s.checkSection(ctx, section); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if section.Position == 0 {
+		sections, err := s.sections.List(ctx)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return err
+		}
+		for _, other := range sections {
+			section.Position = max(section.Position, other.Position)
+		}
+		section.Position++
+	}
+
+	if err := s.sections.Create(ctx, section); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	s.log.InfoContext(ctx, "Home section created", slog.Int("id", section.ID), slog.String("kind", section.Kind))
+	return nil
+}
+
+// UpdateSection replaces a section's definition and reloads it.
+func (s *HomeService) UpdateSection(ctx context.Context, section *models.HomeSection) error {
+	ctx, span := tracing.Start(ctx, "HomeService.UpdateSection")
+	defer span.End()
+
+	if err := s.checkSection(ctx, section); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if err := s.sections.Update(ctx, section); err != nil {
+		tracing.RecordError(span, err)
+		return sectionError(err)
+	}
+	updated, err := s.sections.GetByID(ctx, section.ID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return sectionError(err)
+	}
+	*section = *updated
+	s.log.InfoContext(ctx, "Home section updated", slog.Int("id", section.ID))
+	return nil
+}
+
+func (s *HomeService) DeleteSection(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "HomeService.DeleteSection")
+	defer span.End()
+
+	if err := s.sections.Delete(ctx, id); err != nil {
+		tracing.RecordError(span, err)
+		return sectionError(err)
+	}
+	s.log.InfoContext(ctx, "Home section deleted", slog.Int("id", id))
+	return nil
 }
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/names"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
+)
+
+var (
+	ErrSelfMerge        = errors.New("a millionaire cannot be merged into itself")
+	ErrUnmergeableField = errors.New("field cannot be chosen in a merge")
+	ErrInvalidMinScore  = errors.New("minScore must be above 0 and at most 1")
+)
+
+// Weights of the signals in a duplicate score, and the score a pair needs
+// to be reported by default.
+const (
+	nameWeight      = 0.6
+	birthDateWeight = 0.25
+	companyWeight   = 0.15
+
+	DefaultMinDuplicateScore = 0.85
+	maxDuplicates            = 500
+)
+
+// mergeFollowers are taken from the same side as the field they belong to.
+var mergeFollowers = map[string][]string{
+	"netWorth": {"netWorthCurrency", "netWorthAsOf"},
+	"country":  {"countryCode"},
+	"industry": {"industryCode"},
+}
+
+// millionaireFieldIndex maps the JSON names of models.Millionaire to their
+// field index.
+var millionaireFieldIndex = func() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(models.Millionaire{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+	return fields
+}()
+
+// mergeFields are the JSON names a merge may pick a side for, in
+// declaration order: everything but the ID, timestamps, derived fields and
+// followers.
+var mergeFields = func() []string {
+	skip := map[string]bool{"id": true, "biographyHtml": true, "thumbnailUrl": true, "createdAt": true, "updatedAt": true}
+	for _, followers := range mergeFollowers {
+		for _, f := range followers {
+			skip[f] = true
+		}
+	}
+
+	var fields []string
+	t := reflect.TypeOf(models.Millionaire{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" && !skip[name] {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}()
+
+// MergeService finds millionaires entered twice and merges them.
+type MergeService struct {
+	millionaires repo.MillionaireRepository
+	redirects    repo.Redirects
+	photos       *PhotoService
+	uow          repo.Transactor
+	log          *slog.Logger
+	onChange     []func(context.Context)
+}
+
+func NewMergeService(millionaires repo.MillionaireRepository, redirects repo.Redirects, photos *PhotoService, uow repo.Transactor, log *slog.Logger) *MergeService {
+	return &MergeService{
+		millionaires: millionaires,
+		redirects:    redirects,
+		photos:       photos,
+		uow:          uow,
+		log:          log,
+	}
+}
+
+// OnChange registers f to be called after a merge.
+func (s *MergeService) OnChange(f func(context.Context)) {
+	s.onChange = append(s.onChange, f)
+}
+
+// duplicateCandidate is a millionaire with the normalized values it is
+// compared by.
+type duplicateCandidate struct {
+	m                  models.Millionaire
+	last, first        string
+	name, reversed     string
+	birthDate, company string
+}
+
+func newDuplicateCandidate(m models.Millionaire) duplicateCandidate {
+	c := duplicateCandidate{
+		m:     m,
+		last:  names.Normalize(m.LastName),
+		first: names.Normalize(m.FirstName),
+	}
+	c.name = strings.TrimSpace(c.last + " " + c.first)
+	c.reversed = strings.TrimSpace(c.first + " " + c.last)
+	if m.BirthDate != nil {
+		c.birthDate = *m.BirthDate
+	}
+	if m.Company != nil {
+		c.company = names.Normalize(*m.Company)
+	}
+	return c
+}
+
+// blocks returns the keys of the groups c is compared within: names that
+// start alike, in either order.
+func (c duplicateCandidate) blocks() []string {
+	prefix := func(s string, n int) string {
+		r := []rune(s)
+		return string(r[:min(n, len(r))])
+	}
+	return []string{
+		prefix(c.last, 2) + "|" + prefix(c.first, 1),
+		prefix(c.first, 2) + "|" + prefix(c.last, 1),
+	}
+}
+
+// score compares two candidates. Birth dates count fully when equal and
+// half when only the year is; signals missing on either side are left out
+// of the weighted average.
+func score(a, b duplicateCandidate) models.DuplicatePair {
+	p := models.DuplicatePair{
+		Name:         max(names.Similarity(a.name, b.name), names.Similarity(a.name, b.reversed)),
+		Millionaires: []models.Millionaire{a.m, b.m},
+	}
+	total, weights := nameWeight*p.Name, nameWeight
+
+	if a.birthDate != "" && b.birthDate != "" {
+		var v float64
+		switch {
+		case a.birthDate == b.birthDate:
+			v = 1
+		case len(a.birthDate) >= 4 && len(b.birthDate) >= 4 && a.birthDate[:4] == b.birthDate[:4]:
+			v = 0.5
+		}
+		p.BirthDate = &v
+		total, weights = total+birthDateWeight*v, weights+birthDateWeight
+	}
+	if a.company != "" && b.company != "" {
+		v := names.Similarity(a.company, b.company)
+		p.Company = &v
+		total, weights = total+companyWeight*v, weights+companyWeight
+	}
+
+	p.Score = round3(total / weights)
+	p.Name = round3(p.Name)
+	if p.Company != nil {
+		*p.Company = round3(*p.Company)
+	}
+	return p
+}
+
+func round3(v float64) float64 {
+	return float64(int(v*1000+0.5)) / 1000
+}
+
+// Duplicates returns up to limit pairs of millionaires scoring at least
+// minScore, most likely duplicates first. Only millionaires whose
+// normalized names start alike are compared.
+func (s *MergeService) Duplicates(ctx context.Context, minScore float64, limit int) ([]models.DuplicatePair, error) {
+	ctx, span := tracing.Start(ctx, "MergeService.Duplicates")
+	defer span.End()
+
+	if minScore <= 0 || minScore > 1 {
+		return nil, ErrInvalidMinScore
+	}
+	if limit < 1 || limit > maxDuplicates {
+		limit = maxDuplicates
+	}
+
+	all, err := s.millionaires.SearchAll(ctx, repo.MillionaireFilter{})
+	if err != nil {
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to load millionaires", logger.Err(err))
+		return nil, err
+	}
+
+	candidates := make([]duplicateCandidate, len(all))
+	blocks := make(map[string][]int)
+	for i, m := range all {
+		candidates[i] = newDuplicateCandidate(m)
+		for _, key := range candidates[i].blocks() {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	pairs := []models.DuplicatePair{}
+	for _, members := range blocks {
+		for x, i := range members {
+			for _, j := range members[x+1:] {
+				key := [2]int{min(i, j), max(i, j)}
+				if i == j || seen[key] {
+					continue
+				}
+				seen[key] = true
+				if p := score(candidates[key[0]], candidates[key[1]]); p.Score >= minScore {
+					pairs = append(pairs, p)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(pairs, func(a, b models.DuplicatePair) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.Millionaires[0].ID, b.Millionaires[0].ID),
+			cmp.Compare(a.Millionaires[1].ID, b.Millionaires[1].ID),
+		)
+	})
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
+
+// checkMergeFields rejects sides for fields a merge cannot pick.
+func checkMergeFields(sides map[string]string) error {
+	for name := range sides {
+		if !slices.Contains(mergeFields, name) {
+			return fmt.Errorf("%w %q", ErrUnmergeableField, name)
+		}
+	}
+	return nil
+}
+
+// mergeMillionaires returns target with the fields sides picks from source,
+// and those target lacks, taken from source.
+func mergeMillionaires(target, source models.Millionaire, sides map[string]string) (models.Millionaire, []string) {
+	merged := target
+	to, from := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(&source).Elem()
+
+	fromSource := []string{}
+	for _, name := range mergeFields {
+		i := millionaireFieldIndex[name]
+		side, ok := sides[name]
+		if !ok && to.Field(i).IsZero() && !from.Field(i).IsZero() {
+			side = models.MergeSource
+		}
+		if side != models.MergeSource {
+			continue
+		}
+		to.Field(i).Set(from.Field(i))
+		for _, follower := range mergeFollowers[name] {
+			j := millionaireFieldIndex[follower]
+			to.Field(j).Set(from.Field(j))
+		}
+		fromSource = append(fromSource, name)
+	}
+	merged.BiographyHTML = ""
+	return merged, fromSource
+}
+
+// mergeAudit is what the audit log keeps of a merge: enough to undo it by
+// hand.
+type mergeAudit struct {
+	TargetID   int                `json:"targetId"`
+	SourceID   int                `json:"sourceId"`
+	FromSource []string           `json:"fromSource"`
+	Target     models.Millionaire `json:"target"`
+	Source     models.Millionaire `json:"source"`
+}
+
+// Merge folds the source millionaire into the target in one transaction:
+// the target takes the chosen fields, the source's history (its values win
+// on shared days when the source's net worth is chosen), holdings and
+// featured homepage spots; the source is deleted and its ID redirected to
+// the target. The merge is written to the audit log. A photo no longer
+// used is removed once the merge is committed.
+func (s *MergeService) Merge(ctx context.Context, req models.MergeRequest) (*models.MergeResult, error) {
+	ctx, span := tracing.Start(ctx, "MergeService.Merge")
+	defer span.End()
+
+	if req.TargetID == req.SourceID {
+		return nil, ErrSelfMerge
+	}
+	if err := checkMergeFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	var target, source, merged models.Millionaire
+	var fromSource []string
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		// Locking in ID order keeps concurrent merges of the same pair
+		// from deadlocking.
+		for _, id := range []int{min(req.TargetID, req.SourceID), max(req.TargetID, req.SourceID)} {
+			if _, err := tx.Photos.LockPhotoPath(ctx, id); err != nil {
+				return millionaireError(err)
+			}
+		}
+		t, err := tx.Millionaires.GetByID(ctx, req.TargetID)
+		if err != nil {
+			return millionaireError(err)
+		}
+		src, err := tx.Millionaires.GetByID(ctx, req.SourceID)
+		if err != nil {
+			return millionaireError(err)
+		}
+		target, source = *t, *src
+
+		merged, fromSource = mergeMillionaires(target, source, req.Fields)
+		if err := tx.Millionaires.Update(ctx, &merged); err != nil {
+			return err
+		}
+		if err := tx.History.Copy(ctx, source.ID, target.ID, slices.Contains(fromSource, "netWorth")); err != nil {
+			return err
+		}
+		if err := tx.Holdings.Move(ctx, source.ID, target.ID); err != nil {
+			return err
+		}
+		if err := tx.HomeSections.ReplaceMillionaire(ctx, source.ID, target.ID); err != nil {
+			return err
+		}
+		if err := tx.Redirects.Add(ctx, source.ID, target.ID); err != nil {
+			return err
+		}
+		if err := tx.Millionaires.Delete(ctx, source.ID); err != nil {
+			return err
+		}
+
+		details, err := json.Marshal(mergeAudit{
+			TargetID:   target.ID,
+			SourceID:   source.ID,
+			FromSource: fromSource,
+			Target:     target,
+			Source:     source,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Audit.Add(ctx, &models.AuditEntry{
+			Action:    models.AuditMerge,
+			Actor:     contextAttr(ctx, "user", "unknown"),
+			RequestID: contextAttr(ctx, "request_id", ""),
+			Details:   details,
+		})
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		if !errors.Is(err, ErrMillionaireNotFound) {
+			s.log.ErrorContext(ctx, "Error merging millionaires", slog.Int("targetId", req.TargetID), slog.Int("sourceId", req.SourceID), logger.Err(err))
+		}
+		return nil, err
+	}
+
+	for _, m := range []models.Millionaire{target, source} {
+		if p := m.PathToPhoto; p != nil && strings.HasPrefix(*p, PhotoDir+"/") && (merged.PathToPhoto == nil || *p != *merged.PathToPhoto) {
+			if err := s.photos.RemovePhotoFiles(ctx, *p); err != nil {
+				s.log.WarnContext(ctx, "Could not remove merged photo", slog.String("path", *p), logger.Err(err))
+			}
+		}
+	}
+
+	s.log.InfoContext(ctx, "Millionaires merged", slog.Int("targetId", target.ID), slog.Int("sourceId", source.ID), slog.Any("fromSource", fromSource))
+	for _, f := range s.onChange {
+		f(ctx)
+	}
+	return &models.MergeResult{Millionaire: merged, FromSource: fromSource}, nil
+}
+
+// Resolve returns the ID a merged millionaire was redirected to, or
+// ErrMillionaireNotFound.
+func (s *MergeService) Resolve(ctx context.Context, id int) (int, error) {
+	to, err := s.redirects.Resolve(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrMillionaireNotFound
+	}
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to resolve redirect", slog.Int("id", id), logger.Err(err))
+	}
+	return to, err
+}
+
+// contextAttr returns the request-scoped log attribute key, or def.
+func contextAttr(ctx context.Context, key, def string) string {
+	if v, ok := logger.Value(ctx, key); ok && v.String() != "" {
+		return v.String()
+	}
+	return def
+}
@@ -1,50 +1,229 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 	"wealthlist/internal/logger"
+	"wealthlist/internal/markdown"
+	"wealthlist/internal/metrics"
 	"wealthlist/internal/models"
 	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
 )
 
 type MillionaireServiceInterface interface {
-	CreateMillionaire(m *models.Millionaire) error
-	SearchMillionaire(lastName, firstName, middleName, country string, pageNum, pageSize int) (models.PaginationMillionaireDto, error)
-	GetAllMillionaires(pageNum, pageSize int) (models.PaginationMillionaireDto, error)
-	GetMillionaireByID(id int) (*models.Millionaire, error)
-	UpdateMillionaire(m *models.Millionaire) error
-	DeleteMillionaire(id int) error
+	CreateMillionaire(ctx context.Context, m *models.Millionaire) error
+	CreateMillionaires(ctx context.Context, ms []models.Millionaire) error
+	SearchMillionaire(ctx context.Context, lastName, firstName, middleName, country, industry string, pageNum, pageSize int) (models.PaginationMillionaireDto, error)
+	GetAllMillionaires(ctx context.Context, pageNum, pageSize int) (models.PaginationMillionaireDto, error)
+	// GetMillionaireByID fills in the BiographyHTML only with withHTML.
+	GetMillionaireByID(ctx context.Context, id int, withHTML bool) (*models.Millionaire, error)
+	UpdateMillionaire(ctx context.Context, m *models.Millionaire) error
+	DeleteMillionaire(ctx context.Context, id int) error
 }
 
+var (
+	ErrUnknownCountry  = errors.New("unknown country code")
+	ErrUnknownIndustry = errors.New("unknown industry code")
+)
+
 type millionaireService struct {
-	repo repo.MillionaireRepository
-	log  *slog.Logger
+	repo  repo.MillionaireRepository
+	uow   repo.Transactor
+	vocab repo.Vocabulary
+	log   *slog.Logger
 }
 
 var _ MillionaireServiceInterface = (*millionaireService)(nil) // compile-time check
 
-func NewMillionaireService(repo repo.MillionaireRepository, log *slog.Logger) *millionaireService {
+func NewMillionaireService(repo repo.MillionaireRepository, uow repo.Transactor, vocab repo.Vocabulary, log *slog.Logger) *millionaireService {
 	return &millionaireService{
-		repo: repo,
-		log:  log,
+		repo:  repo,
+		uow:   uow,
+		vocab: vocab,
+		log:   log,
+	}
+}
+
+// classify checks the country and industry codes of m, or looks them up
+// from the free-text fields when no code is given. Free text that matches
+// nothing leaves the code empty; an unknown code is an error. A net worth
+// without a currency or day is taken to be in DefaultCurrency as of today,
+// and may not have more decimal places than its currency.
+func (s *millionaireService) classify(ctx context.Context, m *models.Millionaire) error {
+	if m.NetWorthCurrency == "" {
+		m.NetWorthCurrency = DefaultCurrency
+	}
+	if m.NetWorthAsOf == nil {
+		today := time.Now().Format(time.DateOnly)
+		m.NetWorthAsOf = &today
+	}
+	if m.NetWorth != nil {
+		if err := checkScale("net worth", *m.NetWorth, m.NetWorthCurrency); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if m.CountryCode, err = s.resolve(ctx, s.vocab.ResolveCountry, m.CountryCode, m.Country, ErrUnknownCountry); err != nil {
+		return err
+	}
+	m.IndustryCode, err = s.resolve(ctx, s.vocab.ResolveIndustry, m.IndustryCode, m.Industry, ErrUnknownIndustry)
+	return err
+}
+
+// sameValuation reports whether m has the net worth and currency stored.
+func sameValuation(stored, m *models.Millionaire) bool {
+	if stored.NetWorth == nil || m.NetWorth == nil {
+		return stored.NetWorth == nil && m.NetWorth == nil
+	}
+	return stored.NetWorth.Cmp(*m.NetWorth) == 0 && stored.NetWorthCurrency == m.NetWorthCurrency
+}
+
+// recordValuation adds m's net worth to its history on its valuation day,
+// so the homepage movers see it.
+func recordValuation(ctx context.Context, history repo.History, m *models.Millionaire) error {
+	if m.NetWorth == nil || m.NetWorthAsOf == nil {
+		return nil
+	}
+	return history.Record(ctx, m.ID, models.NetWorthPoint{Date: *m.NetWorthAsOf, NetWorth: *m.NetWorth, Currency: m.NetWorthCurrency})
+}
+
+func (s *millionaireService) resolve(ctx context.Context, lookup func(context.Context, string) (string, error), code, text *string, unknown error) (*string, error) {
+	value := text
+	if code != nil && *code != "" {
+		value = code
+	}
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+
+	resolved, err := lookup(ctx, *value)
+	if err != nil {
+		return nil, err
+	}
+	if resolved == "" {
+		if value == code {
+			return nil, fmt.Errorf("%w %q", unknown, *code)
+		}
+		return nil, nil
+	}
+	return &resolved, nil
+}
+
+func (s *millionaireService) CreateMillionaire(ctx context.Context, m *models.Millionaire) error {
+	ctx, span := tracing.Start(ctx, "millionaireService.CreateMillionaire")
+	defer span.End()
+
+	s.log.InfoContext(ctx, "Creating millionaire")
+
+	if err := s.classify(ctx, m); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		if err := tx.Millionaires.Create(ctx, m); err != nil {
+			return err
+		}
+		return recordValuation(ctx, tx.History, m)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to create millionaire", logger.Err(err))
+		return err
 	}
+
+	metrics.MillionairesCreated.Inc()
+	s.log.InfoContext(ctx, "Millionaire created successfully", slog.Int("id", m.ID))
+	return nil
 }
 
-func (s *millionaireService) CreateMillionaire(m *models.Millionaire) error {
-	s.log.Info("Creating millionaire")
+// CreateMillionaires creates all of ms or, if one fails, none of them.
+func (s *millionaireService) CreateMillionaires(ctx context.Context, ms []models.Millionaire) error {
+	ctx, span := tracing.Start(ctx, "millionaireService.CreateMillionaires")
+	defer span.End()
 
-	err := s.repo.Create(m)
+	s.log.InfoContext(ctx, "Creating millionaires", slog.Int("count", len(ms)))
+
+	for i := range ms {
+		if err := s.classify(ctx, &ms[i]); err != nil {
+			tracing.RecordError(span, err)
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+	}
+
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		for i := range ms {
+			if err := tx.Millionaires.Create(ctx, &ms[i]); err != nil {
+				return fmt.Errorf("record %d: %w", i+1, err)
+			}
+			if err := recordValuation(ctx, tx.History, &ms[i]); err != nil {
+				return fmt.Errorf("record %d: %w", i+1, err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		s.log.Error("Failed to create millionaire", logger.Err(err))
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to create millionaires, nothing was saved", logger.Err(err))
 		return err
 	}
 
-	s.log.Info("Millionaire created successfully", slog.Int("id", m.ID))
+	metrics.MillionairesCreated.Add(float64(len(ms)))
+	s.log.InfoContext(ctx, "Millionaires created successfully", slog.Int("count", len(ms)))
 	return nil
 }
 
-func (s *millionaireService) SearchMillionaire(lastName, firstName, middleName, country string, pageNum, pageSize int) (models.PaginationMillionaireDto, error) {
-	s.log.Debug("Searching millionaire",
+// SearchQuery is the filter of the search and of the statistics.
+type SearchQuery struct {
+	LastName   string
+	FirstName  string
+	MiddleName string
+	Country    string
+	Industry   string
+}
+
+// searchFilter filters by country and industry code when Country and
+// Industry resolve to one, including the industries below it, and by the
+// free text otherwise.
+func searchFilter(ctx context.Context, vocab repo.Vocabulary, q SearchQuery) (repo.MillionaireFilter, error) {
+	filter := repo.MillionaireFilter{
+		LastName:   q.LastName,
+		FirstName:  q.FirstName,
+		MiddleName: q.MiddleName,
+	}
+	if q.Country != "" {
+		code, err := vocab.ResolveCountry(ctx, q.Country)
+		if err != nil {
+			return filter, err
+		}
+		if filter.CountryCode = code; code == "" {
+			filter.Country = q.Country
+		}
+	}
+	if q.Industry != "" {
+		code, err := vocab.ResolveIndustry(ctx, q.Industry)
+		if err != nil {
+			return filter, err
+		}
+		if filter.IndustryCode = code; code == "" {
+			filter.Industry = q.Industry
+		}
+	}
+	return filter, nil
+}
+
+// SearchMillionaire filters as described at searchFilter.
+func (s *millionaireService) SearchMillionaire(ctx context.Context, lastName, firstName, middleName, country, industry string, pageNum, pageSize int) (models.PaginationMillionaireDto, error) {
+	ctx, span := tracing.Start(ctx, "millionaireService.SearchMillionaire")
+	defer span.End()
+
+	s.log.DebugContext(ctx, "Searching millionaire",
 		slog.String("lastName", lastName),
 		slog.String("firstName", firstName),
 		slog.Int("pageNum", pageNum),
@@ -53,101 +232,167 @@ func (s *millionaireService) SearchMillionaire(lastName, firstName, middleName,
 
 	if pageNum < 1 {
 		pageNum = 1
-		s.log.Warn("pageNum adjusted", slog.Int("newPageNum", pageNum))
+		s.log.WarnContext(ctx, "pageNum adjusted", slog.Int("newPageNum", pageNum))
 	}
 	if pageSize < 1 {
 		pageSize = 10
-		s.log.Warn("pageSize adjusted", slog.Int("newPageSize", pageSize))
+		s.log.WarnContext(ctx, "pageSize adjusted", slog.Int("newPageSize", pageSize))
 	}
 
-	filter := repo.MillionaireFilter{
+	filter, err := searchFilter(ctx, s.vocab, SearchQuery{
 		LastName:   lastName,
 		FirstName:  firstName,
 		MiddleName: middleName,
 		Country:    country,
+		Industry:   industry,
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return models.PaginationMillionaireDto{}, err
 	}
 
-	result, err := s.repo.Search(filter, pageNum, pageSize)
+	result, err := s.repo.Search(ctx, filter, pageNum, pageSize)
 	if err != nil {
-		s.log.Error("Search failed", logger.Err(err))
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Search failed", logger.Err(err))
 		return models.PaginationMillionaireDto{}, err
 	}
 
-	s.log.Info("Search completed",
+	s.log.InfoContext(ctx, "Search completed",
 		slog.Int("totalResults", result.Total),
 		slog.Int("returnedResults", len(result.Millionaires)),
 	)
 	return result, nil
 }
 
-func (s *millionaireService) GetAllMillionaires(pageNum, pageSize int) (models.PaginationMillionaireDto, error) {
-	s.log.Debug("Fetching millionaires",
+func (s *millionaireService) GetAllMillionaires(ctx context.Context, pageNum, pageSize int) (models.PaginationMillionaireDto, error) {
+	ctx, span := tracing.Start(ctx, "millionaireService.GetAllMillionaires")
+	defer span.End()
+
+	s.log.DebugContext(ctx, "Fetching millionaires",
 		slog.Int("pageNum", pageNum),
 		slog.Int("pageSize", pageSize),
 	)
 
 	if pageNum < 1 {
 		pageNum = 1
-		s.log.Warn("pageNum adjusted", slog.Int("newPageNum", pageNum))
+		s.log.WarnContext(ctx, "pageNum adjusted", slog.Int("newPageNum", pageNum))
 	}
 	if pageSize < 1 {
 		pageSize = 10
-		s.log.Warn("pageSize adjusted", slog.Int("newPageSize", pageSize))
+		s.log.WarnContext(ctx, "pageSize adjusted", slog.Int("newPageSize", pageSize))
 	}
 
-	result, err := s.repo.GetAll(pageNum, pageSize)
+	result, err := s.repo.GetAll(ctx, pageNum, pageSize)
 	if err != nil {
-		s.log.Error("Failed to fetch millionaires", logger.Err(err))
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to fetch millionaires", logger.Err(err))
 		return models.PaginationMillionaireDto{}, err
 	}
 
-	s.log.Info("Successfully fetched millionaires",
+	s.log.InfoContext(ctx, "Successfully fetched millionaires",
 		slog.Int("total", result.Total),
 		slog.Int("returned", len(result.Millionaires)),
 	)
 	return result, nil
 }
 
-func (s *millionaireService) GetMillionaireByID(id int) (*models.Millionaire, error) {
-	s.log.Debug("Fetching millionaire", slog.Int("id", id))
+func (s *millionaireService) GetMillionaireByID(ctx context.Context, id int, withHTML bool) (*models.Millionaire, error) {
+	ctx, span := tracing.Start(ctx, "millionaireService.GetMillionaireByID")
+	defer span.End()
 
-	millionaire, err := s.repo.GetByID(id)
+	s.log.DebugContext(ctx, "Fetching millionaire", slog.Int("id", id))
+
+	millionaire, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.log.Error("Failed to fetch millionaire", logger.Err(err))
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Failed to fetch millionaire", logger.Err(err))
 		return nil, err
 	}
 
 	if millionaire == nil {
-		s.log.Warn("Millionaire not found")
+		s.log.WarnContext(ctx, "Millionaire not found")
 		return nil, nil
 	}
 
-	s.log.Debug("Successfully fetched millionaire")
+	if withHTML && millionaire.Biography != nil {
+		html, err := markdown.Render(*millionaire.Biography)
+		if err != nil {
+			s.log.WarnContext(ctx, "Could not render biography", slog.Int("id", id), logger.Err(err))
+		}
+		millionaire.BiographyHTML = html
+	}
+
+	s.log.DebugContext(ctx, "Successfully fetched millionaire")
 	return millionaire, nil
 }
 
-func (s *millionaireService) UpdateMillionaire(m *models.Millionaire) error {
-	s.log.Info("Updating millionaire", slog.Int("id", m.ID))
+func (s *millionaireService) UpdateMillionaire(ctx context.Context, m *models.Millionaire) error {
+	ctx, span := tracing.Start(ctx, "millionaireService.UpdateMillionaire")
+	defer span.End()
+
+	s.log.InfoContext(ctx, "Updating millionaire", slog.Int("id", m.ID))
 
-	err := s.repo.Update(m)
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		stored, err := tx.Millionaires.GetByID(ctx, m.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// An unknown ID updates nothing and has no history to record.
+			return s.classify(ctx, m)
+		} else if err != nil {
+			return err
+		}
+
+		// An unchanged net worth without a day keeps the day it was valued
+		// on, rather than becoming today's.
+		if m.NetWorthCurrency == "" {
+			m.NetWorthCurrency = DefaultCurrency
+		}
+		revalued := !sameValuation(stored, m)
+		if m.NetWorthAsOf == nil && !revalued {
+			m.NetWorthAsOf = stored.NetWorthAsOf
+		}
+		if err := s.classify(ctx, m); err != nil {
+			return err
+		}
+
+		if err := tx.Millionaires.Update(ctx, m); err != nil {
+			return err
+		}
+		if revalued && stored.NetWorth != nil && stored.NetWorthAsOf != nil {
+			// Keep the valuation being replaced, unless its day already has one.
+			previous := models.NetWorthPoint{Date: *stored.NetWorthAsOf, NetWorth: *stored.NetWorth, Currency: stored.NetWorthCurrency}
+			if err := tx.History.Add(ctx, m.ID, []models.NetWorthPoint{previous}); err != nil {
+				return err
+			}
+		}
+		return recordValuation(ctx, tx.History, m)
+	})
 	if err != nil {
-		s.log.Error("Update failed", logger.Err(err))
+		tracing.RecordError(span, err)
+		if errors.Is(err, ErrUnknownCountry) || errors.Is(err, ErrUnknownIndustry) || errors.Is(err, ErrInvalidAmount) {
+			return err
+		}
+		s.log.ErrorContext(ctx, "Update failed", logger.Err(err))
 		return err
 	}
 
-	s.log.Info("Millionaire updated successfully")
+	s.log.InfoContext(ctx, "Millionaire updated successfully")
 	return nil
 }
 
-func (s *millionaireService) DeleteMillionaire(id int) error {
-	s.log.Info("Deleting millionaire", slog.Int("id", id))
+func (s *millionaireService) DeleteMillionaire(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "millionaireService.DeleteMillionaire")
+	defer span.End()
+
+	s.log.InfoContext(ctx, "Deleting millionaire", slog.Int("id", id))
 
-	err := s.repo.Delete(id)
+	err := s.repo.Delete(ctx, id)
 	if err != nil {
-		s.log.Error("Delete failed", logger.Err(err))
+		tracing.RecordError(span, err)
+		s.log.ErrorContext(ctx, "Delete failed", logger.Err(err))
 		return err
 	}
 
-	s.log.Info("Millionaire deleted successfully")
+	s.log.InfoContext(ctx, "Millionaire deleted successfully")
 	return nil
 }
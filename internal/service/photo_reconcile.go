@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/tracing"
+)
+
+// MissingPhoto is a database reference to a file that does not exist.
+type MissingPhoto struct {
+	MillionaireID int
+	Path          string
+}
+
+// PhotoReconcileReport compares PhotoDir with the photo paths stored in the
+// database.
+type PhotoReconcileReport struct {
+	Orphaned []string
+	Missing  []MissingPhoto
+}
+
+// Reconcile finds files no millionaire refers to and references to files
+// that are gone. With fix set, orphaned files are deleted and dangling
+// references are cleared.
+func (s *PhotoService) Reconcile(ctx context.Context, fix bool) (*PhotoReconcileReport, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.Reconcile")
+	defer span.End()
+
+	refs, err := s.photoRepo.ListPhotoPaths(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	// Variants and thumbnails share the stem of the original, so a reference
+	// keeps them too.
+	referenced := make(map[string]bool, len(refs))
+	report := &PhotoReconcileReport{}
+	for id, p := range refs {
+		name := filepath.Base(p)
+		referenced[strings.TrimSuffix(name, filepath.Ext(name))] = true
+
+		if _, err := os.Stat(filepath.Join(PhotoDir, name)); os.IsNotExist(err) {
+			report.Missing = append(report.Missing, MissingPhoto{MillionaireID: id, Path: p})
+		}
+	}
+	sort.Slice(report.Missing, func(i, j int) bool { return report.Missing[i].MillionaireID < report.Missing[j].MillionaireID })
+
+	for _, dir := range []string{PhotoDir, PhotoThumbDir} {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			// Dot files are in-flight uploads, thumbnails and health check
+			// probes.
+			if e.IsDir() || strings.HasPrefix(name, ".") {
+				continue
+			}
+			if !referenced[strings.TrimSuffix(name, filepath.Ext(name))] {
+				report.Orphaned = append(report.Orphaned, filepath.Join(dir, name))
+			}
+		}
+	}
+
+	if !fix {
+		return report, nil
+	}
+
+	for _, p := range report.Orphaned {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			tracing.RecordError(span, err)
+			return report, err
+		}
+		s.etags.Delete(p)
+		s.log.InfoContext(ctx, "Removed orphaned photo", slog.String("path", p))
+	}
+	for _, m := range report.Missing {
+		if err := s.photoRepo.ClearPhotoPath(ctx, m.MillionaireID); err != nil {
+			tracing.RecordError(span, err)
+			s.log.ErrorContext(ctx, "Could not clear missing photo", slog.Int("id", m.MillionaireID), logger.Err(err))
+			return report, err
+		}
+		s.log.InfoContext(ctx, "Cleared missing photo", slog.Int("id", m.MillionaireID), slog.String("path", m.Path))
+	}
+
+	return report, nil
+}
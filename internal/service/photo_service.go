@@ -1,74 +1,413 @@
 package service
 
 import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"log/slog"
-	"math/rand"
 	"mime/multipart"
 	"os"
-	"path"
-	"strconv"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
 	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
 )
 
+const PhotoDir = "uploads/photos"
+
+// PhotoThumbDir holds the thumbnails of photos under the photos' names.
+const PhotoThumbDir = PhotoDir + "/thumbs"
+
+// thumbnailSize bounds the longer side of a thumbnail, in pixels.
+const thumbnailSize = 320
+
+var (
+	ErrPhotoNotFound       = errors.New("photo not found")
+	ErrMillionaireNotFound = errors.New("millionaire not found")
+)
+
+// hashedPhotoName matches names produced by UploadPhoto: the content hash is
+// part of the name, so the file behind it never changes.
+var hashedPhotoName = regexp.MustCompile(`^\d+_[0-9a-f]{16}\.[a-z0-9]+$`)
+
+var photoContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".avif": "image/avif",
+}
+
+// PhotoVariantExts lists the alternative encodings that may sit next to an
+// uploaded photo, most preferred first.
+var PhotoVariantExts = []string{".avif", ".webp"}
+
+type PhotoFile struct {
+	Path        string
+	Name        string
+	ContentType string
+	ModTime     time.Time
+	ETag        string
+	Immutable   bool
+}
+
+type photoETag struct {
+	size    int64
+	modTime time.Time
+	etag    string
+}
+
 type PhotoService struct {
 	photoRepo *repo.PhotoRepo
+	uow       repo.Transactor
 	log       *slog.Logger
+	etags     sync.Map
+	onChange  []func(context.Context)
 }
 
-func NewPhotoService(photoRepo *repo.PhotoRepo, log *slog.Logger) *PhotoService {
+func NewPhotoService(photoRepo *repo.PhotoRepo, uow repo.Transactor, log *slog.Logger) *PhotoService {
 	return &PhotoService{
 		photoRepo: photoRepo,
+		uow:       uow,
 		log:       log,
 	}
 }
 
-func (s *PhotoService) UploadPhoto(millionaireID int, file *multipart.FileHeader) (string, error) {
-	randSuffix := strconv.Itoa(rand.Intn(10000))
-	uniqueFileName := fmt.Sprintf("%d_%s_%s", millionaireID, randSuffix, path.Base(file.Filename))
-	savePath := "uploads/photos/" + uniqueFileName
+// OnChange registers f to be called after a millionaire's photo is replaced
+// or deleted.
+func (s *PhotoService) OnChange(f func(context.Context)) {
+	s.onChange = append(s.onChange, f)
+}
+
+func (s *PhotoService) changed(ctx context.Context) {
+	for _, f := range s.onChange {
+		f(ctx)
+	}
+}
+
+// UploadPhoto stores the file and points the millionaire at it in one
+// transaction. The new file is removed again if the database update fails;
+// the previous photo is removed once the change is committed.
+func (s *PhotoService) UploadPhoto(ctx context.Context, millionaireID int, file *multipart.FileHeader) (string, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.UploadPhoto")
+	defer span.End()
+
+	var oldPath, savePath string
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		var err error
+		oldPath, err = tx.Photos.LockPhotoPath(ctx, millionaireID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMillionaireNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		if savePath, err = s.storePhoto(ctx, millionaireID, src, ext); err != nil {
+			return err
+		}
+		return tx.Photos.UpdatePhotoPath(ctx, millionaireID, savePath)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		if savePath != "" && savePath != oldPath {
+			if err := s.RemovePhotoFiles(ctx, savePath); err != nil {
+				s.log.WarnContext(ctx, "Could not remove unused photo", slog.String("path", savePath), logger.Err(err))
+			}
+		}
+		return "", err
+	}
+
+	if oldPath != "" && oldPath != savePath {
+		if err := s.RemovePhotoFiles(ctx, oldPath); err != nil {
+			s.log.WarnContext(ctx, "Could not remove replaced photo", slog.String("path", oldPath), logger.Err(err))
+		}
+	}
+	if err := storeThumbnail(savePath); err != nil {
+		s.log.WarnContext(ctx, "Could not make thumbnail", slog.String("path", savePath), logger.Err(err))
+	}
+
+	metrics.PhotosUploaded.Inc()
+	s.changed(ctx)
+	return savePath, nil
+}
 
-	if err := os.MkdirAll("uploads/photos", os.ModePerm); err != nil {
-		s.log.Error("Error creating directory", logger.Err(err))
+// storePhoto writes src under a name derived from its content.
+func (s *PhotoService) storePhoto(ctx context.Context, millionaireID int, src io.Reader, ext string) (string, error) {
+	if err := os.MkdirAll(PhotoDir, os.ModePerm); err != nil {
+		s.log.ErrorContext(ctx, "Error creating directory", logger.Err(err))
 		return "", err
 	}
 
-	if err := saveUploadedFile(file, savePath); err != nil {
-		s.log.Error("Error saving file", logger.Err(err))
+	tmp, sum, err := saveTempFile(src, PhotoDir)
+	if err != nil {
+		s.log.ErrorContext(ctx, "Error saving file", logger.Err(err))
 		return "", err
 	}
 
+	uniqueFileName := fmt.Sprintf("%d_%s%s", millionaireID, sum[:16], ext)
+	savePath := PhotoDir + "/" + uniqueFileName
+
+	if err := os.Rename(tmp, savePath); err != nil {
+		os.Remove(tmp)
+		s.log.ErrorContext(ctx, "Error saving file", logger.Err(err))
+		return "", err
+	}
 	return savePath, nil
 }
 
-func (s *PhotoService) UpdatePhoto(millionaireID int, filePath string) error {
-	return s.photoRepo.UpdatePhotoPath(millionaireID, filePath)
+// DeletePhoto clears the millionaire's photo and, once that is committed,
+// removes its files. Files that cannot be removed are left for reconcile.
+func (s *PhotoService) DeletePhoto(ctx context.Context, millionaireID int) error {
+	ctx, span := tracing.Start(ctx, "PhotoService.DeletePhoto")
+	defer span.End()
+
+	var photoPath string
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		var err error
+		photoPath, err = tx.Photos.LockPhotoPath(ctx, millionaireID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && photoPath == "") {
+			return ErrPhotoNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Photos.ClearPhotoPath(ctx, millionaireID)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrPhotoNotFound) {
+			tracing.RecordError(span, err)
+			s.log.ErrorContext(ctx, "Error deleting photo", slog.Int("millionaireId", millionaireID), logger.Err(err))
+		}
+		return err
+	}
+	s.changed(ctx)
+
+	// The files go only once nothing refers to them; any left behind are
+	// orphans for `photos reconcile --fix`.
+	if err := s.RemovePhotoFiles(ctx, photoPath); err != nil {
+		s.log.WarnContext(ctx, "Could not remove deleted photo", slog.String("path", photoPath), logger.Err(err))
+	}
+	return nil
+}
+
+// saveTempFile writes src to a temporary file in dir and returns its path
+// together with the hex SHA-256 of the content.
+func saveTempFile(src io.Reader, dir string) (string, string, error) {
+	dst, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer dst.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(dst, io.TeeReader(src, h)); err != nil {
+		os.Remove(dst.Name())
+		return "", "", err
+	}
+
+	return dst.Name(), hex.EncodeToString(h.Sum(nil)), nil
 }
 
-func saveUploadedFile(file *multipart.FileHeader, path string) error {
-	src, err := file.Open()
+// storeThumbnail writes a thumbnail of the photo at path to PhotoThumbDir.
+// Photos the standard library cannot decode, such as WebP, get none and are
+// served in full in its place.
+func storeThumbnail(path string) error {
+	src, err := os.Open(path)
 	if err != nil {
 		return err
 	}
 	defer src.Close()
 
-	dst, err := os.Create(path)
+	img, format, err := image.Decode(src)
+	if errors.Is(err, image.ErrFormat) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(PhotoThumbDir, os.ModePerm); err != nil {
+		return err
+	}
+	dst, err := os.CreateTemp(PhotoThumbDir, ".thumb-*")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(dst.Name())
 	defer dst.Close()
 
-	_, err = io.Copy(dst, src)
-	return err
+	thumb := scaleDown(img, thumbnailSize)
+	switch format {
+	case "png":
+		err = png.Encode(dst, thumb)
+	case "gif":
+		err = gif.Encode(dst, thumb, nil)
+	default:
+		err = jpeg.Encode(dst, thumb, &jpeg.Options{Quality: 85})
+	}
+	if err == nil {
+		err = dst.Close()
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(dst.Name(), filepath.Join(PhotoThumbDir, filepath.Base(path)))
+}
+
+// scaleDown shrinks img, keeping its aspect ratio, so that neither side is
+// longer than size. Each pixel is the average of those it covers.
+func scaleDown(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	tw, th := size, max(1, h*size/w)
+	if h > w {
+		tw, th = max(1, w*size/h), size
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*h/th, b.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*w/tw, b.Min.X+(x+1)*w/tw
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			thumb.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return thumb
+}
+
+// RemovePhotoFiles deletes a stored photo together with any encoded variants
+// and its thumbnail.
+func (s *PhotoService) RemovePhotoFiles(_ context.Context, photoPath string) error {
+	stem := strings.TrimSuffix(photoPath, filepath.Ext(photoPath))
+	paths := []string{photoPath, filepath.Join(PhotoThumbDir, filepath.Base(photoPath))}
+	for _, ext := range PhotoVariantExts {
+		paths = append(paths, stem+ext)
+	}
+
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		s.etags.Delete(p)
+	}
+	return nil
 }
 
-func (s *PhotoService) GetPhotoPath(millionaireID int) (string, error) {
-	return s.photoRepo.GetPhotoPath(millionaireID)
+// ResolvePhoto finds the file to serve for imageName: its thumbnail, when
+// asked for and there is one, or else the first of the variants listed in
+// accepted (e.g. ".webp") and the original that exists.
+func (s *PhotoService) ResolvePhoto(ctx context.Context, imageName string, thumbnail bool, accepted []string) (*PhotoFile, error) {
+	name := filepath.Base(imageName)
+	if name == "." || name == ".." || name != imageName {
+		return nil, ErrPhotoNotFound
+	}
+
+	original := filepath.Join(PhotoDir, name)
+	if _, err := os.Stat(original); err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrPhotoNotFound
+		}
+		return nil, err
+	}
+
+	stem := strings.TrimSuffix(original, filepath.Ext(original))
+	candidates := make([]string, 0, len(accepted)+1)
+	for _, ext := range accepted {
+		if ext != filepath.Ext(original) {
+			candidates = append(candidates, stem+ext)
+		}
+	}
+	candidates = append(candidates, original)
+	if thumbnail {
+		candidates = append([]string{filepath.Join(PhotoThumbDir, name)}, candidates...)
+	}
+
+	for _, p := range candidates {
+		info, err := os.Stat(p)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		etag, err := s.etag(p, info)
+		if err != nil {
+			s.log.ErrorContext(ctx, "Error hashing photo", slog.String("path", p), logger.Err(err))
+			return nil, err
+		}
+
+		contentType, ok := photoContentTypes[strings.ToLower(filepath.Ext(p))]
+		if !ok {
+			contentType = "application/octet-stream"
+		}
+
+		return &PhotoFile{
+			Path:        p,
+			Name:        filepath.Base(p),
+			ContentType: contentType,
+			ModTime:     info.ModTime(),
+			ETag:        etag,
+			Immutable:   hashedPhotoName.MatchString(name),
+		}, nil
+	}
+
+	return nil, ErrPhotoNotFound
 }
 
-func (s *PhotoService) ClearPhotoPath(millionaireID int) error {
-	return s.photoRepo.ClearPhotoPath(millionaireID)
+// etag returns a strong validator derived from the file content. Hashes are
+// cached until the file's size or modification time changes.
+func (s *PhotoService) etag(path string, info os.FileInfo) (string, error) {
+	if v, ok := s.etags.Load(path); ok {
+		cached := v.(photoETag)
+		if cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+			return cached.etag, nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	etag := `"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+	s.etags.Store(path, photoETag{size: info.Size(), modTime: info.ModTime(), etag: etag})
+	return etag, nil
 }
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/metrics"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/seed"
+	"wealthlist/internal/tracing"
+)
+
+// seedBatchSize bounds how much work one failure throws away; batches that
+// were committed are kept and skipped by the next run.
+const seedBatchSize = 100
+
+type SeedOptions struct {
+	Seed   uint64
+	Count  int
+	Reset  bool
+	Photos bool
+}
+
+type SeedReport struct {
+	Deleted int
+	Created int
+	Skipped int
+}
+
+type SeedService struct {
+	uow    repo.Transactor
+	photos *PhotoService
+	log    *slog.Logger
+}
+
+func NewSeedService(uow repo.Transactor, photos *PhotoService, log *slog.Logger) *SeedService {
+	return &SeedService{uow: uow, photos: photos, log: log}
+}
+
+// Seed inserts the people seed.Generate returns for the options. People
+// already stored, recognised by name and birth date, are skipped, so
+// running the same seed twice changes nothing and a larger count only adds
+// the difference. Reset first deletes every millionaire and their photos.
+func (s *SeedService) Seed(ctx context.Context, opts SeedOptions) (*SeedReport, error) {
+	ctx, span := tracing.Start(ctx, "SeedService.Seed")
+	defer span.End()
+
+	report := &SeedReport{}
+	if opts.Reset {
+		deleted, err := s.reset(ctx)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return report, err
+		}
+		report.Deleted = deleted
+	}
+
+	var existing map[repo.MillionaireKey]bool
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		var err error
+		existing, err = tx.Seed.Keys(ctx)
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return report, err
+	}
+
+	var missing []seed.Person
+	for _, p := range seed.Generate(opts.Seed, opts.Count) {
+		last, first, birth := p.Key()
+		if existing[repo.MillionaireKey{LastName: last, FirstName: first, BirthDate: birth}] {
+			report.Skipped++
+			continue
+		}
+		missing = append(missing, p)
+	}
+
+	for start := 0; start < len(missing); start += seedBatchSize {
+		batch := missing[start:min(start+seedBatchSize, len(missing))]
+		if err := s.insert(ctx, batch, opts.Photos); err != nil {
+			tracing.RecordError(span, err)
+			return report, err
+		}
+		report.Created += len(batch)
+		metrics.MillionairesCreated.Add(float64(len(batch)))
+		s.log.InfoContext(ctx, "Seeded millionaires", slog.Int("created", report.Created), slog.Int("of", len(missing)))
+	}
+
+	return report, nil
+}
+
+// reset deletes all millionaires, then the photo files they referenced.
+func (s *SeedService) reset(ctx context.Context) (int, error) {
+	var paths map[int]string
+	var deleted int
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		keys, err := tx.Seed.Keys(ctx)
+		if err != nil {
+			return err
+		}
+		deleted = len(keys)
+
+		if paths, err = tx.Photos.ListPhotoPaths(ctx); err != nil {
+			return err
+		}
+		return tx.Seed.DeleteAll(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range paths {
+		if err := s.photos.RemovePhotoFiles(ctx, p); err != nil {
+			s.log.WarnContext(ctx, "Could not remove photo of deleted millionaire", slog.String("path", p), logger.Err(err))
+		}
+	}
+	s.log.InfoContext(ctx, "Deleted all millionaires", slog.Int("deleted", deleted))
+	return deleted, nil
+}
+
+// insert stores one batch in a transaction. Photos written for a batch that
+// is rolled back are removed again.
+func (s *SeedService) insert(ctx context.Context, batch []seed.Person, photos bool) error {
+	var written []string
+	err := s.uow.WithTx(ctx, func(tx repo.Repos) error {
+		for i := range batch {
+			p := &batch[i]
+			if err := tx.Millionaires.Create(ctx, &p.Millionaire); err != nil {
+				return err
+			}
+			if err := tx.History.Add(ctx, p.Millionaire.ID, p.History); err != nil {
+				return err
+			}
+			if !photos {
+				continue
+			}
+
+			avatar, err := seed.Avatar(*p)
+			if err != nil {
+				return err
+			}
+			path, err := s.photos.storePhoto(ctx, p.Millionaire.ID, bytes.NewReader(avatar), ".png")
+			if err != nil {
+				return err
+			}
+			written = append(written, path)
+			if err := tx.Photos.UpdatePhotoPath(ctx, p.Millionaire.ID, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, path := range written {
+			if err := s.photos.RemovePhotoFiles(ctx, path); err != nil {
+				s.log.WarnContext(ctx, "Could not remove unused photo", slog.String("path", path), logger.Err(err))
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"wealthlist/internal/repo"
+)
+
+func TestSeedTwice(t *testing.T) {
+	ctx := context.Background()
+	millionaires := repo.NewMemoryMillionaireRepo()
+	history := repo.NewMemoryHistory()
+	uow := repo.NewMemoryUnitOfWork(repo.Repos{
+		Millionaires: millionaires,
+		History:      history,
+		Seed:         repo.NewMemorySeed(millionaires),
+	})
+	s := NewSeedService(uow, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	const n = seedBatchSize + 20
+	report, err := s.Seed(ctx, SeedOptions{Seed: 7, Count: n})
+	if err != nil || report.Created != n || report.Skipped != 0 {
+		t.Fatalf("first run: %+v, %v; want %d created", report, err, n)
+	}
+	all, err := millionaires.SearchAll(ctx, repo.MillionaireFilter{})
+	if err != nil || len(all) != n {
+		t.Fatalf("stored: %d millionaires, %v; want %d", len(all), err, n)
+	}
+	points, err := history.List(ctx, all[0].ID)
+	if err != nil || len(points) == 0 {
+		t.Errorf("history of %d: %v, %v", all[0].ID, points, err)
+	}
+
+	report, err = s.Seed(ctx, SeedOptions{Seed: 7, Count: n})
+	if err != nil || report.Created != 0 || report.Skipped != n {
+		t.Errorf("second run: %+v, %v; want all %d skipped", report, err, n)
+	}
+
+	report, err = s.Seed(ctx, SeedOptions{Seed: 7, Count: n + 5})
+	if err != nil || report.Created != 5 || report.Skipped != n {
+		t.Errorf("larger run: %+v, %v; want 5 created and %d skipped", report, err, n)
+	}
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"wealthlist/internal/logger"
+	"wealthlist/internal/models"
+	"wealthlist/internal/money"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
+)
+
+var (
+	ErrUnknownDimension = errors.New("unknown dimension")
+	ErrInvalidBuckets   = errors.New("invalid histogram buckets")
+)
+
+// Dimensions the statistics can be grouped by.
+const (
+	DimensionCountry  = "country"
+	DimensionIndustry = "industry"
+	DimensionAge      = "age"
+	DimensionDecade   = "decade"
+)
+
+const (
+	// unknownKey groups millionaires without the value grouped by.
+	unknownKey = "unknown"
+	// maxBuckets bounds the number of histogram bucket boundaries.
+	maxBuckets = 100
+)
+
+// percentiles are reported next to the median, keyed "p10" and so on.
+var percentiles = []int{10, 25, 75, 90, 99}
+
+// ageBrackets are the lower bounds of the age groups, see ageKey.
+var ageBrackets = []int{30, 40, 50, 60, 70, 80}
+
+// DefaultBuckets are the histogram boundaries used when none are given:
+// 1 million to 100 billion, a bucket per power of ten.
+var DefaultBuckets = []money.Amount{
+	money.FromInt(1_000_000),
+	money.FromInt(10_000_000),
+	money.FromInt(100_000_000),
+	money.FromInt(1_000_000_000),
+	money.FromInt(10_000_000_000),
+	money.FromInt(100_000_000_000),
+}
+
+type StatsService struct {
+	millionaires repo.MillionaireRepository
+	vocab        repo.Vocabulary
+	currency     *CurrencyService
+	log          *slog.Logger
+}
+
+func NewStatsService(millionaires repo.MillionaireRepository, vocab repo.Vocabulary, currency *CurrencyService, log *slog.Logger) *StatsService {
+	return &StatsService{millionaires: millionaires, vocab: vocab, currency: currency, log: log}
+}
+
+// valuations are the net worths of the millionaires matching q, converted
+// to currency at the rates of each valuation's day.
+type valuations struct {
+	currency     string
+	unconverted  int
+	millionaires []models.Millionaire
+}
+
+// load fetches and converts the millionaires matching q. An empty currency
+// is repo.RankingCurrency; without any rates for it, only valuations
+// already in it are kept.
+func (s *StatsService) load(ctx context.Context, q SearchQuery, currency string) (valuations, error) {
+	v := valuations{currency: strings.ToUpper(currency)}
+	if v.currency == "" {
+		v.currency = repo.RankingCurrency
+	}
+
+	filter, err := searchFilter(ctx, s.vocab, q)
+	if err != nil {
+		return v, err
+	}
+	ms, err := s.millionaires.SearchAll(ctx, filter)
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to fetch millionaires", logger.Err(err))
+		return v, err
+	}
+	err = s.currency.Convert(ctx, v.currency, ms)
+	if err != nil && !(errors.Is(err, ErrUnknownCurrency) && currency == "") {
+		return v, err
+	}
+
+	for _, m := range ms {
+		if m.NetWorth == nil || m.NetWorthCurrency != v.currency {
+			v.unconverted++
+			continue
+		}
+		v.millionaires = append(v.millionaires, m)
+	}
+	return v, nil
+}
+
+// Summary returns the statistics of every millionaire matching q.
+func (s *StatsService) Summary(ctx context.Context, q SearchQuery, currency string) (models.StatsSummary, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.Summary")
+	defer span.End()
+
+	v, err := s.load(ctx, q, currency)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return models.StatsSummary{}, err
+	}
+	return models.StatsSummary{
+		Currency:      v.currency,
+		Unconverted:   v.unconverted,
+		NetWorthStats: summarize(v.millionaires, v.currency),
+	}, nil
+}
+
+// Groups returns the statistics of the millionaires matching q per country
+// or industry code (the free text when there is none), age bracket or birth
+// decade. Countries and industries come by total, richest first; ages and
+// decades in order. Millionaires without the value are grouped as
+// "unknown", last.
+func (s *StatsService) Groups(ctx context.Context, q SearchQuery, currency, dimension string) (models.StatsGroups, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.Groups")
+	defer span.End()
+
+	var key func(models.Millionaire) string
+	today := time.Now()
+	switch dimension {
+	case DimensionCountry:
+		key = func(m models.Millionaire) string { return codeKey(m.CountryCode, m.Country) }
+	case DimensionIndustry:
+		key = func(m models.Millionaire) string { return codeKey(m.IndustryCode, m.Industry) }
+	case DimensionAge:
+		key = func(m models.Millionaire) string { return ageKey(m.BirthDate, today) }
+	case DimensionDecade:
+		key = func(m models.Millionaire) string { return decadeKey(m.BirthDate) }
+	default:
+		return models.StatsGroups{}, fmt.Errorf("%w %q", ErrUnknownDimension, dimension)
+	}
+
+	v, err := s.load(ctx, q, currency)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return models.StatsGroups{}, err
+	}
+
+	byKey := make(map[string][]models.Millionaire)
+	for _, m := range v.millionaires {
+		k := key(m)
+		byKey[k] = append(byKey[k], m)
+	}
+	groups := make([]models.NetWorthStats, 0, len(byKey))
+	for k, ms := range byKey {
+		stats := summarize(ms, v.currency)
+		stats.Key = k
+		groups = append(groups, stats)
+	}
+	slices.SortFunc(groups, func(a, b models.NetWorthStats) int {
+		if (a.Key == unknownKey) != (b.Key == unknownKey) {
+			if a.Key == unknownKey {
+				return 1
+			}
+			return -1
+		}
+		switch dimension {
+		case DimensionCountry, DimensionIndustry:
+			if c := b.Total.Cmp(a.Total); c != 0 {
+				return c
+			}
+		case DimensionAge:
+			// "<30" sorts after the digits; every other key starts with its bound.
+			if strings.HasPrefix(a.Key, "<") != strings.HasPrefix(b.Key, "<") {
+				if strings.HasPrefix(a.Key, "<") {
+					return -1
+				}
+				return 1
+			}
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return models.StatsGroups{
+		Currency:    v.currency,
+		Unconverted: v.unconverted,
+		Dimension:   dimension,
+		Groups:      groups,
+	}, nil
+}
+
+// Histogram counts the millionaires matching q between consecutive bounds,
+// which must be strictly increasing; none means DefaultBuckets.
+func (s *StatsService) Histogram(ctx context.Context, q SearchQuery, currency string, bounds []money.Amount) (models.Histogram, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.Histogram")
+	defer span.End()
+
+	if len(bounds) == 0 {
+		bounds = DefaultBuckets
+	}
+	if len(bounds) > maxBuckets {
+		return models.Histogram{}, fmt.Errorf("%w: at most %d boundaries", ErrInvalidBuckets, maxBuckets)
+	}
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i-1].Cmp(bounds[i]) >= 0 {
+			return models.Histogram{}, fmt.Errorf("%w: %s does not follow %s", ErrInvalidBuckets, bounds[i], bounds[i-1])
+		}
+	}
+
+	v, err := s.load(ctx, q, currency)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return models.Histogram{}, err
+	}
+
+	buckets := make([]models.HistogramBucket, len(bounds)+1)
+	for i := range bounds {
+		buckets[i].To = &bounds[i]
+		buckets[i+1].From = &bounds[i]
+	}
+	for _, m := range v.millionaires {
+		i := sort.Search(len(bounds), func(i int) bool { return m.NetWorth.Cmp(bounds[i]) < 0 })
+		buckets[i].Count++
+		buckets[i].Total = buckets[i].Total.Add(*m.NetWorth)
+	}
+
+	return models.Histogram{
+		Currency:    v.currency,
+		Unconverted: v.unconverted,
+		Buckets:     buckets,
+	}, nil
+}
+
+// summarize computes the statistics of the net worths of ms, all in
+// currency.
+func summarize(ms []models.Millionaire, currency string) models.NetWorthStats {
+	values := make([]money.Amount, len(ms))
+	for i, m := range ms {
+		values[i] = *m.NetWorth
+	}
+	slices.SortFunc(values, money.Amount.Cmp)
+
+	n := len(values)
+	stats := models.NetWorthStats{Count: n, Total: money.Sum(values...)}
+	if n == 0 {
+		return stats
+	}
+
+	units := money.MinorUnits(currency)
+	mean := stats.Total.Quo(money.FromInt(int64(n)), units)
+	median := percentile(values, 50, units)
+	stats.Mean, stats.Median = &mean, &median
+	stats.Min, stats.Max = &values[0], &values[n-1]
+	stats.Percentiles = make(map[string]money.Amount, len(percentiles))
+	for _, p := range percentiles {
+		stats.Percentiles["p"+strconv.Itoa(p)] = percentile(values, p, units)
+	}
+
+	if stats.Total.Sign() > 0 {
+		// G = Σ (2i - n - 1) x_i / (n Σ x) over x sorted ascending, i from 1.
+		var weighted money.Amount
+		for i, x := range values {
+			weighted = weighted.Add(x.Mul(money.FromInt(int64(2*(i+1) - n - 1))))
+		}
+		stats.Gini = ratio(weighted, stats.Total.Mul(money.FromInt(int64(n))))
+		stats.Top1Share = ratio(money.Sum(values[n-topCount(n, 1):]...), stats.Total)
+		stats.Top10Share = ratio(money.Sum(values[n-topCount(n, 10):]...), stats.Total)
+	}
+	return stats
+}
+
+// percentile interpolates linearly between the closest ranks of sorted,
+// like Postgres' percentile_cont, rounding to scale decimal places.
+func percentile(sorted []money.Amount, p, scale int) money.Amount {
+	pos := p * (len(sorted) - 1)
+	lo, rest := pos/100, pos%100
+	if rest == 0 {
+		return sorted[lo]
+	}
+	step := sorted[lo+1].Sub(sorted[lo]).Mul(money.FromInt(int64(rest))).Quo(money.FromInt(100), scale)
+	return sorted[lo].Add(step).Round(scale)
+}
+
+// topCount is how many of n people make up the top p percent, at least one.
+func topCount(n, p int) int {
+	return max((n*p+99)/100, 1)
+}
+
+// ratio returns a/b to four decimal places.
+func ratio(a, b money.Amount) *float64 {
+	r, _ := strconv.ParseFloat(a.Quo(b, 4).String(), 64)
+	return &r
+}
+
+func codeKey(code, text *string) string {
+	switch {
+	case code != nil && *code != "":
+		return *code
+	case text != nil && *text != "":
+		return *text
+	}
+	return unknownKey
+}
+
+// birthday reads the day a birth date column holds, as given or as the
+// driver prints a DATE.
+func birthday(date *string) (time.Time, bool) {
+	if date == nil || len(*date) < len(time.DateOnly) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.DateOnly, (*date)[:len(time.DateOnly)])
+	return t, err == nil
+}
+
+// ageKey puts an age on today into a bracket: "<30", "30-39" to "70-79",
+// and "80+".
+func ageKey(date *string, today time.Time) string {
+	born, ok := birthday(date)
+	if !ok {
+		return unknownKey
+	}
+	age := today.Year() - born.Year()
+	if today.Month() < born.Month() || today.Month() == born.Month() && today.Day() < born.Day() {
+		age--
+	}
+
+	i := sort.SearchInts(ageBrackets, age+1) // brackets at or below age
+	switch {
+	case i == 0:
+		return "<" + strconv.Itoa(ageBrackets[0])
+	case i == len(ageBrackets):
+		return strconv.Itoa(ageBrackets[i-1]) + "+"
+	}
+	return fmt.Sprintf("%d-%d", ageBrackets[i-1], ageBrackets[i]-1)
+}
+
+// decadeKey is the decade of birth, such as "1950s".
+func decadeKey(date *string) string {
+	born, ok := birthday(date)
+	if !ok {
+		return unknownKey
+	}
+	return strconv.Itoa(born.Year()/10*10) + "s"
+}
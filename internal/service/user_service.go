@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"wealthlist/internal/models"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	RoleAdmin = "admin"
+
+	MinPasswordLength = 12
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrWeakPassword       = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+)
+
+type UserService struct {
+	repo *repo.UserRepo
+	log  *slog.Logger
+}
+
+func NewUserService(repo *repo.UserRepo, log *slog.Logger) *UserService {
+	return &UserService{repo: repo, log: log}
+}
+
+func (s *UserService) CreateUser(ctx context.Context, username, password, role string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, errors.New("username is required")
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &models.User{Username: username, PasswordHash: hash, Role: role}
+	if err := s.repo.Create(ctx, u); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	s.log.InfoContext(ctx, "User created", slog.String("username", username), slog.String("role", role))
+	return u, nil
+}
+
+func (s *UserService) ResetPassword(ctx context.Context, username, password string) error {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer span.End()
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, username, hash); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	s.log.InfoContext(ctx, "Password reset", slog.String("username", username))
+	return nil
+}
+
+// Authenticate returns the user if the password matches. Unknown users and
+// wrong passwords both yield ErrInvalidCredentials.
+func (s *UserService) Authenticate(ctx context.Context, username, password string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Authenticate")
+	defer span.End()
+
+	u, err := s.repo.GetByUsername(ctx, username)
+	if errors.Is(err, repo.ErrUserNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return u, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
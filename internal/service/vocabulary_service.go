@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"wealthlist/internal/models"
+	"wealthlist/internal/repo"
+	"wealthlist/internal/tracing"
+)
+
+var (
+	ErrCountryNotFound  = errors.New("country not found")
+	ErrIndustryNotFound = errors.New("industry not found")
+)
+
+type VocabularyService struct {
+	repo *repo.VocabularyRepo
+	log  *slog.Logger
+}
+
+func NewVocabularyService(repo *repo.VocabularyRepo, log *slog.Logger) *VocabularyService {
+	return &VocabularyService{repo: repo, log: log}
+}
+
+// Countries lists all countries, named in lang where possible.
+func (s *VocabularyService) Countries(ctx context.Context, lang string) ([]models.Country, error) {
+	ctx, span := tracing.Start(ctx, "VocabularyService.Countries")
+	defer span.End()
+
+	countries, err := s.repo.Countries(ctx, lang)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	return countries, err
+}
+
+func (s *VocabularyService) Country(ctx context.Context, code string) (*models.Country, error) {
+	ctx, span := tracing.Start(ctx, "VocabularyService.Country")
+	defer span.End()
+
+	country, err := s.repo.Country(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCountryNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	return country, err
+}
+
+// Industries returns the taxonomy as a tree of its top-level industries.
+func (s *VocabularyService) Industries(ctx context.Context) ([]models.Industry, error) {
+	ctx, span := tracing.Start(ctx, "VocabularyService.Industries")
+	defer span.End()
+
+	flat, err := s.repo.Industries(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return industryTree(flat, nil), nil
+}
+
+// Industry returns one industry with the industries below it.
+func (s *VocabularyService) Industry(ctx context.Context, code string) (*models.Industry, error) {
+	ctx, span := tracing.Start(ctx, "VocabularyService.Industry")
+	defer span.End()
+
+	flat, err := s.repo.Industries(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for _, i := range flat {
+		if i.Code == code {
+			i.Children = industryTree(flat, &i.Code)
+			return &i, nil
+		}
+	}
+	return nil, ErrIndustryNotFound
+}
+
+// Unmatched lists free-text values that no country or industry code was
+// found for.
+func (s *VocabularyService) Unmatched(ctx context.Context) ([]models.UnmatchedValue, error) {
+	ctx, span := tracing.Start(ctx, "VocabularyService.Unmatched")
+	defer span.End()
+
+	values, err := s.repo.Unmatched(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	return values, err
+}
+
+// industryTree nests the industries of flat below parent, or below the
+// root when parent is nil.
+func industryTree(flat []models.Industry, parent *string) []models.Industry {
+	var level []models.Industry
+	for _, i := range flat {
+		if (parent == nil) != (i.ParentCode == nil) || parent != nil && *parent != *i.ParentCode {
+			continue
+		}
+		i.Children = industryTree(flat, &i.Code)
+		level = append(level, i)
+	}
+	return level
+}
@@ -2,6 +2,12 @@ package main
 
 import "wealthlist/cmd"
 
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin API token, sent as "Bearer <ADMIN_TOKEN>".
+//
+// @securityDefinitions.basic AdminBasic
 func main() {
 	cmd.Run()
 }
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS millionaires (
+    id SERIAL PRIMARY KEY,
+    last_name VARCHAR(500) NOT NULL,
+    first_name VARCHAR(500) NOT NULL,
+    middle_name VARCHAR(500),
+    birth_date DATE,
+    birth_place TEXT,
+    net_worth BIGINT NOT NULL,
+    industry TEXT,
+    country TEXT,
+    company TEXT,
+    biography TEXT,
+    path_to_photo TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
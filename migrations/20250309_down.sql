@@ -1 +0,0 @@
-DROP TABLE IF EXISTS millionaires;
\ No newline at end of file
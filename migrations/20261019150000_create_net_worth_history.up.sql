@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS net_worth_history (
+    millionaire_id INTEGER NOT NULL REFERENCES millionaires (id) ON DELETE CASCADE,
+    recorded_on DATE NOT NULL,
+    net_worth BIGINT NOT NULL,
+    PRIMARY KEY (millionaire_id, recorded_on)
+);
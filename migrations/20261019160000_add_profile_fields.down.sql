@@ -0,0 +1,8 @@
+ALTER TABLE millionaires
+    DROP COLUMN IF EXISTS education,
+    DROP COLUMN IF EXISTS citizenships,
+    DROP COLUMN IF EXISTS marital_status,
+    DROP COLUMN IF EXISTS children_count,
+    DROP COLUMN IF EXISTS residence_city,
+    DROP COLUMN IF EXISTS website,
+    DROP COLUMN IF EXISTS social_handles;
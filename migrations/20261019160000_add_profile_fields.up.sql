@@ -0,0 +1,8 @@
+ALTER TABLE millionaires
+    ADD COLUMN IF NOT EXISTS education TEXT,
+    ADD COLUMN IF NOT EXISTS citizenships TEXT[],
+    ADD COLUMN IF NOT EXISTS marital_status VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS children_count INTEGER CHECK (children_count >= 0),
+    ADD COLUMN IF NOT EXISTS residence_city TEXT,
+    ADD COLUMN IF NOT EXISTS website TEXT,
+    ADD COLUMN IF NOT EXISTS social_handles JSONB;
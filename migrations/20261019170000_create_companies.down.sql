@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS holdings;
+DROP TABLE IF EXISTS companies;
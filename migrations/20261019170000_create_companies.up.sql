@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS companies (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(500) NOT NULL,
+    legal_id VARCHAR(100) UNIQUE,
+    country TEXT,
+    industry TEXT,
+    ticker VARCHAR(20),
+    founded DATE,
+    website TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS companies_name_idx ON companies (lower(name));
+
+CREATE TABLE IF NOT EXISTS holdings (
+    id SERIAL PRIMARY KEY,
+    millionaire_id INTEGER NOT NULL REFERENCES millionaires (id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies (id) ON DELETE RESTRICT,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('founder', 'ceo', 'shareholder')),
+    stake_percent NUMERIC(7, 4) CHECK (stake_percent > 0 AND stake_percent <= 100),
+    value BIGINT CHECK (value >= 0),
+    started_on DATE,
+    ended_on DATE,
+    CHECK (ended_on IS NULL OR started_on IS NULL OR ended_on >= started_on)
+);
+
+CREATE INDEX IF NOT EXISTS holdings_millionaire_id_idx ON holdings (millionaire_id);
+CREATE INDEX IF NOT EXISTS holdings_company_id_idx ON holdings (company_id);
+
+-- Turn the free-text company of each millionaire into a company and a
+-- shareholder holding. Spellings that differ only in case or surrounding
+-- spaces become one company.
+INSERT INTO companies (name, country, industry)
+SELECT DISTINCT ON (lower(trim(company))) trim(company), country, industry
+FROM millionaires
+WHERE trim(coalesce(company, '')) <> ''
+ORDER BY lower(trim(company)), id;
+
+INSERT INTO holdings (millionaire_id, company_id, role)
+SELECT m.id, c.id, 'shareholder'
+FROM millionaires m
+JOIN companies c ON lower(c.name) = lower(trim(m.company));
@@ -0,0 +1,12 @@
+DROP VIEW IF EXISTS unmatched_vocabulary;
+DROP VIEW IF EXISTS industry_lookup;
+DROP VIEW IF EXISTS country_lookup;
+
+ALTER TABLE millionaires
+    DROP COLUMN IF EXISTS country_code,
+    DROP COLUMN IF EXISTS industry_code;
+
+DROP TABLE IF EXISTS industries;
+DROP TABLE IF EXISTS country_aliases;
+DROP TABLE IF EXISTS country_names;
+DROP TABLE IF EXISTS countries;
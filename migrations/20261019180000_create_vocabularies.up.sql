@@ -0,0 +1,1160 @@
+-- Countries follow ISO 3166-1. Names come from the Unicode CLDR; name is
+-- the English one, country_names adds translations and country_aliases
+-- common spellings that are neither.
+CREATE TABLE IF NOT EXISTS countries (
+    code CHAR(2) PRIMARY KEY,
+    alpha3 CHAR(3) NOT NULL UNIQUE,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS country_names (
+    country_code CHAR(2) NOT NULL REFERENCES countries (code) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    name TEXT NOT NULL,
+    PRIMARY KEY (country_code, lang)
+);
+
+CREATE TABLE IF NOT EXISTS country_aliases (
+    alias TEXT PRIMARY KEY,
+    country_code CHAR(2) NOT NULL REFERENCES countries (code) ON DELETE CASCADE
+);
+
+-- Industry codes are dotted paths: a child's code is its parent's code,
+-- a dot and one more segment, so "technology" and everything below it is
+-- code = 'technology' OR code LIKE 'technology.%'.
+CREATE TABLE IF NOT EXISTS industries (
+    code VARCHAR(100) PRIMARY KEY CHECK (code ~ '^[a-z0-9-]+(\.[a-z0-9-]+)*$'),
+    parent_code VARCHAR(100) REFERENCES industries (code),
+    name TEXT NOT NULL UNIQUE,
+    CHECK (
+        (parent_code IS NULL AND position('.' IN code) = 0)
+        OR (code LIKE parent_code || '.%' AND position('.' IN substr(code, length(parent_code) + 2)) = 0)
+    )
+);
+
+INSERT INTO countries (code, alpha3, name) VALUES
+    ('AD', 'AND', 'Andorra'),
+    ('AE', 'ARE', 'United Arab Emirates'),
+    ('AF', 'AFG', 'Afghanistan'),
+    ('AG', 'ATG', 'Antigua & Barbuda'),
+    ('AI', 'AIA', 'Anguilla'),
+    ('AL', 'ALB', 'Albania'),
+    ('AM', 'ARM', 'Armenia'),
+    ('AO', 'AGO', 'Angola'),
+    ('AQ', 'ATA', 'Antarctica'),
+    ('AR', 'ARG', 'Argentina'),
+    ('AS', 'ASM', 'American Samoa'),
+    ('AT', 'AUT', 'Austria'),
+    ('AU', 'AUS', 'Australia'),
+    ('AW', 'ABW', 'Aruba'),
+    ('AX', 'ALA', 'Åland Islands'),
+    ('AZ', 'AZE', 'Azerbaijan'),
+    ('BA', 'BIH', 'Bosnia & Herzegovina'),
+    ('BB', 'BRB', 'Barbados'),
+    ('BD', 'BGD', 'Bangladesh'),
+    ('BE', 'BEL', 'Belgium'),
+    ('BF', 'BFA', 'Burkina Faso'),
+    ('BG', 'BGR', 'Bulgaria'),
+    ('BH', 'BHR', 'Bahrain'),
+    ('BI', 'BDI', 'Burundi'),
+    ('BJ', 'BEN', 'Benin'),
+    ('BL', 'BLM', 'St. Barthélemy'),
+    ('BM', 'BMU', 'Bermuda'),
+    ('BN', 'BRN', 'Brunei'),
+    ('BO', 'BOL', 'Bolivia'),
+    ('BQ', 'BES', 'Caribbean Netherlands'),
+    ('BR', 'BRA', 'Brazil'),
+    ('BS', 'BHS', 'Bahamas'),
+    ('BT', 'BTN', 'Bhutan'),
+    ('BV', 'BVT', 'Bouvet Island'),
+    ('BW', 'BWA', 'Botswana'),
+    ('BY', 'BLR', 'Belarus'),
+    ('BZ', 'BLZ', 'Belize'),
+    ('CA', 'CAN', 'Canada'),
+    ('CC', 'CCK', 'Cocos (Keeling) Islands'),
+    ('CD', 'COD', 'Congo - Kinshasa'),
+    ('CF', 'CAF', 'Central African Republic'),
+    ('CG', 'COG', 'Congo - Brazzaville'),
+    ('CH', 'CHE', 'Switzerland'),
+    ('CI', 'CIV', 'Côte d’Ivoire'),
+    ('CK', 'COK', 'Cook Islands'),
+    ('CL', 'CHL', 'Chile'),
+    ('CM', 'CMR', 'Cameroon'),
+    ('CN', 'CHN', 'China'),
+    ('CO', 'COL', 'Colombia'),
+    ('CR', 'CRI', 'Costa Rica'),
+    ('CU', 'CUB', 'Cuba'),
+    ('CV', 'CPV', 'Cape Verde'),
+    ('CW', 'CUW', 'Curaçao'),
+    ('CX', 'CXR', 'Christmas Island'),
+    ('CY', 'CYP', 'Cyprus'),
+    ('CZ', 'CZE', 'Czechia'),
+    ('DE', 'DEU', 'Germany'),
+    ('DJ', 'DJI', 'Djibouti'),
+    ('DK', 'DNK', 'Denmark'),
+    ('DM', 'DMA', 'Dominica'),
+    ('DO', 'DOM', 'Dominican Republic'),
+    ('DZ', 'DZA', 'Algeria'),
+    ('EC', 'ECU', 'Ecuador'),
+    ('EE', 'EST', 'Estonia'),
+    ('EG', 'EGY', 'Egypt'),
+    ('EH', 'ESH', 'Western Sahara'),
+    ('ER', 'ERI', 'Eritrea'),
+    ('ES', 'ESP', 'Spain'),
+    ('ET', 'ETH', 'Ethiopia'),
+    ('FI', 'FIN', 'Finland'),
+    ('FJ', 'FJI', 'Fiji'),
+    ('FK', 'FLK', 'Falkland Islands'),
+    ('FM', 'FSM', 'Micronesia'),
+    ('FO', 'FRO', 'Faroe Islands'),
+    ('FR', 'FRA', 'France'),
+    ('GA', 'GAB', 'Gabon'),
+    ('GB', 'GBR', 'United Kingdom'),
+    ('GD', 'GRD', 'Grenada'),
+    ('GE', 'GEO', 'Georgia'),
+    ('GF', 'GUF', 'French Guiana'),
+    ('GG', 'GGY', 'Guernsey'),
+    ('GH', 'GHA', 'Ghana'),
+    ('GI', 'GIB', 'Gibraltar'),
+    ('GL', 'GRL', 'Greenland'),
+    ('GM', 'GMB', 'Gambia'),
+    ('GN', 'GIN', 'Guinea'),
+    ('GP', 'GLP', 'Guadeloupe'),
+    ('GQ', 'GNQ', 'Equatorial Guinea'),
+    ('GR', 'GRC', 'Greece'),
+    ('GS', 'SGS', 'South Georgia & South Sandwich Islands'),
+    ('GT', 'GTM', 'Guatemala'),
+    ('GU', 'GUM', 'Guam'),
+    ('GW', 'GNB', 'Guinea-Bissau'),
+    ('GY', 'GUY', 'Guyana'),
+    ('HK', 'HKG', 'Hong Kong SAR China'),
+    ('HM', 'HMD', 'Heard & McDonald Islands'),
+    ('HN', 'HND', 'Honduras'),
+    ('HR', 'HRV', 'Croatia'),
+    ('HT', 'HTI', 'Haiti'),
+    ('HU', 'HUN', 'Hungary'),
+    ('ID', 'IDN', 'Indonesia'),
+    ('IE', 'IRL', 'Ireland'),
+    ('IL', 'ISR', 'Israel'),
+    ('IM', 'IMN', 'Isle of Man'),
+    ('IN', 'IND', 'India'),
+    ('IO', 'IOT', 'British Indian Ocean Territory'),
+    ('IQ', 'IRQ', 'Iraq'),
+    ('IR', 'IRN', 'Iran'),
+    ('IS', 'ISL', 'Iceland'),
+    ('IT', 'ITA', 'Italy'),
+    ('JE', 'JEY', 'Jersey'),
+    ('JM', 'JAM', 'Jamaica'),
+    ('JO', 'JOR', 'Jordan'),
+    ('JP', 'JPN', 'Japan'),
+    ('KE', 'KEN', 'Kenya'),
+    ('KG', 'KGZ', 'Kyrgyzstan'),
+    ('KH', 'KHM', 'Cambodia'),
+    ('KI', 'KIR', 'Kiribati'),
+    ('KM', 'COM', 'Comoros'),
+    ('KN', 'KNA', 'St. Kitts & Nevis'),
+    ('KP', 'PRK', 'North Korea'),
+    ('KR', 'KOR', 'South Korea'),
+    ('KW', 'KWT', 'Kuwait'),
+    ('KY', 'CYM', 'Cayman Islands'),
+    ('KZ', 'KAZ', 'Kazakhstan'),
+    ('LA', 'LAO', 'Laos'),
+    ('LB', 'LBN', 'Lebanon'),
+    ('LC', 'LCA', 'St. Lucia'),
+    ('LI', 'LIE', 'Liechtenstein'),
+    ('LK', 'LKA', 'Sri Lanka'),
+    ('LR', 'LBR', 'Liberia'),
+    ('LS', 'LSO', 'Lesotho'),
+    ('LT', 'LTU', 'Lithuania'),
+    ('LU', 'LUX', 'Luxembourg'),
+    ('LV', 'LVA', 'Latvia'),
+    ('LY', 'LBY', 'Libya'),
+    ('MA', 'MAR', 'Morocco'),
+    ('MC', 'MCO', 'Monaco'),
+    ('MD', 'MDA', 'Moldova'),
+    ('ME', 'MNE', 'Montenegro'),
+    ('MF', 'MAF', 'St. Martin'),
+    ('MG', 'MDG', 'Madagascar'),
+    ('MH', 'MHL', 'Marshall Islands'),
+    ('MK', 'MKD', 'Macedonia'),
+    ('ML', 'MLI', 'Mali'),
+    ('MM', 'MMR', 'Myanmar (Burma)'),
+    ('MN', 'MNG', 'Mongolia'),
+    ('MO', 'MAC', 'Macau SAR China'),
+    ('MP', 'MNP', 'Northern Mariana Islands'),
+    ('MQ', 'MTQ', 'Martinique'),
+    ('MR', 'MRT', 'Mauritania'),
+    ('MS', 'MSR', 'Montserrat'),
+    ('MT', 'MLT', 'Malta'),
+    ('MU', 'MUS', 'Mauritius'),
+    ('MV', 'MDV', 'Maldives'),
+    ('MW', 'MWI', 'Malawi'),
+    ('MX', 'MEX', 'Mexico'),
+    ('MY', 'MYS', 'Malaysia'),
+    ('MZ', 'MOZ', 'Mozambique'),
+    ('NA', 'NAM', 'Namibia'),
+    ('NC', 'NCL', 'New Caledonia'),
+    ('NE', 'NER', 'Niger'),
+    ('NF', 'NFK', 'Norfolk Island'),
+    ('NG', 'NGA', 'Nigeria'),
+    ('NI', 'NIC', 'Nicaragua'),
+    ('NL', 'NLD', 'Netherlands'),
+    ('NO', 'NOR', 'Norway'),
+    ('NP', 'NPL', 'Nepal'),
+    ('NR', 'NRU', 'Nauru'),
+    ('NU', 'NIU', 'Niue'),
+    ('NZ', 'NZL', 'New Zealand'),
+    ('OM', 'OMN', 'Oman'),
+    ('PA', 'PAN', 'Panama'),
+    ('PE', 'PER', 'Peru'),
+    ('PF', 'PYF', 'French Polynesia'),
+    ('PG', 'PNG', 'Papua New Guinea'),
+    ('PH', 'PHL', 'Philippines'),
+    ('PK', 'PAK', 'Pakistan'),
+    ('PL', 'POL', 'Poland'),
+    ('PM', 'SPM', 'St. Pierre & Miquelon'),
+    ('PN', 'PCN', 'Pitcairn Islands'),
+    ('PR', 'PRI', 'Puerto Rico'),
+    ('PS', 'PSE', 'Palestinian Territories'),
+    ('PT', 'PRT', 'Portugal'),
+    ('PW', 'PLW', 'Palau'),
+    ('PY', 'PRY', 'Paraguay'),
+    ('QA', 'QAT', 'Qatar'),
+    ('RE', 'REU', 'Réunion'),
+    ('RO', 'ROU', 'Romania'),
+    ('RS', 'SRB', 'Serbia'),
+    ('RU', 'RUS', 'Russia'),
+    ('RW', 'RWA', 'Rwanda'),
+    ('SA', 'SAU', 'Saudi Arabia'),
+    ('SB', 'SLB', 'Solomon Islands'),
+    ('SC', 'SYC', 'Seychelles'),
+    ('SD', 'SDN', 'Sudan'),
+    ('SE', 'SWE', 'Sweden'),
+    ('SG', 'SGP', 'Singapore'),
+    ('SH', 'SHN', 'St. Helena'),
+    ('SI', 'SVN', 'Slovenia'),
+    ('SJ', 'SJM', 'Svalbard & Jan Mayen'),
+    ('SK', 'SVK', 'Slovakia'),
+    ('SL', 'SLE', 'Sierra Leone'),
+    ('SM', 'SMR', 'San Marino'),
+    ('SN', 'SEN', 'Senegal'),
+    ('SO', 'SOM', 'Somalia'),
+    ('SR', 'SUR', 'Suriname'),
+    ('SS', 'SSD', 'South Sudan'),
+    ('ST', 'STP', 'São Tomé & Príncipe'),
+    ('SV', 'SLV', 'El Salvador'),
+    ('SX', 'SXM', 'Sint Maarten'),
+    ('SY', 'SYR', 'Syria'),
+    ('SZ', 'SWZ', 'Swaziland'),
+    ('TC', 'TCA', 'Turks & Caicos Islands'),
+    ('TD', 'TCD', 'Chad'),
+    ('TF', 'ATF', 'French Southern Territories'),
+    ('TG', 'TGO', 'Togo'),
+    ('TH', 'THA', 'Thailand'),
+    ('TJ', 'TJK', 'Tajikistan'),
+    ('TK', 'TKL', 'Tokelau'),
+    ('TL', 'TLS', 'Timor-Leste'),
+    ('TM', 'TKM', 'Turkmenistan'),
+    ('TN', 'TUN', 'Tunisia'),
+    ('TO', 'TON', 'Tonga'),
+    ('TR', 'TUR', 'Turkey'),
+    ('TT', 'TTO', 'Trinidad & Tobago'),
+    ('TV', 'TUV', 'Tuvalu'),
+    ('TW', 'TWN', 'Taiwan'),
+    ('TZ', 'TZA', 'Tanzania'),
+    ('UA', 'UKR', 'Ukraine'),
+    ('UG', 'UGA', 'Uganda'),
+    ('UM', 'UMI', 'U.S. Outlying Islands'),
+    ('US', 'USA', 'United States'),
+    ('UY', 'URY', 'Uruguay'),
+    ('UZ', 'UZB', 'Uzbekistan'),
+    ('VA', 'VAT', 'Vatican City'),
+    ('VC', 'VCT', 'St. Vincent & Grenadines'),
+    ('VE', 'VEN', 'Venezuela'),
+    ('VG', 'VGB', 'British Virgin Islands'),
+    ('VI', 'VIR', 'U.S. Virgin Islands'),
+    ('VN', 'VNM', 'Vietnam'),
+    ('VU', 'VUT', 'Vanuatu'),
+    ('WF', 'WLF', 'Wallis & Futuna'),
+    ('WS', 'WSM', 'Samoa'),
+    ('YE', 'YEM', 'Yemen'),
+    ('YT', 'MYT', 'Mayotte'),
+    ('ZA', 'ZAF', 'South Africa'),
+    ('ZM', 'ZMB', 'Zambia'),
+    ('ZW', 'ZWE', 'Zimbabwe');
+
+INSERT INTO country_names (country_code, lang, name) VALUES
+    ('AD', 'en', 'Andorra'),
+    ('AD', 'ru', 'Андорра'),
+    ('AD', 'kk', 'Андорра'),
+    ('AE', 'en', 'United Arab Emirates'),
+    ('AE', 'ru', 'ОАЭ'),
+    ('AE', 'kk', 'Біріккен Араб Әмірліктері'),
+    ('AF', 'en', 'Afghanistan'),
+    ('AF', 'ru', 'Афганистан'),
+    ('AF', 'kk', 'Ауғанстан'),
+    ('AG', 'en', 'Antigua & Barbuda'),
+    ('AG', 'ru', 'Антигуа и Барбуда'),
+    ('AG', 'kk', 'Антигуа және Барбуда'),
+    ('AI', 'en', 'Anguilla'),
+    ('AI', 'ru', 'Ангилья'),
+    ('AI', 'kk', 'Ангилья'),
+    ('AL', 'en', 'Albania'),
+    ('AL', 'ru', 'Албания'),
+    ('AL', 'kk', 'Албания'),
+    ('AM', 'en', 'Armenia'),
+    ('AM', 'ru', 'Армения'),
+    ('AM', 'kk', 'Армения'),
+    ('AO', 'en', 'Angola'),
+    ('AO', 'ru', 'Ангола'),
+    ('AO', 'kk', 'Ангола'),
+    ('AQ', 'en', 'Antarctica'),
+    ('AQ', 'ru', 'Антарктида'),
+    ('AQ', 'kk', 'Антарктида'),
+    ('AR', 'en', 'Argentina'),
+    ('AR', 'ru', 'Аргентина'),
+    ('AR', 'kk', 'Аргентина'),
+    ('AS', 'en', 'American Samoa'),
+    ('AS', 'ru', 'Американское Самоа'),
+    ('AS', 'kk', 'Америкалық Самоа'),
+    ('AT', 'en', 'Austria'),
+    ('AT', 'ru', 'Австрия'),
+    ('AT', 'kk', 'Австрия'),
+    ('AU', 'en', 'Australia'),
+    ('AU', 'ru', 'Австралия'),
+    ('AU', 'kk', 'Австралия'),
+    ('AW', 'en', 'Aruba'),
+    ('AW', 'ru', 'Аруба'),
+    ('AW', 'kk', 'Аруба'),
+    ('AX', 'en', 'Åland Islands'),
+    ('AX', 'ru', 'Аландские о-ва'),
+    ('AX', 'kk', 'Аланд аралдары'),
+    ('AZ', 'en', 'Azerbaijan'),
+    ('AZ', 'ru', 'Азербайджан'),
+    ('AZ', 'kk', 'Әзірбайжан'),
+    ('BA', 'en', 'Bosnia & Herzegovina'),
+    ('BA', 'ru', 'Босния и Герцеговина'),
+    ('BA', 'kk', 'Босния және Герцеговина'),
+    ('BB', 'en', 'Barbados'),
+    ('BB', 'ru', 'Барбадос'),
+    ('BB', 'kk', 'Барбадос'),
+    ('BD', 'en', 'Bangladesh'),
+    ('BD', 'ru', 'Бангладеш'),
+    ('BD', 'kk', 'Бангладеш'),
+    ('BE', 'en', 'Belgium'),
+    ('BE', 'ru', 'Бельгия'),
+    ('BE', 'kk', 'Бельгия'),
+    ('BF', 'en', 'Burkina Faso'),
+    ('BF', 'ru', 'Буркина-Фасо'),
+    ('BF', 'kk', 'Буркина-Фасо'),
+    ('BG', 'en', 'Bulgaria'),
+    ('BG', 'ru', 'Болгария'),
+    ('BG', 'kk', 'Болгария'),
+    ('BH', 'en', 'Bahrain'),
+    ('BH', 'ru', 'Бахрейн'),
+    ('BH', 'kk', 'Бахрейн'),
+    ('BI', 'en', 'Burundi'),
+    ('BI', 'ru', 'Бурунди'),
+    ('BI', 'kk', 'Бурунди'),
+    ('BJ', 'en', 'Benin'),
+    ('BJ', 'ru', 'Бенин'),
+    ('BJ', 'kk', 'Бенин'),
+    ('BL', 'en', 'St. Barthélemy'),
+    ('BL', 'ru', 'Сен-Бартелеми'),
+    ('BL', 'kk', 'Сен-Бартелеми'),
+    ('BM', 'en', 'Bermuda'),
+    ('BM', 'ru', 'Бермудские о-ва'),
+    ('BM', 'kk', 'Бермуд аралдары'),
+    ('BN', 'en', 'Brunei'),
+    ('BN', 'ru', 'Бруней-Даруссалам'),
+    ('BN', 'kk', 'Бруней'),
+    ('BO', 'en', 'Bolivia'),
+    ('BO', 'ru', 'Боливия'),
+    ('BO', 'kk', 'Боливия'),
+    ('BQ', 'en', 'Caribbean Netherlands'),
+    ('BQ', 'ru', 'Бонэйр, Синт-Эстатиус и Саба'),
+    ('BQ', 'kk', 'Бонэйр, Синт-Эстатиус және Саба'),
+    ('BR', 'en', 'Brazil'),
+    ('BR', 'ru', 'Бразилия'),
+    ('BR', 'kk', 'Бразилия'),
+    ('BS', 'en', 'Bahamas'),
+    ('BS', 'ru', 'Багамы'),
+    ('BS', 'kk', 'Багам аралдары'),
+    ('BT', 'en', 'Bhutan'),
+    ('BT', 'ru', 'Бутан'),
+    ('BT', 'kk', 'Бутан'),
+    ('BV', 'en', 'Bouvet Island'),
+    ('BV', 'ru', 'о-в Буве'),
+    ('BV', 'kk', 'Буве аралы'),
+    ('BW', 'en', 'Botswana'),
+    ('BW', 'ru', 'Ботсвана'),
+    ('BW', 'kk', 'Ботсвана'),
+    ('BY', 'en', 'Belarus'),
+    ('BY', 'ru', 'Беларусь'),
+    ('BY', 'kk', 'Беларусь'),
+    ('BZ', 'en', 'Belize'),
+    ('BZ', 'ru', 'Белиз'),
+    ('BZ', 'kk', 'Белиз'),
+    ('CA', 'en', 'Canada'),
+    ('CA', 'ru', 'Канада'),
+    ('CA', 'kk', 'Канада'),
+    ('CC', 'en', 'Cocos (Keeling) Islands'),
+    ('CC', 'ru', 'Кокосовые о-ва'),
+    ('CC', 'kk', 'Кокос (Килинг) аралдары'),
+    ('CD', 'en', 'Congo - Kinshasa'),
+    ('CD', 'ru', 'Конго - Киншаса'),
+    ('CD', 'kk', 'Конго'),
+    ('CF', 'en', 'Central African Republic'),
+    ('CF', 'ru', 'Центрально-Африканская Республика'),
+    ('CF', 'kk', 'Орталық Африка Республикасы'),
+    ('CG', 'en', 'Congo - Brazzaville'),
+    ('CG', 'ru', 'Конго - Браззавиль'),
+    ('CG', 'kk', 'Конго-Браззавиль Республикасы'),
+    ('CH', 'en', 'Switzerland'),
+    ('CH', 'ru', 'Швейцария'),
+    ('CH', 'kk', 'Швейцария'),
+    ('CI', 'en', 'Côte d’Ivoire'),
+    ('CI', 'ru', 'Кот-д’Ивуар'),
+    ('CI', 'kk', 'Кот-д’Ивуар'),
+    ('CK', 'en', 'Cook Islands'),
+    ('CK', 'ru', 'Острова Кука'),
+    ('CK', 'kk', 'Кук аралдары'),
+    ('CL', 'en', 'Chile'),
+    ('CL', 'ru', 'Чили'),
+    ('CL', 'kk', 'Чили'),
+    ('CM', 'en', 'Cameroon'),
+    ('CM', 'ru', 'Камерун'),
+    ('CM', 'kk', 'Камерун'),
+    ('CN', 'en', 'China'),
+    ('CN', 'ru', 'Китай'),
+    ('CN', 'kk', 'Қытай'),
+    ('CO', 'en', 'Colombia'),
+    ('CO', 'ru', 'Колумбия'),
+    ('CO', 'kk', 'Колумбия'),
+    ('CR', 'en', 'Costa Rica'),
+    ('CR', 'ru', 'Коста-Рика'),
+    ('CR', 'kk', 'Коста-Рика'),
+    ('CU', 'en', 'Cuba'),
+    ('CU', 'ru', 'Куба'),
+    ('CU', 'kk', 'Куба'),
+    ('CV', 'en', 'Cape Verde'),
+    ('CV', 'ru', 'Кабо-Верде'),
+    ('CV', 'kk', 'Кабо-Верде'),
+    ('CW', 'en', 'Curaçao'),
+    ('CW', 'ru', 'Кюрасао'),
+    ('CW', 'kk', 'Кюрасао'),
+    ('CX', 'en', 'Christmas Island'),
+    ('CX', 'ru', 'о-в Рождества'),
+    ('CX', 'kk', 'Рождество аралы'),
+    ('CY', 'en', 'Cyprus'),
+    ('CY', 'ru', 'Кипр'),
+    ('CY', 'kk', 'Кипр'),
+    ('CZ', 'en', 'Czechia'),
+    ('CZ', 'ru', 'Чехия'),
+    ('CZ', 'kk', 'Чехия'),
+    ('DE', 'en', 'Germany'),
+    ('DE', 'ru', 'Германия'),
+    ('DE', 'kk', 'Германия'),
+    ('DJ', 'en', 'Djibouti'),
+    ('DJ', 'ru', 'Джибути'),
+    ('DJ', 'kk', 'Джибути'),
+    ('DK', 'en', 'Denmark'),
+    ('DK', 'ru', 'Дания'),
+    ('DK', 'kk', 'Дания'),
+    ('DM', 'en', 'Dominica'),
+    ('DM', 'ru', 'Доминика'),
+    ('DM', 'kk', 'Доминика'),
+    ('DO', 'en', 'Dominican Republic'),
+    ('DO', 'ru', 'Доминиканская Республика'),
+    ('DO', 'kk', 'Доминикан Республикасы'),
+    ('DZ', 'en', 'Algeria'),
+    ('DZ', 'ru', 'Алжир'),
+    ('DZ', 'kk', 'Алжир'),
+    ('EC', 'en', 'Ecuador'),
+    ('EC', 'ru', 'Эквадор'),
+    ('EC', 'kk', 'Эквадор'),
+    ('EE', 'en', 'Estonia'),
+    ('EE', 'ru', 'Эстония'),
+    ('EE', 'kk', 'Эстония'),
+    ('EG', 'en', 'Egypt'),
+    ('EG', 'ru', 'Египет'),
+    ('EG', 'kk', 'Мысыр'),
+    ('EH', 'en', 'Western Sahara'),
+    ('EH', 'ru', 'Западная Сахара'),
+    ('EH', 'kk', 'Батыс Сахара'),
+    ('ER', 'en', 'Eritrea'),
+    ('ER', 'ru', 'Эритрея'),
+    ('ER', 'kk', 'Эритрея'),
+    ('ES', 'en', 'Spain'),
+    ('ES', 'ru', 'Испания'),
+    ('ES', 'kk', 'Испания'),
+    ('ET', 'en', 'Ethiopia'),
+    ('ET', 'ru', 'Эфиопия'),
+    ('ET', 'kk', 'Эфиопия'),
+    ('FI', 'en', 'Finland'),
+    ('FI', 'ru', 'Финляндия'),
+    ('FI', 'kk', 'Финляндия'),
+    ('FJ', 'en', 'Fiji'),
+    ('FJ', 'ru', 'Фиджи'),
+    ('FJ', 'kk', 'Фиджи'),
+    ('FK', 'en', 'Falkland Islands'),
+    ('FK', 'ru', 'Фолклендские о-ва'),
+    ('FK', 'kk', 'Фолкленд аралдары'),
+    ('FM', 'en', 'Micronesia'),
+    ('FM', 'ru', 'Федеративные Штаты Микронезии'),
+    ('FM', 'kk', 'Микронезия'),
+    ('FO', 'en', 'Faroe Islands'),
+    ('FO', 'ru', 'Фарерские о-ва'),
+    ('FO', 'kk', 'Фарер аралдары'),
+    ('FR', 'en', 'France'),
+    ('FR', 'ru', 'Франция'),
+    ('FR', 'kk', 'Франция'),
+    ('GA', 'en', 'Gabon'),
+    ('GA', 'ru', 'Габон'),
+    ('GA', 'kk', 'Габон'),
+    ('GB', 'en', 'United Kingdom'),
+    ('GB', 'ru', 'Великобритания'),
+    ('GB', 'kk', 'Ұлыбритания'),
+    ('GD', 'en', 'Grenada'),
+    ('GD', 'ru', 'Гренада'),
+    ('GD', 'kk', 'Гренада'),
+    ('GE', 'en', 'Georgia'),
+    ('GE', 'ru', 'Грузия'),
+    ('GE', 'kk', 'Грузия'),
+    ('GF', 'en', 'French Guiana'),
+    ('GF', 'ru', 'Французская Гвиана'),
+    ('GF', 'kk', 'Француз Гвианасы'),
+    ('GG', 'en', 'Guernsey'),
+    ('GG', 'ru', 'Гернси'),
+    ('GG', 'kk', 'Гернси'),
+    ('GH', 'en', 'Ghana'),
+    ('GH', 'ru', 'Гана'),
+    ('GH', 'kk', 'Гана'),
+    ('GI', 'en', 'Gibraltar'),
+    ('GI', 'ru', 'Гибралтар'),
+    ('GI', 'kk', 'Гибралтар'),
+    ('GL', 'en', 'Greenland'),
+    ('GL', 'ru', 'Гренландия'),
+    ('GL', 'kk', 'Гренландия'),
+    ('GM', 'en', 'Gambia'),
+    ('GM', 'ru', 'Гамбия'),
+    ('GM', 'kk', 'Гамбия'),
+    ('GN', 'en', 'Guinea'),
+    ('GN', 'ru', 'Гвинея'),
+    ('GN', 'kk', 'Гвинея'),
+    ('GP', 'en', 'Guadeloupe'),
+    ('GP', 'ru', 'Гваделупа'),
+    ('GP', 'kk', 'Гваделупа'),
+    ('GQ', 'en', 'Equatorial Guinea'),
+    ('GQ', 'ru', 'Экваториальная Гвинея'),
+    ('GQ', 'kk', 'Экваторлық Гвинея'),
+    ('GR', 'en', 'Greece'),
+    ('GR', 'ru', 'Греция'),
+    ('GR', 'kk', 'Грекия'),
+    ('GS', 'en', 'South Georgia & South Sandwich Islands'),
+    ('GS', 'ru', 'Южная Георгия и Южные Сандвичевы о-ва'),
+    ('GS', 'kk', 'Оңтүстік Георгия және Оңтүстік Сандвич аралдары'),
+    ('GT', 'en', 'Guatemala'),
+    ('GT', 'ru', 'Гватемала'),
+    ('GT', 'kk', 'Гватемала'),
+    ('GU', 'en', 'Guam'),
+    ('GU', 'ru', 'Гуам'),
+    ('GU', 'kk', 'Гуам'),
+    ('GW', 'en', 'Guinea-Bissau'),
+    ('GW', 'ru', 'Гвинея-Бисау'),
+    ('GW', 'kk', 'Гвинея-Бисау'),
+    ('GY', 'en', 'Guyana'),
+    ('GY', 'ru', 'Гайана'),
+    ('GY', 'kk', 'Гайана'),
+    ('HK', 'en', 'Hong Kong SAR China'),
+    ('HK', 'ru', 'Гонконг (САР)'),
+    ('HK', 'kk', 'Сянган АӘА'),
+    ('HM', 'en', 'Heard & McDonald Islands'),
+    ('HM', 'ru', 'о-ва Херд и Макдональд'),
+    ('HM', 'kk', 'Херд аралы және Макдональд аралдары'),
+    ('HN', 'en', 'Honduras'),
+    ('HN', 'ru', 'Гондурас'),
+    ('HN', 'kk', 'Гондурас'),
+    ('HR', 'en', 'Croatia'),
+    ('HR', 'ru', 'Хорватия'),
+    ('HR', 'kk', 'Хорватия'),
+    ('HT', 'en', 'Haiti'),
+    ('HT', 'ru', 'Гаити'),
+    ('HT', 'kk', 'Гаити'),
+    ('HU', 'en', 'Hungary'),
+    ('HU', 'ru', 'Венгрия'),
+    ('HU', 'kk', 'Венгрия'),
+    ('ID', 'en', 'Indonesia'),
+    ('ID', 'ru', 'Индонезия'),
+    ('ID', 'kk', 'Индонезия'),
+    ('IE', 'en', 'Ireland'),
+    ('IE', 'ru', 'Ирландия'),
+    ('IE', 'kk', 'Ирландия'),
+    ('IL', 'en', 'Israel'),
+    ('IL', 'ru', 'Израиль'),
+    ('IL', 'kk', 'Израиль'),
+    ('IM', 'en', 'Isle of Man'),
+    ('IM', 'ru', 'о-в Мэн'),
+    ('IM', 'kk', 'Мэн аралы'),
+    ('IN', 'en', 'India'),
+    ('IN', 'ru', 'Индия'),
+    ('IN', 'kk', 'Үндістан'),
+    ('IO', 'en', 'British Indian Ocean Territory'),
+    ('IO', 'ru', 'Британская территория в Индийском океане'),
+    ('IO', 'kk', 'Үнді мұхитындағы Британ аймағы'),
+    ('IQ', 'en', 'Iraq'),
+    ('IQ', 'ru', 'Ирак'),
+    ('IQ', 'kk', 'Ирак'),
+    ('IR', 'en', 'Iran'),
+    ('IR', 'ru', 'Иран'),
+    ('IR', 'kk', 'Иран'),
+    ('IS', 'en', 'Iceland'),
+    ('IS', 'ru', 'Исландия'),
+    ('IS', 'kk', 'Исландия'),
+    ('IT', 'en', 'Italy'),
+    ('IT', 'ru', 'Италия'),
+    ('IT', 'kk', 'Италия'),
+    ('JE', 'en', 'Jersey'),
+    ('JE', 'ru', 'Джерси'),
+    ('JE', 'kk', 'Джерси'),
+    ('JM', 'en', 'Jamaica'),
+    ('JM', 'ru', 'Ямайка'),
+    ('JM', 'kk', 'Ямайка'),
+    ('JO', 'en', 'Jordan'),
+    ('JO', 'ru', 'Иордания'),
+    ('JO', 'kk', 'Иордания'),
+    ('JP', 'en', 'Japan'),
+    ('JP', 'ru', 'Япония'),
+    ('JP', 'kk', 'Жапония'),
+    ('KE', 'en', 'Kenya'),
+    ('KE', 'ru', 'Кения'),
+    ('KE', 'kk', 'Кения'),
+    ('KG', 'en', 'Kyrgyzstan'),
+    ('KG', 'ru', 'Киргизия'),
+    ('KG', 'kk', 'Қырғызстан'),
+    ('KH', 'en', 'Cambodia'),
+    ('KH', 'ru', 'Камбоджа'),
+    ('KH', 'kk', 'Камбоджа'),
+    ('KI', 'en', 'Kiribati'),
+    ('KI', 'ru', 'Кирибати'),
+    ('KI', 'kk', 'Кирибати'),
+    ('KM', 'en', 'Comoros'),
+    ('KM', 'ru', 'Коморы'),
+    ('KM', 'kk', 'Комор аралдары'),
+    ('KN', 'en', 'St. Kitts & Nevis'),
+    ('KN', 'ru', 'Сент-Китс и Невис'),
+    ('KN', 'kk', 'Сент-Китс және Невис'),
+    ('KP', 'en', 'North Korea'),
+    ('KP', 'ru', 'КНДР'),
+    ('KP', 'kk', 'Солтүстік Корея'),
+    ('KR', 'en', 'South Korea'),
+    ('KR', 'ru', 'Республика Корея'),
+    ('KR', 'kk', 'Оңтүстік Корея'),
+    ('KW', 'en', 'Kuwait'),
+    ('KW', 'ru', 'Кувейт'),
+    ('KW', 'kk', 'Кувейт'),
+    ('KY', 'en', 'Cayman Islands'),
+    ('KY', 'ru', 'Каймановы о-ва'),
+    ('KY', 'kk', 'Кайман аралдары'),
+    ('KZ', 'en', 'Kazakhstan'),
+    ('KZ', 'ru', 'Казахстан'),
+    ('KZ', 'kk', 'Қазақстан'),
+    ('LA', 'en', 'Laos'),
+    ('LA', 'ru', 'Лаос'),
+    ('LA', 'kk', 'Лаос'),
+    ('LB', 'en', 'Lebanon'),
+    ('LB', 'ru', 'Ливан'),
+    ('LB', 'kk', 'Ливан'),
+    ('LC', 'en', 'St. Lucia'),
+    ('LC', 'ru', 'Сент-Люсия'),
+    ('LC', 'kk', 'Сент-Люсия'),
+    ('LI', 'en', 'Liechtenstein'),
+    ('LI', 'ru', 'Лихтенштейн'),
+    ('LI', 'kk', 'Лихтенштейн'),
+    ('LK', 'en', 'Sri Lanka'),
+    ('LK', 'ru', 'Шри-Ланка'),
+    ('LK', 'kk', 'Шри-Ланка'),
+    ('LR', 'en', 'Liberia'),
+    ('LR', 'ru', 'Либерия'),
+    ('LR', 'kk', 'Либерия'),
+    ('LS', 'en', 'Lesotho'),
+    ('LS', 'ru', 'Лесото'),
+    ('LS', 'kk', 'Лесото'),
+    ('LT', 'en', 'Lithuania'),
+    ('LT', 'ru', 'Литва'),
+    ('LT', 'kk', 'Литва'),
+    ('LU', 'en', 'Luxembourg'),
+    ('LU', 'ru', 'Люксембург'),
+    ('LU', 'kk', 'Люксембург'),
+    ('LV', 'en', 'Latvia'),
+    ('LV', 'ru', 'Латвия'),
+    ('LV', 'kk', 'Латвия'),
+    ('LY', 'en', 'Libya'),
+    ('LY', 'ru', 'Ливия'),
+    ('LY', 'kk', 'Ливия'),
+    ('MA', 'en', 'Morocco'),
+    ('MA', 'ru', 'Марокко'),
+    ('MA', 'kk', 'Марокко'),
+    ('MC', 'en', 'Monaco'),
+    ('MC', 'ru', 'Монако'),
+    ('MC', 'kk', 'Монако'),
+    ('MD', 'en', 'Moldova'),
+    ('MD', 'ru', 'Молдова'),
+    ('MD', 'kk', 'Молдова'),
+    ('ME', 'en', 'Montenegro'),
+    ('ME', 'ru', 'Черногория'),
+    ('ME', 'kk', 'Черногория'),
+    ('MF', 'en', 'St. Martin'),
+    ('MF', 'ru', 'Сен-Мартен'),
+    ('MF', 'kk', 'Сен-Мартен'),
+    ('MG', 'en', 'Madagascar'),
+    ('MG', 'ru', 'Мадагаскар'),
+    ('MG', 'kk', 'Мадагаскар'),
+    ('MH', 'en', 'Marshall Islands'),
+    ('MH', 'ru', 'Маршалловы Острова'),
+    ('MH', 'kk', 'Маршалл аралдары'),
+    ('MK', 'en', 'Macedonia'),
+    ('MK', 'ru', 'Македония'),
+    ('MK', 'kk', 'Македония'),
+    ('ML', 'en', 'Mali'),
+    ('ML', 'ru', 'Мали'),
+    ('ML', 'kk', 'Мали'),
+    ('MM', 'en', 'Myanmar (Burma)'),
+    ('MM', 'ru', 'Мьянма (Бирма)'),
+    ('MM', 'kk', 'Мьянма (Бирма)'),
+    ('MN', 'en', 'Mongolia'),
+    ('MN', 'ru', 'Монголия'),
+    ('MN', 'kk', 'Моңғолия'),
+    ('MO', 'en', 'Macau SAR China'),
+    ('MO', 'ru', 'Макао (САР)'),
+    ('MO', 'kk', 'Макао АӘА'),
+    ('MP', 'en', 'Northern Mariana Islands'),
+    ('MP', 'ru', 'Северные Марианские о-ва'),
+    ('MP', 'kk', 'Солтүстік Мариана аралдары'),
+    ('MQ', 'en', 'Martinique'),
+    ('MQ', 'ru', 'Мартиника'),
+    ('MQ', 'kk', 'Мартиника'),
+    ('MR', 'en', 'Mauritania'),
+    ('MR', 'ru', 'Мавритания'),
+    ('MR', 'kk', 'Мавритания'),
+    ('MS', 'en', 'Montserrat'),
+    ('MS', 'ru', 'Монтсеррат'),
+    ('MS', 'kk', 'Монтсеррат'),
+    ('MT', 'en', 'Malta'),
+    ('MT', 'ru', 'Мальта'),
+    ('MT', 'kk', 'Мальта'),
+    ('MU', 'en', 'Mauritius'),
+    ('MU', 'ru', 'Маврикий'),
+    ('MU', 'kk', 'Маврикий'),
+    ('MV', 'en', 'Maldives'),
+    ('MV', 'ru', 'Мальдивы'),
+    ('MV', 'kk', 'Мальдив аралдары'),
+    ('MW', 'en', 'Malawi'),
+    ('MW', 'ru', 'Малави'),
+    ('MW', 'kk', 'Малави'),
+    ('MX', 'en', 'Mexico'),
+    ('MX', 'ru', 'Мексика'),
+    ('MX', 'kk', 'Мексика'),
+    ('MY', 'en', 'Malaysia'),
+    ('MY', 'ru', 'Малайзия'),
+    ('MY', 'kk', 'Малайзия'),
+    ('MZ', 'en', 'Mozambique'),
+    ('MZ', 'ru', 'Мозамбик'),
+    ('MZ', 'kk', 'Мозамбик'),
+    ('NA', 'en', 'Namibia'),
+    ('NA', 'ru', 'Намибия'),
+    ('NA', 'kk', 'Намибия'),
+    ('NC', 'en', 'New Caledonia'),
+    ('NC', 'ru', 'Новая Каледония'),
+    ('NC', 'kk', 'Жаңа Каледония'),
+    ('NE', 'en', 'Niger'),
+    ('NE', 'ru', 'Нигер'),
+    ('NE', 'kk', 'Нигер'),
+    ('NF', 'en', 'Norfolk Island'),
+    ('NF', 'ru', 'о-в Норфолк'),
+    ('NF', 'kk', 'Норфолк аралы'),
+    ('NG', 'en', 'Nigeria'),
+    ('NG', 'ru', 'Нигерия'),
+    ('NG', 'kk', 'Нигерия'),
+    ('NI', 'en', 'Nicaragua'),
+    ('NI', 'ru', 'Никарагуа'),
+    ('NI', 'kk', 'Никарагуа'),
+    ('NL', 'en', 'Netherlands'),
+    ('NL', 'ru', 'Нидерланды'),
+    ('NL', 'kk', 'Нидерланд'),
+    ('NO', 'en', 'Norway'),
+    ('NO', 'ru', 'Норвегия'),
+    ('NO', 'kk', 'Норвегия'),
+    ('NP', 'en', 'Nepal'),
+    ('NP', 'ru', 'Непал'),
+    ('NP', 'kk', 'Непал'),
+    ('NR', 'en', 'Nauru'),
+    ('NR', 'ru', 'Науру'),
+    ('NR', 'kk', 'Науру'),
+    ('NU', 'en', 'Niue'),
+    ('NU', 'ru', 'Ниуэ'),
+    ('NU', 'kk', 'Ниуэ'),
+    ('NZ', 'en', 'New Zealand'),
+    ('NZ', 'ru', 'Новая Зеландия'),
+    ('NZ', 'kk', 'Жаңа Зеландия'),
+    ('OM', 'en', 'Oman'),
+    ('OM', 'ru', 'Оман'),
+    ('OM', 'kk', 'Оман'),
+    ('PA', 'en', 'Panama'),
+    ('PA', 'ru', 'Панама'),
+    ('PA', 'kk', 'Панама'),
+    ('PE', 'en', 'Peru'),
+    ('PE', 'ru', 'Перу'),
+    ('PE', 'kk', 'Перу'),
+    ('PF', 'en', 'French Polynesia'),
+    ('PF', 'ru', 'Французская Полинезия'),
+    ('PF', 'kk', 'Француз Полинезиясы'),
+    ('PG', 'en', 'Papua New Guinea'),
+    ('PG', 'ru', 'Папуа — Новая Гвинея'),
+    ('PG', 'kk', 'Папуа — Жаңа Гвинея'),
+    ('PH', 'en', 'Philippines'),
+    ('PH', 'ru', 'Филиппины'),
+    ('PH', 'kk', 'Филиппин аралдары'),
+    ('PK', 'en', 'Pakistan'),
+    ('PK', 'ru', 'Пакистан'),
+    ('PK', 'kk', 'Пәкістан'),
+    ('PL', 'en', 'Poland'),
+    ('PL', 'ru', 'Польша'),
+    ('PL', 'kk', 'Польша'),
+    ('PM', 'en', 'St. Pierre & Miquelon'),
+    ('PM', 'ru', 'Сен-Пьер и Микелон'),
+    ('PM', 'kk', 'Сен-Пьер және Микелон'),
+    ('PN', 'en', 'Pitcairn Islands'),
+    ('PN', 'ru', 'острова Питкэрн'),
+    ('PN', 'kk', 'Питкэрн аралдары'),
+    ('PR', 'en', 'Puerto Rico'),
+    ('PR', 'ru', 'Пуэрто-Рико'),
+    ('PR', 'kk', 'Пуэрто-Рико'),
+    ('PS', 'en', 'Palestinian Territories'),
+    ('PS', 'ru', 'Палестинские территории'),
+    ('PS', 'kk', 'Палестина аймақтары'),
+    ('PT', 'en', 'Portugal'),
+    ('PT', 'ru', 'Португалия'),
+    ('PT', 'kk', 'Португалия'),
+    ('PW', 'en', 'Palau'),
+    ('PW', 'ru', 'Палау'),
+    ('PW', 'kk', 'Палау'),
+    ('PY', 'en', 'Paraguay'),
+    ('PY', 'ru', 'Парагвай'),
+    ('PY', 'kk', 'Парагвай'),
+    ('QA', 'en', 'Qatar'),
+    ('QA', 'ru', 'Катар'),
+    ('QA', 'kk', 'Катар'),
+    ('RE', 'en', 'Réunion'),
+    ('RE', 'ru', 'Реюньон'),
+    ('RE', 'kk', 'Реюньон'),
+    ('RO', 'en', 'Romania'),
+    ('RO', 'ru', 'Румыния'),
+    ('RO', 'kk', 'Румыния'),
+    ('RS', 'en', 'Serbia'),
+    ('RS', 'ru', 'Сербия'),
+    ('RS', 'kk', 'Сербия'),
+    ('RU', 'en', 'Russia'),
+    ('RU', 'ru', 'Россия'),
+    ('RU', 'kk', 'Ресей'),
+    ('RW', 'en', 'Rwanda'),
+    ('RW', 'ru', 'Руанда'),
+    ('RW', 'kk', 'Руанда'),
+    ('SA', 'en', 'Saudi Arabia'),
+    ('SA', 'ru', 'Саудовская Аравия'),
+    ('SA', 'kk', 'Сауд Арабиясы'),
+    ('SB', 'en', 'Solomon Islands'),
+    ('SB', 'ru', 'Соломоновы Острова'),
+    ('SB', 'kk', 'Соломон аралдары'),
+    ('SC', 'en', 'Seychelles'),
+    ('SC', 'ru', 'Сейшельские Острова'),
+    ('SC', 'kk', 'Сейшель аралдары'),
+    ('SD', 'en', 'Sudan'),
+    ('SD', 'ru', 'Судан'),
+    ('SD', 'kk', 'Судан'),
+    ('SE', 'en', 'Sweden'),
+    ('SE', 'ru', 'Швеция'),
+    ('SE', 'kk', 'Швеция'),
+    ('SG', 'en', 'Singapore'),
+    ('SG', 'ru', 'Сингапур'),
+    ('SG', 'kk', 'Сингапур'),
+    ('SH', 'en', 'St. Helena'),
+    ('SH', 'ru', 'о-в Св. Елены'),
+    ('SH', 'kk', 'Әулие Елена аралы'),
+    ('SI', 'en', 'Slovenia'),
+    ('SI', 'ru', 'Словения'),
+    ('SI', 'kk', 'Словения'),
+    ('SJ', 'en', 'Svalbard & Jan Mayen'),
+    ('SJ', 'ru', 'Шпицберген и Ян-Майен'),
+    ('SJ', 'kk', 'Шпицберген және Ян-Майен'),
+    ('SK', 'en', 'Slovakia'),
+    ('SK', 'ru', 'Словакия'),
+    ('SK', 'kk', 'Словакия'),
+    ('SL', 'en', 'Sierra Leone'),
+    ('SL', 'ru', 'Сьерра-Леоне'),
+    ('SL', 'kk', 'Сьерра-Леоне'),
+    ('SM', 'en', 'San Marino'),
+    ('SM', 'ru', 'Сан-Марино'),
+    ('SM', 'kk', 'Сан-Марино'),
+    ('SN', 'en', 'Senegal'),
+    ('SN', 'ru', 'Сенегал'),
+    ('SN', 'kk', 'Сенегал'),
+    ('SO', 'en', 'Somalia'),
+    ('SO', 'ru', 'Сомали'),
+    ('SO', 'kk', 'Сомали'),
+    ('SR', 'en', 'Suriname'),
+    ('SR', 'ru', 'Суринам'),
+    ('SR', 'kk', 'Суринам'),
+    ('SS', 'en', 'South Sudan'),
+    ('SS', 'ru', 'Южный Судан'),
+    ('SS', 'kk', 'Оңтүстік Судан'),
+    ('ST', 'en', 'São Tomé & Príncipe'),
+    ('ST', 'ru', 'Сан-Томе и Принсипи'),
+    ('ST', 'kk', 'Сан-Томе және Принсипи'),
+    ('SV', 'en', 'El Salvador'),
+    ('SV', 'ru', 'Сальвадор'),
+    ('SV', 'kk', 'Сальвадор'),
+    ('SX', 'en', 'Sint Maarten'),
+    ('SX', 'ru', 'Синт-Мартен'),
+    ('SX', 'kk', 'Синт-Мартен'),
+    ('SY', 'en', 'Syria'),
+    ('SY', 'ru', 'Сирия'),
+    ('SY', 'kk', 'Сирия'),
+    ('SZ', 'en', 'Swaziland'),
+    ('SZ', 'ru', 'Свазиленд'),
+    ('SZ', 'kk', 'Свазиленд'),
+    ('TC', 'en', 'Turks & Caicos Islands'),
+    ('TC', 'ru', 'о-ва Тёркс и Кайкос'),
+    ('TC', 'kk', 'Теркс және Кайкос аралдары'),
+    ('TD', 'en', 'Chad'),
+    ('TD', 'ru', 'Чад'),
+    ('TD', 'kk', 'Чад'),
+    ('TF', 'en', 'French Southern Territories'),
+    ('TF', 'ru', 'Французские Южные территории'),
+    ('TF', 'kk', 'Францияның оңтүстік аймақтары'),
+    ('TG', 'en', 'Togo'),
+    ('TG', 'ru', 'Того'),
+    ('TG', 'kk', 'Того'),
+    ('TH', 'en', 'Thailand'),
+    ('TH', 'ru', 'Таиланд'),
+    ('TH', 'kk', 'Тайланд'),
+    ('TJ', 'en', 'Tajikistan'),
+    ('TJ', 'ru', 'Таджикистан'),
+    ('TJ', 'kk', 'Тәжікстан'),
+    ('TK', 'en', 'Tokelau'),
+    ('TK', 'ru', 'Токелау'),
+    ('TK', 'kk', 'Токелау'),
+    ('TL', 'en', 'Timor-Leste'),
+    ('TL', 'ru', 'Восточный Тимор'),
+    ('TL', 'kk', 'Тимор-Лесте'),
+    ('TM', 'en', 'Turkmenistan'),
+    ('TM', 'ru', 'Туркменистан'),
+    ('TM', 'kk', 'Түрікменстан'),
+    ('TN', 'en', 'Tunisia'),
+    ('TN', 'ru', 'Тунис'),
+    ('TN', 'kk', 'Тунис'),
+    ('TO', 'en', 'Tonga'),
+    ('TO', 'ru', 'Тонга'),
+    ('TO', 'kk', 'Тонга'),
+    ('TR', 'en', 'Turkey'),
+    ('TR', 'ru', 'Турция'),
+    ('TR', 'kk', 'Түркия'),
+    ('TT', 'en', 'Trinidad & Tobago'),
+    ('TT', 'ru', 'Тринидад и Тобаго'),
+    ('TT', 'kk', 'Тринидад және Тобаго'),
+    ('TV', 'en', 'Tuvalu'),
+    ('TV', 'ru', 'Тувалу'),
+    ('TV', 'kk', 'Тувалу'),
+    ('TW', 'en', 'Taiwan'),
+    ('TW', 'ru', 'Тайвань'),
+    ('TW', 'kk', 'Тайвань'),
+    ('TZ', 'en', 'Tanzania'),
+    ('TZ', 'ru', 'Танзания'),
+    ('TZ', 'kk', 'Танзания'),
+    ('UA', 'en', 'Ukraine'),
+    ('UA', 'ru', 'Украина'),
+    ('UA', 'kk', 'Украина'),
+    ('UG', 'en', 'Uganda'),
+    ('UG', 'ru', 'Уганда'),
+    ('UG', 'kk', 'Уганда'),
+    ('UM', 'en', 'U.S. Outlying Islands'),
+    ('UM', 'ru', 'Внешние малые о-ва (США)'),
+    ('UM', 'kk', 'АҚШ-тың сыртқы кіші аралдары'),
+    ('US', 'en', 'United States'),
+    ('US', 'ru', 'Соединенные Штаты'),
+    ('US', 'kk', 'Америка Құрама Штаттары'),
+    ('UY', 'en', 'Uruguay'),
+    ('UY', 'ru', 'Уругвай'),
+    ('UY', 'kk', 'Уругвай'),
+    ('UZ', 'en', 'Uzbekistan'),
+    ('UZ', 'ru', 'Узбекистан'),
+    ('UZ', 'kk', 'Өзбекстан'),
+    ('VA', 'en', 'Vatican City'),
+    ('VA', 'ru', 'Ватикан'),
+    ('VA', 'kk', 'Ватикан'),
+    ('VC', 'en', 'St. Vincent & Grenadines'),
+    ('VC', 'ru', 'Сент-Винсент и Гренадины'),
+    ('VC', 'kk', 'Сент-Винсент және Гренадин аралдары'),
+    ('VE', 'en', 'Venezuela'),
+    ('VE', 'ru', 'Венесуэла'),
+    ('VE', 'kk', 'Венесуэла'),
+    ('VG', 'en', 'British Virgin Islands'),
+    ('VG', 'ru', 'Виргинские о-ва (Британские)'),
+    ('VG', 'kk', 'Британдық Виргин аралдары'),
+    ('VI', 'en', 'U.S. Virgin Islands'),
+    ('VI', 'ru', 'Виргинские о-ва (США)'),
+    ('VI', 'kk', 'АҚШ-тың Виргин аралдары'),
+    ('VN', 'en', 'Vietnam'),
+    ('VN', 'ru', 'Вьетнам'),
+    ('VN', 'kk', 'Вьетнам'),
+    ('VU', 'en', 'Vanuatu'),
+    ('VU', 'ru', 'Вануату'),
+    ('VU', 'kk', 'Вануату'),
+    ('WF', 'en', 'Wallis & Futuna'),
+    ('WF', 'ru', 'Уоллис и Футуна'),
+    ('WF', 'kk', 'Уоллис және Футуна'),
+    ('WS', 'en', 'Samoa'),
+    ('WS', 'ru', 'Самоа'),
+    ('WS', 'kk', 'Самоа'),
+    ('YE', 'en', 'Yemen'),
+    ('YE', 'ru', 'Йемен'),
+    ('YE', 'kk', 'Йемен'),
+    ('YT', 'en', 'Mayotte'),
+    ('YT', 'ru', 'Майотта'),
+    ('YT', 'kk', 'Майотта'),
+    ('ZA', 'en', 'South Africa'),
+    ('ZA', 'ru', 'Южно-Африканская Республика'),
+    ('ZA', 'kk', 'Оңтүстік Африка Республикасы'),
+    ('ZM', 'en', 'Zambia'),
+    ('ZM', 'ru', 'Замбия'),
+    ('ZM', 'kk', 'Замбия'),
+    ('ZW', 'en', 'Zimbabwe'),
+    ('ZW', 'ru', 'Зимбабве'),
+    ('ZW', 'kk', 'Зимбабве');
+
+INSERT INTO country_aliases (alias, country_code) VALUES
+    ('USA', 'US'),
+    ('United States of America', 'US'),
+    ('America', 'US'),
+    ('США', 'US'),
+    ('UK', 'GB'),
+    ('Great Britain', 'GB'),
+    ('Britain', 'GB'),
+    ('England', 'GB'),
+    ('Scotland', 'GB'),
+    ('Wales', 'GB'),
+    ('Northern Ireland', 'GB'),
+    ('Russian Federation', 'RU'),
+    ('РФ', 'RU'),
+    ('Российская Федерация', 'RU'),
+    ('Republic of Kazakhstan', 'KZ'),
+    ('Республика Казахстан', 'KZ'),
+    ('Қазақстан Республикасы', 'KZ'),
+    ('UAE', 'AE'),
+    ('Emirates', 'AE'),
+    ('Объединенные Арабские Эмираты', 'AE'),
+    ('Czech Republic', 'CZ'),
+    ('Holland', 'NL'),
+    ('South Korea', 'KR'),
+    ('Korea', 'KR'),
+    ('North Korea', 'KP'),
+    ('Hong Kong', 'HK'),
+    ('Macau', 'MO'),
+    ('Türkiye', 'TR'),
+    ('Ivory Coast', 'CI'),
+    ('Swaziland', 'SZ'),
+    ('Burma', 'MM'),
+    ('Vatican', 'VA'),
+    ('Democratic Republic of the Congo', 'CD'),
+    ('Republic of the Congo', 'CG'),
+    ('Taiwan, Province of China', 'TW');
+
+INSERT INTO industries (code, parent_code, name) VALUES
+    ('technology', NULL, 'Technology'),
+    ('technology.software', 'technology', 'Software'),
+    ('technology.hardware', 'technology', 'Hardware & Semiconductors'),
+    ('technology.internet', 'technology', 'Internet & E-commerce'),
+    ('technology.telecom', 'technology', 'Telecom'),
+    ('finance', NULL, 'Finance & Investments'),
+    ('finance.banking', 'finance', 'Banking'),
+    ('finance.investments', 'finance', 'Investments & Asset Management'),
+    ('finance.insurance', 'finance', 'Insurance'),
+    ('finance.fintech', 'finance', 'Fintech'),
+    ('energy', NULL, 'Energy'),
+    ('energy.oil-gas', 'energy', 'Oil & Gas'),
+    ('energy.power', 'energy', 'Power & Utilities'),
+    ('energy.renewables', 'energy', 'Renewable Energy'),
+    ('materials', NULL, 'Materials'),
+    ('materials.mining', 'materials', 'Metals & Mining'),
+    ('materials.chemicals', 'materials', 'Chemicals'),
+    ('real-estate', NULL, 'Real Estate'),
+    ('consumer', NULL, 'Consumer'),
+    ('consumer.retail', 'consumer', 'Retail'),
+    ('consumer.food-beverage', 'consumer', 'Food & Beverage'),
+    ('consumer.fashion', 'consumer', 'Fashion & Luxury'),
+    ('consumer.automotive', 'consumer', 'Automotive'),
+    ('healthcare', NULL, 'Healthcare'),
+    ('healthcare.pharma', 'healthcare', 'Pharmaceuticals'),
+    ('healthcare.medical', 'healthcare', 'Medical Devices & Services'),
+    ('industrials', NULL, 'Industrials'),
+    ('industrials.construction', 'industrials', 'Construction'),
+    ('industrials.logistics', 'industrials', 'Logistics'),
+    ('industrials.manufacturing', 'industrials', 'Manufacturing'),
+    ('media', NULL, 'Media & Entertainment'),
+    ('media.broadcasting', 'media', 'Broadcasting'),
+    ('media.film-music', 'media', 'Film & Music'),
+    ('media.gaming', 'media', 'Gaming'),
+    ('agriculture', NULL, 'Agriculture'),
+    ('diversified', NULL, 'Diversified');
+
+-- Lookup keys are lower-cased codes and names in every language. Keys
+-- that would point to more than one country or industry are left out.
+CREATE OR REPLACE VIEW country_lookup AS
+SELECT key, min(code) AS code
+FROM (
+    SELECT lower(code) AS key, code FROM countries
+    UNION ALL SELECT lower(alpha3), code FROM countries
+    UNION ALL SELECT lower(name), code FROM countries
+    UNION ALL SELECT lower(name), country_code FROM country_names
+    UNION ALL SELECT lower(alias), country_code FROM country_aliases
+) keys
+GROUP BY key
+HAVING count(DISTINCT code) = 1;
+
+CREATE OR REPLACE VIEW industry_lookup AS
+SELECT key, min(code) AS code
+FROM (
+    SELECT lower(code) AS key, code FROM industries
+    UNION ALL SELECT lower(name), code FROM industries
+) keys
+GROUP BY key
+HAVING count(DISTINCT code) = 1;
+
+ALTER TABLE millionaires
+    ADD COLUMN IF NOT EXISTS country_code CHAR(2) REFERENCES countries (code),
+    ADD COLUMN IF NOT EXISTS industry_code VARCHAR(100) REFERENCES industries (code);
+
+CREATE INDEX IF NOT EXISTS millionaires_country_code_idx ON millionaires (country_code);
+CREATE INDEX IF NOT EXISTS millionaires_industry_code_idx ON millionaires (industry_code varchar_pattern_ops);
+
+UPDATE millionaires m
+SET country_code = l.code
+FROM country_lookup l
+WHERE l.key = lower(trim(m.country));
+
+UPDATE millionaires m
+SET industry_code = l.code
+FROM industry_lookup l
+WHERE l.key = lower(trim(m.industry));
+
+-- Free-text values no code was found for; see `wealthlist vocabulary unmatched`.
+CREATE OR REPLACE VIEW unmatched_vocabulary AS
+SELECT 'country' AS kind, trim(country) AS value, count(*) AS millionaires
+FROM millionaires
+WHERE country_code IS NULL AND trim(coalesce(country, '')) <> ''
+GROUP BY trim(country)
+UNION ALL
+SELECT 'industry', trim(industry), count(*)
+FROM millionaires
+WHERE industry_code IS NULL AND trim(coalesce(industry, '')) <> ''
+GROUP BY trim(industry);
@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS convert_money(NUMERIC, CHAR(3), CHAR(3), DATE);
+DROP FUNCTION IF EXISTS euro_rate(CHAR(3), DATE);
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE net_worth_history DROP COLUMN IF EXISTS currency;
+ALTER TABLE millionaires DROP COLUMN IF EXISTS net_worth_as_of, DROP COLUMN IF EXISTS net_worth_currency;
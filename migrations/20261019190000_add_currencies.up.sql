@@ -0,0 +1,41 @@
+-- Valuations carry their currency and the day they were made.
+ALTER TABLE millionaires
+    ADD COLUMN IF NOT EXISTS net_worth_currency CHAR(3) NOT NULL DEFAULT 'USD' CHECK (net_worth_currency ~ '^[A-Z]{3}$'),
+    ADD COLUMN IF NOT EXISTS net_worth_as_of DATE;
+
+UPDATE millionaires SET net_worth_as_of = updated_at::date WHERE net_worth_as_of IS NULL;
+
+ALTER TABLE millionaires
+    ALTER COLUMN net_worth_as_of SET DEFAULT CURRENT_DATE,
+    ALTER COLUMN net_worth_as_of SET NOT NULL;
+
+ALTER TABLE net_worth_history
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Rates are units of a currency per euro, the way the ECB publishes them.
+-- The euro itself is always 1 and is not stored.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency CHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$' AND currency <> 'EUR'),
+    rate_date DATE NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (currency, rate_date)
+);
+
+-- euro_rate is the rate in effect on a day: the latest one published on or
+-- before it. It is NULL when there is none.
+CREATE OR REPLACE FUNCTION euro_rate(CHAR(3), DATE) RETURNS NUMERIC
+LANGUAGE sql STABLE AS $$
+    SELECT CASE WHEN $1 = 'EUR' THEN 1 ELSE (
+        SELECT rate FROM exchange_rates
+        WHERE currency = $1 AND rate_date <= $2
+        ORDER BY rate_date DESC
+        LIMIT 1
+    ) END
+$$;
+
+-- convert_money converts an amount at the rates in effect on a day; it is
+-- NULL when either rate is missing.
+CREATE OR REPLACE FUNCTION convert_money(NUMERIC, CHAR(3), CHAR(3), DATE) RETURNS NUMERIC
+LANGUAGE sql STABLE AS $$
+    SELECT CASE WHEN $2 = $3 THEN $1 ELSE $1 / euro_rate($2, $4) * euro_rate($3, $4) END
+$$;
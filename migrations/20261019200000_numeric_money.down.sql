@@ -0,0 +1,4 @@
+ALTER TABLE exchange_rates ALTER COLUMN rate TYPE NUMERIC(20, 10);
+ALTER TABLE holdings ALTER COLUMN value TYPE BIGINT USING round(value);
+ALTER TABLE net_worth_history ALTER COLUMN net_worth TYPE BIGINT USING round(net_worth);
+ALTER TABLE millionaires ALTER COLUMN net_worth TYPE BIGINT USING round(net_worth);
@@ -0,0 +1,6 @@
+-- Money is NUMERIC without a fixed scale, so values keep the digits they
+-- were written with.
+ALTER TABLE millionaires ALTER COLUMN net_worth TYPE NUMERIC;
+ALTER TABLE net_worth_history ALTER COLUMN net_worth TYPE NUMERIC;
+ALTER TABLE holdings ALTER COLUMN value TYPE NUMERIC;
+ALTER TABLE exchange_rates ALTER COLUMN rate TYPE NUMERIC;
@@ -0,0 +1,23 @@
+-- Sections of the homepage, in position order. Top, newcomers, gainers and
+-- losers list up to size millionaires, optionally only those in countries
+-- or industries (codes; an industry includes the ones below it). Gainers and
+-- losers compare net worth with window_days ago. Featured lists
+-- millionaire_ids in their order; deleted millionaires drop out.
+CREATE TABLE IF NOT EXISTS home_sections (
+    id SERIAL PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0 CHECK (position >= 0),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('top', 'newcomers', 'gainers', 'losers', 'featured')),
+    title VARCHAR(200) NOT NULL,
+    size INTEGER NOT NULL DEFAULT 10 CHECK (size BETWEEN 1 AND 100),
+    countries TEXT[] NOT NULL DEFAULT '{}',
+    industries TEXT[] NOT NULL DEFAULT '{}',
+    window_days INTEGER CHECK (window_days > 0),
+    millionaire_ids INTEGER[] NOT NULL DEFAULT '{}',
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (kind NOT IN ('gainers', 'losers') OR window_days IS NOT NULL)
+);
+
+-- The homepage as it was: the global top 10.
+INSERT INTO home_sections (position, kind, title, size) VALUES (1, 'top', 'Top 10', 10);
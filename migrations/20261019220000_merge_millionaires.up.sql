@@ -0,0 +1,22 @@
+-- A millionaire merged into another leaves a redirect from its ID. Redirects
+-- go straight to the surviving millionaire and disappear with it.
+CREATE TABLE IF NOT EXISTS millionaire_redirects (
+    old_id INTEGER PRIMARY KEY,
+    new_id INTEGER NOT NULL REFERENCES millionaires(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_millionaire_redirects_new_id ON millionaire_redirects (new_id);
+
+-- Changes worth keeping a record of, such as merges. Details hold what is
+-- needed to understand or undo the change.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id SERIAL PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(100) NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log (action, created_at);
@@ -2,54 +2,259 @@ package migrations
 
 import (
 	"database/sql"
+	"embed"
 	"fmt"
+	"io/fs"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
 )
 
-func RunMigrationUp(db *sql.DB) error {
+//go:embed *.sql
+var files embed.FS
+
+// Migration is a pair of <version>_<name>.up.sql / .down.sql files.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load returns the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		name := e.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionStr, migrationName, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration file %s: expected <version>_<name>.%s.sql", name, direction)
+		}
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration file %s: invalid version: %w", name, err)
+		}
+
+		body, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: migrationName}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// LatestVersion is the schema version this binary expects.
+func LatestVersion() (int64, error) {
+	migrations, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+func ensureVersionTable(db *sql.DB) error {
+	_, err := db.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// CurrentVersion returns the highest applied migration version, or 0 for an
+// empty database.
+func CurrentVersion(db *sql.DB) (int64, error) {
+	exists, err := versionTableExists(db)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version.Int64, nil
+}
+
+func versionTableExists(db *sql.DB) (bool, error) {
 	var exists bool
 	err := db.QueryRow(`
 		SELECT EXISTS (
 			SELECT 1
 			FROM information_schema.tables
-			WHERE table_name = 'millionaires'
+			WHERE table_name = 'schema_migrations'
 		);
 	`).Scan(&exists)
 	if err != nil {
-		return fmt.Errorf("failed to check if table exists: %w", err)
+		return false, fmt.Errorf("failed to check if table exists: %w", err)
 	}
+	return exists, nil
+}
 
-	if exists {
-		log.Println("Table 'millionaires' already exists, skipping creation")
-		return nil
+// Pending returns the embedded migrations that have not been applied,
+// ordered by version. A migration older than the newest applied one, e.g.
+// from a branch merged late, is pending too.
+func Pending(db *sql.DB) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := versionTableExists(db)
+	if err != nil || !exists {
+		return migrations, err
 	}
 
-	upSQL := `
-	CREATE TABLE millionaires (
-		id SERIAL PRIMARY KEY,
-		last_name VARCHAR(500) NOT NULL,
-		first_name VARCHAR(500) NOT NULL,
-		middle_name VARCHAR(500),
-		birth_date DATE,
-		birth_place TEXT,
-		net_worth BIGINT NOT NULL,
-		industry TEXT,
-		country TEXT,
-		company TEXT,
-		biography TEXT,
-		path_to_photo TEXT,
-		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
-	);
-	`
-	_, err = db.Exec(upSQL)
-	return err
+	rows, err := db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return pending(migrations, applied), nil
+}
+
+// pending returns the migrations whose versions are not in applied, keeping
+// their order.
+func pending(migrations []Migration, applied map[int64]bool) []Migration {
+	var missing []Migration
+	for _, m := range migrations {
+		if !applied[m.Version] {
+			missing = append(missing, m)
+		}
+	}
+	return missing
 }
 
+// RunMigrationUp applies every pending migration in version order, each in
+// its own transaction.
+func RunMigrationUp(db *sql.DB) error {
+	if err := ensureVersionTable(db); err != nil {
+		return err
+	}
+
+	migrations, err := Pending(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		log.Printf("Applying migration %d_%s", m.Version, m.Name)
+		if err := apply(db, m.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// RunMigrationDown rolls back the most recently applied migration.
 func RunMigrationDown(db *sql.DB) error {
-	downSQL := `
-	DROP TABLE IF EXISTS millionaires;
-	`
-	_, err := db.Exec(downSQL)
-	return err
+	if err := ensureVersionTable(db); err != nil {
+		return err
+	}
+
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	current, err := CurrentVersion(db)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		log.Println("No migrations applied, nothing to roll back")
+		return nil
+	}
+
+	for _, m := range migrations {
+		if m.Version != current {
+			continue
+		}
+
+		log.Printf("Rolling back migration %d_%s", m.Version, m.Name)
+		if err := apply(db, m.Down, `DELETE FROM schema_migrations WHERE version = $1`, m.Version); err != nil {
+			return fmt.Errorf("rollback of %d_%s failed: %w", m.Version, m.Name, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("applied version %d has no migration file", current)
+}
+
+func apply(db *sql.DB, script string, bookkeeping string, args ...interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if strings.TrimSpace(script) != "" {
+		if _, err := tx.Exec(script); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(bookkeeping, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	for i, m := range migrations {
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			t.Errorf("%d_%s comes after %d", m.Version, m.Name, migrations[i-1].Version)
+		}
+		if m.Down == "" {
+			t.Errorf("%d_%s has no down script", m.Version, m.Name)
+		}
+	}
+}
+
+func TestPendingOutOfOrder(t *testing.T) {
+	migrations := []Migration{
+		{Version: 20261019100000, Name: "first"},
+		{Version: 20261019110000, Name: "merged_late"},
+		{Version: 20261019120000, Name: "newest"},
+		{Version: 20261019130000, Name: "unreleased"},
+	}
+	// The newest is applied, but the one merged after it has an older
+	// timestamp; both it and what follows still have to run, in order.
+	applied := map[int64]bool{20261019100000: true, 20261019120000: true}
+
+	var got []string
+	for _, m := range pending(migrations, applied) {
+		got = append(got, m.Name)
+	}
+	if want := []string{"merged_late", "unreleased"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pending: %v, want %v", got, want)
+	}
+
+	if got := pending(migrations, map[int64]bool{
+		20261019100000: true, 20261019110000: true, 20261019120000: true, 20261019130000: true,
+	}); len(got) != 0 {
+		t.Errorf("pending with everything applied: %v", got)
+	}
+}
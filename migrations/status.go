@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// versionLayout is used for migrations created by Create.
+const versionLayout = "20060102150405"
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// MigrationStatus reports whether a migration has been applied. Migrations
+// recorded in the database without a matching file have Missing set.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Missing   bool
+}
+
+// Status lists the embedded migrations and the applied ones, ordered by
+// version.
+func Status(db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureVersionTable(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`SELECT version, name, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	byVersion := make(map[int64]*MigrationStatus)
+	for _, m := range migrations {
+		byVersion[m.Version] = &MigrationStatus{Version: m.Version, Name: m.Name}
+	}
+
+	for rows.Next() {
+		var (
+			version   int64
+			name      string
+			appliedAt time.Time
+		)
+		if err := rows.Scan(&version, &name, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		s, ok := byVersion[version]
+		if !ok {
+			s = &MigrationStatus{Version: version, Name: name, Missing: true}
+			byVersion[version] = s
+		}
+		s.Applied = true
+		s.AppliedAt = &appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(byVersion))
+	for _, s := range byVersion {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// Create writes empty up and down scripts for a new migration to dir and
+// returns their paths. The version is the current UTC timestamp.
+func Create(dir, name string) ([]string, error) {
+	if !migrationName.MatchString(name) {
+		return nil, fmt.Errorf("migration name %q: use lowercase letters, digits and underscores", name)
+	}
+
+	base := time.Now().UTC().Format(versionLayout) + "_" + name
+	paths := []string{
+		filepath.Join(dir, base+".up.sql"),
+		filepath.Join(dir, base+".down.sql"),
+	}
+
+	for _, p := range paths {
+		f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}